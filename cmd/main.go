@@ -5,6 +5,7 @@ import (
 	"autotm-admin/internal/configs"
 	"autotm-admin/internal/handlers"
 	"autotm-admin/internal/migrations"
+	"autotm-admin/internal/userstub"
 	"context"
 	_ "github.com/lib/pq"
 	"github.com/rs/cors"
@@ -61,7 +62,17 @@ func main() {
 	} else {
 		logger.Info("Migration flag is disabled; skipping migrations.")
 	}
-	router := handlers.Manager(logger, psqlClient, cfg)
+
+	if cfg.UserServiceStub {
+		userStub, err := userstub.Start(logger)
+		if err != nil {
+			logger.Fatalf("Failed to start user service stub: %v", err)
+		}
+		defer userStub.Close()
+		cfg.UserServiceURL = userStub.URL
+		logger.Info("Using user service stub at ", userStub.URL)
+	}
+	router := handlers.Manager(ctx, logger, psqlClient, cfg)
 
 	router.Get("/autotm-admin/swagger/*", httpSwagger.WrapHandler)
 
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"autotm-admin/internal/configs"
+	"autotm-admin/internal/migrations"
+	"autotm-admin/internal/repository"
+	"autotm-admin/internal/services"
+	"context"
+	_ "embed"
+	_ "github.com/lib/pq"
+	slog "github.com/salamsites/package-log"
+	spsql "github.com/salamsites/package-psql"
+	"os/signal"
+	"syscall"
+)
+
+// catalog holds the standard categories, brands, models, body types,
+// velayats and cities loaded into a fresh environment.
+//
+//go:embed catalog.json
+var catalog []byte
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	cfg := configs.GetConfig()
+	logger := slog.GetLogger(cfg.Log.Path, cfg.Log.Filename)
+
+	psqlClient, err := spsql.NewClient(ctx,
+		spsql.Options{
+			Host:          cfg.Storage.Psql.Host,
+			Port:          cfg.Storage.Psql.Port,
+			Database:      cfg.Storage.Psql.Database,
+			Username:      cfg.Storage.Psql.Username,
+			Password:      cfg.Storage.Psql.Password,
+			PgPoolMaxConn: cfg.Storage.Psql.PgPoolMaxConn,
+		})
+	if err != nil {
+		logger.Errorf("psql client does not connect: %v", err)
+		panic("psql client does not connect")
+	}
+	defer psqlClient.Close()
+
+	if cfg.Storage.Psql.Migration {
+		if err = migrations.RunMigrations(logger, psqlClient.StdDB()); err != nil {
+			logger.Fatalf("Failed to apply migrations: %v", err)
+		}
+	}
+
+	seedService := services.NewSeedService(logger, repository.NewSeedPsqlRepository(logger, psqlClient))
+	result, err := seedService.SeedCatalog(ctx, catalog)
+	if err != nil {
+		logger.Fatalf("Failed to seed catalog: %v", err)
+	}
+
+	for table, count := range result.Created {
+		logger.Infof("seed: %s created %d", table, count)
+	}
+	logger.Info("seed catalog completed")
+}
@@ -0,0 +1,87 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS categories (
+                "id" SERIAL PRIMARY KEY,
+                "slug" CHARACTER VARYING(100) NOT NULL UNIQUE,
+                "name_tm" CHARACTER VARYING(255) NOT NULL,
+                "name_en" CHARACTER VARYING(255) NOT NULL,
+                "name_ru" CHARACTER VARYING(255) NOT NULL,
+                "icon_path" CHARACTER VARYING(255),
+                "is_active" BOOLEAN NOT NULL DEFAULT TRUE,
+                "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+                "updated_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO categories (slug, name_tm, name_en, name_ru) VALUES
+    ('auto', 'Awtoulaglar', 'Cars', 'Легковые'),
+    ('moto', 'Motosikller', 'Motorcycles', 'Мотоциклы'),
+    ('truck', 'Ýük awtoulaglary', 'Trucks', 'Грузовики')
+ON CONFLICT (slug) DO NOTHING;
+
+-- body_types
+ALTER TABLE body_types ADD COLUMN IF NOT EXISTS "category_id" INTEGER;
+UPDATE body_types bt SET category_id = c.id FROM categories c WHERE c.slug = bt.category::text;
+-- body types saved without a category were shown for cars
+UPDATE body_types SET category_id = (SELECT id FROM categories WHERE slug = 'auto') WHERE category_id IS NULL;
+ALTER TABLE body_types DROP COLUMN IF EXISTS "category";
+ALTER TABLE body_types ALTER COLUMN category_id SET NOT NULL;
+ALTER TABLE body_types
+    ADD CONSTRAINT body_types_category_id_fk
+        FOREIGN KEY (category_id)
+            REFERENCES categories(id)
+                ON UPDATE CASCADE ON DELETE RESTRICT;
+
+-- brand_categories
+ALTER TABLE brand_categories ADD COLUMN IF NOT EXISTS "category_id" INTEGER;
+UPDATE brand_categories bc SET category_id = c.id FROM categories c WHERE c.slug = bc.category::text;
+ALTER TABLE brand_categories DROP CONSTRAINT IF EXISTS brand_categories_pkey;
+ALTER TABLE brand_categories DROP COLUMN IF EXISTS "category";
+ALTER TABLE brand_categories ALTER COLUMN category_id SET NOT NULL;
+ALTER TABLE brand_categories ADD PRIMARY KEY (brand_id, category_id);
+ALTER TABLE brand_categories
+    ADD CONSTRAINT brand_categories_category_id_fk
+        FOREIGN KEY (category_id)
+            REFERENCES categories(id)
+                ON UPDATE CASCADE ON DELETE RESTRICT;
+
+-- models
+ALTER TABLE models ADD COLUMN IF NOT EXISTS "category_id" INTEGER;
+UPDATE models m SET category_id = c.id FROM categories c WHERE c.slug = m.category::text;
+ALTER TABLE models DROP COLUMN IF EXISTS "category";
+ALTER TABLE models ALTER COLUMN category_id SET NOT NULL;
+ALTER TABLE models
+    ADD CONSTRAINT models_category_id_fk
+        FOREIGN KEY (category_id)
+            REFERENCES categories(id)
+                ON UPDATE CASCADE ON DELETE RESTRICT;
+
+DROP TYPE IF EXISTS category_type;
+
+
+-- +goose Down
+CREATE TYPE category_type AS ENUM ('auto', 'moto', 'truck');
+
+ALTER TABLE models ADD COLUMN IF NOT EXISTS "category" category_type;
+UPDATE models m SET category = c.slug::category_type FROM categories c
+    WHERE c.id = m.category_id AND c.slug IN ('auto', 'moto', 'truck');
+DELETE FROM models WHERE category IS NULL;
+ALTER TABLE models DROP CONSTRAINT IF EXISTS models_category_id_fk;
+ALTER TABLE models DROP COLUMN IF EXISTS "category_id";
+ALTER TABLE models ALTER COLUMN category SET NOT NULL;
+
+ALTER TABLE brand_categories ADD COLUMN IF NOT EXISTS "category" category_type;
+UPDATE brand_categories bc SET category = c.slug::category_type FROM categories c
+    WHERE c.id = bc.category_id AND c.slug IN ('auto', 'moto', 'truck');
+DELETE FROM brand_categories WHERE category IS NULL;
+ALTER TABLE brand_categories DROP CONSTRAINT IF EXISTS brand_categories_pkey;
+ALTER TABLE brand_categories DROP CONSTRAINT IF EXISTS brand_categories_category_id_fk;
+ALTER TABLE brand_categories DROP COLUMN IF EXISTS "category_id";
+ALTER TABLE brand_categories ALTER COLUMN category SET NOT NULL;
+ALTER TABLE brand_categories ADD PRIMARY KEY (brand_id, category);
+
+ALTER TABLE body_types ADD COLUMN IF NOT EXISTS "category" category_type;
+UPDATE body_types bt SET category = c.slug::category_type FROM categories c
+    WHERE c.id = bt.category_id AND c.slug IN ('auto', 'moto', 'truck');
+ALTER TABLE body_types DROP CONSTRAINT IF EXISTS body_types_category_id_fk;
+ALTER TABLE body_types DROP COLUMN IF EXISTS "category_id";
+
+DROP TABLE IF EXISTS categories;
@@ -0,0 +1,45 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS translations (
+                "entity_type" CHARACTER VARYING(50) NOT NULL,
+                "entity_id" BIGINT NOT NULL,
+                "field" CHARACTER VARYING(50) NOT NULL,
+                "locale" CHARACTER VARYING(10) NOT NULL,
+                "value" TEXT NOT NULL,
+                "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+                "updated_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+                PRIMARY KEY (entity_type, entity_id, field, locale)
+);
+
+INSERT INTO translations (entity_type, entity_id, field, locale, value)
+SELECT 'categories', c.id, 'name', l.locale, l.value
+FROM categories c, LATERAL (VALUES ('tm', c.name_tm), ('en', c.name_en), ('ru', c.name_ru)) AS l(locale, value)
+WHERE COALESCE(l.value, '') <> ''
+ON CONFLICT DO NOTHING;
+
+INSERT INTO translations (entity_type, entity_id, field, locale, value)
+SELECT 'body_types', bt.id, 'name', l.locale, l.value
+FROM body_types bt, LATERAL (VALUES ('tm', bt.name_tm), ('en', bt.name_en), ('ru', bt.name_ru)) AS l(locale, value)
+WHERE COALESCE(l.value, '') <> ''
+ON CONFLICT DO NOTHING;
+
+INSERT INTO translations (entity_type, entity_id, field, locale, value)
+SELECT 'regions', r.id, 'name', l.locale, l.value
+FROM regions r, LATERAL (VALUES ('tm', r.name_tm), ('en', r.name_en), ('ru', r.name_ru)) AS l(locale, value)
+WHERE COALESCE(l.value, '') <> ''
+ON CONFLICT DO NOTHING;
+
+INSERT INTO translations (entity_type, entity_id, field, locale, value)
+SELECT 'cities', c.id, 'name', l.locale, l.value
+FROM cities c, LATERAL (VALUES ('tm', c.name_tm), ('en', c.name_en), ('ru', c.name_ru)) AS l(locale, value)
+WHERE COALESCE(l.value, '') <> ''
+ON CONFLICT DO NOTHING;
+
+INSERT INTO translations (entity_type, entity_id, field, locale, value)
+SELECT 'sliders', s.id, 'image_path', l.locale, l.value
+FROM sliders s, LATERAL (VALUES ('tm', s.image_path_tm), ('en', s.image_path_en), ('ru', s.image_path_ru)) AS l(locale, value)
+WHERE COALESCE(l.value, '') <> ''
+ON CONFLICT DO NOTHING;
+
+
+-- +goose Down
+DROP TABLE IF EXISTS translations;
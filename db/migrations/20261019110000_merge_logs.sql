@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS merge_logs (
+                "id" SERIAL PRIMARY KEY,
+                "entity_type" CHARACTER VARYING(50) NOT NULL,
+                "source_id" BIGINT NOT NULL,
+                "source_name" CHARACTER VARYING(255) NOT NULL,
+                "target_id" BIGINT NOT NULL,
+                "target_name" CHARACTER VARYING(255) NOT NULL,
+                "repointed" JSONB NOT NULL DEFAULT '{}'::jsonb,
+                "merged" JSONB NOT NULL DEFAULT '{}'::jsonb,
+                "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS merge_logs_entity_idx ON merge_logs (entity_type, target_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS merge_logs;
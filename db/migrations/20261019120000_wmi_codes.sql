@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS wmi_codes (
+                "id" SERIAL PRIMARY KEY,
+                "code" CHARACTER VARYING(3) NOT NULL UNIQUE,
+                "brand_id" INTEGER NOT NULL,
+                "manufacturer" CHARACTER VARYING(255) NOT NULL,
+                "country" CHARACTER VARYING(100),
+                "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+                "updated_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+                CONSTRAINT brand_id_fk
+                    FOREIGN KEY (brand_id)
+                        REFERENCES brands(id)
+                            ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS wmi_codes_brand_id_idx ON wmi_codes (brand_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS wmi_codes;
@@ -0,0 +1,29 @@
+-- +goose Up
+ALTER TABLE cities
+    ADD COLUMN IF NOT EXISTS "latitude" DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS "longitude" DOUBLE PRECISION,
+    ADD CONSTRAINT cities_latitude_check CHECK (latitude BETWEEN -90 AND 90),
+    ADD CONSTRAINT cities_longitude_check CHECK (longitude BETWEEN -180 AND 180);
+
+ALTER TABLE auto_stores
+    ADD COLUMN IF NOT EXISTS "latitude" DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS "longitude" DOUBLE PRECISION,
+    ADD CONSTRAINT auto_stores_latitude_check CHECK (latitude BETWEEN -90 AND 90),
+    ADD CONSTRAINT auto_stores_longitude_check CHECK (longitude BETWEEN -180 AND 180);
+
+CREATE INDEX IF NOT EXISTS auto_stores_latitude_idx ON auto_stores (latitude) WHERE latitude IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS auto_stores_latitude_idx;
+
+ALTER TABLE auto_stores
+    DROP CONSTRAINT IF EXISTS auto_stores_longitude_check,
+    DROP CONSTRAINT IF EXISTS auto_stores_latitude_check,
+    DROP COLUMN IF EXISTS "longitude",
+    DROP COLUMN IF EXISTS "latitude";
+
+ALTER TABLE cities
+    DROP CONSTRAINT IF EXISTS cities_longitude_check,
+    DROP CONSTRAINT IF EXISTS cities_latitude_check,
+    DROP COLUMN IF EXISTS "longitude",
+    DROP COLUMN IF EXISTS "latitude";
@@ -0,0 +1,43 @@
+-- +goose Up
+ALTER TABLE cities DROP CONSTRAINT IF EXISTS region_id_fk;
+ALTER TABLE cities
+    ADD CONSTRAINT region_id_fk
+        FOREIGN KEY (region_id)
+            REFERENCES regions(id)
+                ON UPDATE CASCADE ON DELETE RESTRICT;
+
+ALTER TABLE auto_stores DROP CONSTRAINT IF EXISTS region_id_fk;
+ALTER TABLE auto_stores
+    ADD CONSTRAINT region_id_fk
+        FOREIGN KEY (region_id)
+            REFERENCES regions(id)
+                ON UPDATE CASCADE ON DELETE RESTRICT;
+
+ALTER TABLE auto_stores DROP CONSTRAINT IF EXISTS city_id_fk;
+ALTER TABLE auto_stores
+    ADD CONSTRAINT city_id_fk
+        FOREIGN KEY (city_id)
+            REFERENCES cities(id)
+                ON UPDATE CASCADE ON DELETE RESTRICT;
+
+-- +goose Down
+ALTER TABLE auto_stores DROP CONSTRAINT IF EXISTS city_id_fk;
+ALTER TABLE auto_stores
+    ADD CONSTRAINT city_id_fk
+        FOREIGN KEY (city_id)
+            REFERENCES cities(id)
+                ON UPDATE CASCADE ON DELETE SET NULL;
+
+ALTER TABLE auto_stores DROP CONSTRAINT IF EXISTS region_id_fk;
+ALTER TABLE auto_stores
+    ADD CONSTRAINT region_id_fk
+        FOREIGN KEY (region_id)
+            REFERENCES regions(id)
+                ON UPDATE CASCADE ON DELETE SET NULL;
+
+ALTER TABLE cities DROP CONSTRAINT IF EXISTS region_id_fk;
+ALTER TABLE cities
+    ADD CONSTRAINT region_id_fk
+        FOREIGN KEY (region_id)
+            REFERENCES regions(id)
+                ON UPDATE CASCADE ON DELETE SET NULL;
@@ -0,0 +1,31 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS districts (
+                "id" SERIAL PRIMARY KEY,
+                "name_tm" CHARACTER VARYING(255) NOT NULL,
+                "name_en" CHARACTER VARYING(255) NOT NULL,
+                "name_ru" CHARACTER VARYING(255) NOT NULL,
+                "city_id" INTEGER NOT NULL,
+                "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                "updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                CONSTRAINT city_id_fk
+                    FOREIGN KEY (city_id)
+                        REFERENCES cities(id)
+                            ON UPDATE CASCADE ON DELETE RESTRICT
+);
+
+CREATE INDEX IF NOT EXISTS districts_city_id_idx ON districts (city_id);
+
+ALTER TABLE auto_stores
+    ADD COLUMN IF NOT EXISTS "district_id" INTEGER,
+    ADD CONSTRAINT district_id_fk
+        FOREIGN KEY (district_id)
+            REFERENCES districts(id)
+                ON UPDATE CASCADE ON DELETE RESTRICT;
+
+-- +goose Down
+ALTER TABLE auto_stores
+    DROP CONSTRAINT IF EXISTS district_id_fk,
+    DROP COLUMN IF EXISTS "district_id";
+
+DELETE FROM translations WHERE entity_type = 'districts';
+DROP TABLE IF EXISTS districts;
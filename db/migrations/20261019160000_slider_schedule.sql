@@ -0,0 +1,27 @@
+-- +goose Up
+ALTER TABLE sliders
+    ADD COLUMN IF NOT EXISTS "starts_at" TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS "ends_at" TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS "is_active" BOOLEAN NOT NULL DEFAULT TRUE,
+    ADD COLUMN IF NOT EXISTS "position" INTEGER NOT NULL DEFAULT 0,
+    ADD CONSTRAINT sliders_schedule_check CHECK (ends_at IS NULL OR starts_at IS NULL OR ends_at > starts_at);
+
+-- keep the current newest-first order as the initial position per platform
+UPDATE sliders s SET position = o.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY platform ORDER BY created_at DESC, id DESC) - 1 AS position
+    FROM sliders
+) o
+WHERE s.id = o.id;
+
+CREATE INDEX IF NOT EXISTS sliders_platform_position_idx ON sliders (platform, position);
+
+-- +goose Down
+DROP INDEX IF EXISTS sliders_platform_position_idx;
+
+ALTER TABLE sliders
+    DROP CONSTRAINT IF EXISTS sliders_schedule_check,
+    DROP COLUMN IF EXISTS "position",
+    DROP COLUMN IF EXISTS "is_active",
+    DROP COLUMN IF EXISTS "ends_at",
+    DROP COLUMN IF EXISTS "starts_at";
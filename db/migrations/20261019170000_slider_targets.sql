@@ -0,0 +1,25 @@
+-- +goose Up
+ALTER TABLE sliders
+    ADD COLUMN IF NOT EXISTS "target_type" CHARACTER VARYING(20) NOT NULL DEFAULT 'none',
+    ADD COLUMN IF NOT EXISTS "target_id" INTEGER,
+    ADD COLUMN IF NOT EXISTS "target_url" TEXT,
+    ADD COLUMN IF NOT EXISTS "target_screen" CHARACTER VARYING(100),
+    ADD CONSTRAINT sliders_target_type_check
+        CHECK (target_type IN ('none', 'url', 'brand', 'model', 'auto_store', 'screen')),
+    ADD CONSTRAINT sliders_target_id_check
+        CHECK ((target_type IN ('brand', 'model', 'auto_store')) = (target_id IS NOT NULL));
+
+CREATE INDEX IF NOT EXISTS sliders_target_idx ON sliders (target_type, target_id) WHERE target_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS sliders_target_idx;
+
+DELETE FROM translations WHERE entity_type = 'sliders' AND field = 'target_url';
+
+ALTER TABLE sliders
+    DROP CONSTRAINT IF EXISTS sliders_target_id_check,
+    DROP CONSTRAINT IF EXISTS sliders_target_type_check,
+    DROP COLUMN IF EXISTS "target_screen",
+    DROP COLUMN IF EXISTS "target_url",
+    DROP COLUMN IF EXISTS "target_id",
+    DROP COLUMN IF EXISTS "target_type";
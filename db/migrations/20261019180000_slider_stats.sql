@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS slider_stats (
+                "slider_id" INTEGER NOT NULL,
+                "platform" CHARACTER VARYING(100) NOT NULL,
+                "day" DATE NOT NULL,
+                "impressions" BIGINT NOT NULL DEFAULT 0,
+                "clicks" BIGINT NOT NULL DEFAULT 0,
+                PRIMARY KEY (slider_id, platform, day),
+                CONSTRAINT slider_id_fk
+                    FOREIGN KEY (slider_id)
+                        REFERENCES sliders(id)
+                            ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS slider_stats_day_idx ON slider_stats (day);
+
+-- +goose Down
+DROP TABLE IF EXISTS slider_stats;
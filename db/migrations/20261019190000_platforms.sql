@@ -0,0 +1,49 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS platforms (
+                "id" SERIAL PRIMARY KEY,
+                "code" CHARACTER VARYING(50) NOT NULL UNIQUE,
+                "name" CHARACTER VARYING(255) NOT NULL,
+                "aspect_width" INTEGER NOT NULL DEFAULT 0,
+                "aspect_height" INTEGER NOT NULL DEFAULT 0,
+                "min_width" INTEGER NOT NULL DEFAULT 0,
+                "min_height" INTEGER NOT NULL DEFAULT 0,
+                "is_active" BOOLEAN NOT NULL DEFAULT TRUE,
+                "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                "updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                CONSTRAINT platforms_aspect_check CHECK ((aspect_width = 0) = (aspect_height = 0))
+);
+
+INSERT INTO platforms (code, name, aspect_width, aspect_height, min_width, min_height) VALUES
+    ('web', 'Web', 3, 1, 1200, 400),
+    ('android', 'Android', 2, 1, 720, 360),
+    ('ios', 'iOS', 2, 1, 750, 375)
+ON CONFLICT (code) DO NOTHING;
+
+-- fold spelling variants such as "iOS" or "web " into one code
+UPDATE sliders SET platform = lower(btrim(platform)) WHERE platform <> lower(btrim(platform));
+
+WITH moved AS (
+    DELETE FROM slider_stats WHERE platform <> lower(btrim(platform)) RETURNING *
+)
+INSERT INTO slider_stats (slider_id, platform, day, impressions, clicks)
+SELECT slider_id, lower(btrim(platform)), day, SUM(impressions), SUM(clicks)
+FROM moved
+GROUP BY slider_id, lower(btrim(platform)), day
+ON CONFLICT (slider_id, platform, day)
+DO UPDATE SET impressions = slider_stats.impressions + EXCLUDED.impressions,
+              clicks = slider_stats.clicks + EXCLUDED.clicks;
+
+-- keep sliders of platforms that are not seeded above; they get no image requirements
+INSERT INTO platforms (code, name)
+SELECT DISTINCT platform, platform FROM sliders
+ON CONFLICT (code) DO NOTHING;
+
+ALTER TABLE sliders
+    ADD CONSTRAINT platform_fk
+        FOREIGN KEY (platform)
+            REFERENCES platforms(code)
+                ON UPDATE CASCADE ON DELETE RESTRICT;
+
+-- +goose Down
+ALTER TABLE sliders DROP CONSTRAINT IF EXISTS platform_fk;
+DROP TABLE IF EXISTS platforms;
@@ -0,0 +1,36 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS slider_variants (
+                "id" SERIAL PRIMARY KEY,
+                "slider_id" INTEGER NOT NULL,
+                "name" CHARACTER VARYING(100) NOT NULL,
+                "weight" INTEGER NOT NULL DEFAULT 1 CHECK (weight >= 0),
+                "image_path_tm" CHARACTER VARYING(255) NOT NULL,
+                "image_path_en" CHARACTER VARYING(255) NOT NULL,
+                "image_path_ru" CHARACTER VARYING(255) NOT NULL,
+                "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                "updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                CONSTRAINT slider_id_fk
+                    FOREIGN KEY (slider_id)
+                        REFERENCES sliders(id)
+                            ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS slider_variants_slider_id_idx ON slider_variants (slider_id);
+
+-- variant 0 is the slider's own creative; variant ids are kept without a foreign
+-- key so the stats of a removed variant survive its promotion or deletion
+ALTER TABLE slider_stats
+    ADD COLUMN IF NOT EXISTS "variant_id" INTEGER NOT NULL DEFAULT 0,
+    DROP CONSTRAINT IF EXISTS slider_stats_pkey,
+    ADD PRIMARY KEY (slider_id, variant_id, platform, day);
+
+-- +goose Down
+DELETE FROM slider_stats WHERE variant_id <> 0;
+
+ALTER TABLE slider_stats
+    DROP CONSTRAINT IF EXISTS slider_stats_pkey,
+    DROP COLUMN IF EXISTS "variant_id",
+    ADD PRIMARY KEY (slider_id, platform, day);
+
+DELETE FROM translations WHERE entity_type = 'slider_variants';
+DROP TABLE IF EXISTS slider_variants;
@@ -0,0 +1,31 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS auto_store_hours (
+                "id" SERIAL PRIMARY KEY,
+                "auto_store_id" INTEGER NOT NULL,
+                "weekday" SMALLINT NOT NULL CHECK (weekday BETWEEN 1 AND 7),
+                "opens_at" TIME NOT NULL,
+                "closes_at" TIME NOT NULL,
+                CONSTRAINT auto_store_hours_interval_check CHECK (closes_at > opens_at),
+                CONSTRAINT auto_store_id_fk
+                    FOREIGN KEY (auto_store_id)
+                        REFERENCES auto_stores(id)
+                            ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS auto_store_hours_auto_store_id_idx ON auto_store_hours (auto_store_id, weekday);
+
+CREATE TABLE IF NOT EXISTS auto_store_closures (
+                "id" SERIAL PRIMARY KEY,
+                "auto_store_id" INTEGER NOT NULL,
+                "date" DATE NOT NULL,
+                "reason" CHARACTER VARYING(255) NOT NULL DEFAULT '',
+                CONSTRAINT auto_store_closures_date_key UNIQUE (auto_store_id, date),
+                CONSTRAINT auto_store_id_fk
+                    FOREIGN KEY (auto_store_id)
+                        REFERENCES auto_stores(id)
+                            ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS auto_store_closures;
+DROP TABLE IF EXISTS auto_store_hours;
@@ -0,0 +1,52 @@
+-- +goose Up
+-- stores published before the review workflow existed stay visible
+ALTER TABLE auto_stores
+    ADD COLUMN IF NOT EXISTS "status" CHARACTER VARYING(20) NOT NULL DEFAULT 'approved',
+    ADD COLUMN IF NOT EXISTS "rejection_reason" TEXT,
+    ADD COLUMN IF NOT EXISTS "reviewed_by" INTEGER,
+    ADD COLUMN IF NOT EXISTS "reviewed_at" TIMESTAMP,
+    ADD CONSTRAINT auto_stores_status_check
+        CHECK (status IN ('pending', 'approved', 'rejected', 'suspended')),
+    ADD CONSTRAINT reviewed_by_fk
+        FOREIGN KEY (reviewed_by)
+            REFERENCES users(id)
+                ON UPDATE CASCADE ON DELETE SET NULL;
+
+ALTER TABLE auto_stores ALTER COLUMN "status" SET DEFAULT 'pending';
+
+CREATE INDEX IF NOT EXISTS auto_stores_status_idx ON auto_stores (status);
+
+CREATE TABLE IF NOT EXISTS auto_store_status_history (
+                "id" SERIAL PRIMARY KEY,
+                "auto_store_id" INTEGER NOT NULL,
+                "from_status" CHARACTER VARYING(20),
+                "to_status" CHARACTER VARYING(20) NOT NULL,
+                "reason" TEXT,
+                "changed_by" INTEGER,
+                "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                CONSTRAINT auto_store_id_fk
+                    FOREIGN KEY (auto_store_id)
+                        REFERENCES auto_stores(id)
+                            ON UPDATE CASCADE ON DELETE CASCADE,
+                CONSTRAINT changed_by_fk
+                    FOREIGN KEY (changed_by)
+                        REFERENCES users(id)
+                            ON UPDATE CASCADE ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS auto_store_status_history_auto_store_id_idx ON auto_store_status_history (auto_store_id, created_at);
+
+INSERT INTO auto_store_status_history (auto_store_id, from_status, to_status)
+SELECT id, NULL, status FROM auto_stores;
+
+-- +goose Down
+DROP TABLE IF EXISTS auto_store_status_history;
+DROP INDEX IF EXISTS auto_stores_status_idx;
+
+ALTER TABLE auto_stores
+    DROP CONSTRAINT IF EXISTS reviewed_by_fk,
+    DROP CONSTRAINT IF EXISTS auto_stores_status_check,
+    DROP COLUMN IF EXISTS "reviewed_at",
+    DROP COLUMN IF EXISTS "reviewed_by",
+    DROP COLUMN IF EXISTS "rejection_reason",
+    DROP COLUMN IF EXISTS "status";
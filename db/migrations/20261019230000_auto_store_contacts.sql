@@ -0,0 +1,32 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS auto_store_contacts (
+                "id" SERIAL PRIMARY KEY,
+                "auto_store_id" INTEGER NOT NULL,
+                "type" CHARACTER VARYING(20) NOT NULL,
+                "value" CHARACTER VARYING(255) NOT NULL,
+                "position" INTEGER NOT NULL,
+                "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                CONSTRAINT auto_store_id_fk
+                    FOREIGN KEY (auto_store_id)
+                        REFERENCES auto_stores(id)
+                            ON UPDATE CASCADE ON DELETE CASCADE,
+                CONSTRAINT auto_store_contacts_type_check
+                    CHECK (type IN ('phone', 'whatsapp', 'telegram', 'imo', 'instagram', 'email', 'website')),
+                CONSTRAINT auto_store_contacts_position_key UNIQUE (auto_store_id, position),
+                CONSTRAINT auto_store_contacts_value_key UNIQUE (auto_store_id, type, value)
+);
+
+-- the single phone number and email become the first contacts of each store
+INSERT INTO auto_store_contacts (auto_store_id, type, value, position)
+SELECT id, 'phone', btrim(phone_number), 1
+FROM auto_stores
+WHERE btrim(COALESCE(phone_number, '')) <> '';
+
+INSERT INTO auto_store_contacts (auto_store_id, type, value, position)
+SELECT ast.id, 'email', lower(btrim(ast.email)),
+       (SELECT COUNT(*) + 1 FROM auto_store_contacts c WHERE c.auto_store_id = ast.id)
+FROM auto_stores ast
+WHERE btrim(COALESCE(ast.email, '')) <> '';
+
+-- +goose Down
+DROP TABLE IF EXISTS auto_store_contacts;
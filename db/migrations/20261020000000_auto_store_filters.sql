@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE INDEX IF NOT EXISTS auto_stores_region_id_idx ON auto_stores (region_id);
+CREATE INDEX IF NOT EXISTS auto_stores_city_id_idx ON auto_stores (city_id);
+CREATE INDEX IF NOT EXISTS auto_stores_user_id_idx ON auto_stores (user_id);
+CREATE INDEX IF NOT EXISTS auto_stores_created_at_idx ON auto_stores (created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS auto_stores_created_at_idx;
+DROP INDEX IF EXISTS auto_stores_user_id_idx;
+DROP INDEX IF EXISTS auto_stores_city_id_idx;
+DROP INDEX IF EXISTS auto_stores_region_id_idx;
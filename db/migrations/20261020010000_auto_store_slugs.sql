@@ -0,0 +1,85 @@
+-- +goose Up
+ALTER TABLE auto_stores ADD COLUMN IF NOT EXISTS "slug" CHARACTER VARYING(100);
+
+-- existing stores get the same slug the application would generate, cut at a
+-- hyphen like helpers.Slugify when longer than 80 characters
+WITH transliterated AS (
+    SELECT id,
+           btrim(regexp_replace(
+               translate(
+                   replace(replace(replace(replace(replace(replace(replace(replace(replace(replace(replace(
+                       lower(store_name),
+                       'щ', 'shch'), 'ж', 'zh'), 'ё', 'yo'), 'ц', 'ts'), 'ч', 'ch'), 'ш', 'sh'), 'ю', 'yu'),
+                       'я', 'ya'), 'ç', 'ch'), 'ş', 'sh'), 'ž', 'zh'),
+                   'абвгдезийклмнопрстуфхыэňýäöüéèêáàíóúъь',
+                   'abvgdeziyklmnoprstufhyenyaoueeeaaiou'),
+               '[^a-z0-9]+', '-', 'g'), '-') AS slug
+    FROM auto_stores
+),
+truncated AS (
+    SELECT id,
+           rtrim(CASE
+               WHEN length(slug) <= 80 THEN slug
+               WHEN strpos(reverse(left(slug, 80)), '-') BETWEEN 1 AND 39
+                   THEN left(slug, 80 - strpos(reverse(left(slug, 80)), '-'))
+               ELSE left(slug, 80)
+           END, '-') AS slug
+    FROM transliterated
+)
+UPDATE auto_stores ast
+SET slug = COALESCE(NULLIF(truncated.slug, ''), 'store')
+FROM truncated
+WHERE truncated.id = ast.id;
+
+-- later stores sharing a slug get the first free numeric suffix, skipping slugs
+-- that other stores already have
+-- +goose StatementBegin
+DO $$
+DECLARE
+    store RECORD;
+    candidate TEXT;
+    n INTEGER;
+BEGIN
+    FOR store IN
+        SELECT id, slug
+        FROM (
+            SELECT id, slug, created_at,
+                   row_number() OVER (PARTITION BY slug ORDER BY created_at, id) AS rank
+            FROM auto_stores
+        ) ranked
+        WHERE rank > 1
+        ORDER BY created_at, id
+    LOOP
+        n := 2;
+        candidate := store.slug || '-' || n;
+        WHILE EXISTS (SELECT 1 FROM auto_stores WHERE slug = candidate) LOOP
+            n := n + 1;
+            candidate := store.slug || '-' || n;
+        END LOOP;
+        UPDATE auto_stores SET slug = candidate WHERE id = store.id;
+    END LOOP;
+END $$;
+-- +goose StatementEnd
+
+ALTER TABLE auto_stores
+    ALTER COLUMN "slug" SET NOT NULL,
+    ADD CONSTRAINT auto_stores_slug_key UNIQUE (slug);
+
+CREATE TABLE IF NOT EXISTS auto_store_slugs (
+                "slug" CHARACTER VARYING(100) PRIMARY KEY,
+                "auto_store_id" INTEGER NOT NULL,
+                "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                CONSTRAINT auto_store_id_fk
+                    FOREIGN KEY (auto_store_id)
+                        REFERENCES auto_stores(id)
+                            ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS auto_store_slugs_auto_store_id_idx ON auto_store_slugs (auto_store_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS auto_store_slugs;
+
+ALTER TABLE auto_stores
+    DROP CONSTRAINT IF EXISTS auto_stores_slug_key,
+    DROP COLUMN IF EXISTS "slug";
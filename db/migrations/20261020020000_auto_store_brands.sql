@@ -0,0 +1,31 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS auto_store_brands (
+                "id" SERIAL PRIMARY KEY,
+                "auto_store_id" INTEGER NOT NULL,
+                "brand_id" INTEGER NOT NULL,
+                "category_id" INTEGER,
+                "role" CHARACTER VARYING(20) NOT NULL,
+                "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                CONSTRAINT auto_store_id_fk
+                    FOREIGN KEY (auto_store_id)
+                        REFERENCES auto_stores(id)
+                            ON UPDATE CASCADE ON DELETE CASCADE,
+                CONSTRAINT brand_id_fk
+                    FOREIGN KEY (brand_id)
+                        REFERENCES brands(id)
+                            ON UPDATE CASCADE ON DELETE CASCADE,
+                CONSTRAINT category_id_fk
+                    FOREIGN KEY (category_id)
+                        REFERENCES categories(id)
+                            ON UPDATE CASCADE ON DELETE RESTRICT,
+                CONSTRAINT auto_store_brands_role_check
+                    CHECK (role IN ('official_dealer', 'dealer', 'service', 'parts'))
+);
+
+-- a NULL category means every category of the brand
+CREATE UNIQUE INDEX IF NOT EXISTS auto_store_brands_key
+    ON auto_store_brands (auto_store_id, brand_id, COALESCE(category_id, 0), role);
+CREATE INDEX IF NOT EXISTS auto_store_brands_brand_id_idx ON auto_store_brands (brand_id, role);
+
+-- +goose Down
+DROP TABLE IF EXISTS auto_store_brands;
@@ -0,0 +1,47 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS subscription_plans (
+                "id" SERIAL PRIMARY KEY,
+                "name" CHARACTER VARYING(255) NOT NULL UNIQUE,
+                "price" NUMERIC(12, 2) NOT NULL DEFAULT 0,
+                "currency" CHARACTER VARYING(3) NOT NULL DEFAULT 'TMT',
+                "duration_days" INTEGER NOT NULL,
+                "max_gallery_images" INTEGER NOT NULL DEFAULT 0,
+                "is_active" BOOLEAN NOT NULL DEFAULT TRUE,
+                "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                "updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                CONSTRAINT subscription_plans_price_check CHECK (price >= 0),
+                CONSTRAINT subscription_plans_duration_check CHECK (duration_days > 0),
+                CONSTRAINT subscription_plans_images_check CHECK (max_gallery_images >= 0)
+);
+
+CREATE TABLE IF NOT EXISTS store_subscriptions (
+                "id" SERIAL PRIMARY KEY,
+                "auto_store_id" INTEGER NOT NULL,
+                "plan_id" INTEGER NOT NULL,
+                "starts_at" TIMESTAMPTZ NOT NULL,
+                "ends_at" TIMESTAMPTZ NOT NULL,
+                "status" CHARACTER VARYING(20) NOT NULL DEFAULT 'active',
+                "cancelled_at" TIMESTAMPTZ,
+                "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                CONSTRAINT auto_store_id_fk
+                    FOREIGN KEY (auto_store_id)
+                        REFERENCES auto_stores(id)
+                            ON UPDATE CASCADE ON DELETE CASCADE,
+                CONSTRAINT plan_id_fk
+                    FOREIGN KEY (plan_id)
+                        REFERENCES subscription_plans(id)
+                            ON UPDATE CASCADE ON DELETE RESTRICT,
+                CONSTRAINT store_subscriptions_period_check CHECK (ends_at > starts_at),
+                CONSTRAINT store_subscriptions_status_check CHECK (status IN ('active', 'cancelled'))
+);
+
+CREATE INDEX IF NOT EXISTS store_subscriptions_auto_store_id_idx ON store_subscriptions (auto_store_id, ends_at);
+
+-- stores that never had a subscription stay listed
+ALTER TABLE auto_stores
+    ADD COLUMN IF NOT EXISTS "is_expired" BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE auto_stores DROP COLUMN IF EXISTS "is_expired";
+DROP TABLE IF EXISTS store_subscriptions;
+DROP TABLE IF EXISTS subscription_plans;
@@ -5,17 +5,35 @@ import (
 	"github.com/ilyakaznacheev/cleanenv"
 	"os"
 	"sync"
+	"time"
 )
 
 type Config struct {
-	IsDebug        *bool   `yaml:"is_debug" env-required:"true"`
-	Listen         Listen  `yaml:"listen"`
-	Swagger        Swagger `yaml:"swagger"`
-	Storage        Storage `yaml:"storage"`
-	Log            Log     `yaml:"log"`
-	FilePath       string  `yaml:"file_path"`
-	Auth           Auth    `yaml:"auth"`
-	UserServiceURL string  `yaml:"user_service"`
+	IsDebug          *bool      `yaml:"is_debug" env-required:"true"`
+	Listen           Listen     `yaml:"listen"`
+	Swagger          Swagger    `yaml:"swagger"`
+	Storage          Storage    `yaml:"storage"`
+	Log              Log        `yaml:"log"`
+	FilePath         string     `yaml:"file_path"`
+	Auth             Auth       `yaml:"auth"`
+	UserServiceURL   string     `yaml:"user_service"`
+	UserServiceStub  bool       `yaml:"user_service_stub" env-default:"false"`
+	UserClient       UserClient `yaml:"user_client"`
+	Locales          []string   `yaml:"locales" env-default:"tm,en,ru"`
+	TimeZone         string     `yaml:"time_zone" env-default:"Asia/Ashgabat"`
+	MaxStoresPerUser int        `yaml:"max_stores_per_user" env-default:"0"`
+}
+
+// UserClient tunes the calls to the user service. A call is retried Retries
+// times with exponential backoff; after BreakerThreshold failed calls in a row
+// the service is not called for BreakerCooldown. Users are cached for CacheTTL.
+type UserClient struct {
+	Timeout          time.Duration `yaml:"timeout" env-default:"5s"`
+	Retries          int           `yaml:"retries" env-default:"2"`
+	RetryBackoff     time.Duration `yaml:"retry_backoff" env-default:"200ms"`
+	BreakerThreshold int           `yaml:"breaker_threshold" env-default:"5"`
+	BreakerCooldown  time.Duration `yaml:"breaker_cooldown" env-default:"30s"`
+	CacheTTL         time.Duration `yaml:"cache_ttl" env-default:"1m"`
 }
 
 type Auth struct {
@@ -1,48 +1,104 @@
 package dtos
 
+import "time"
+
 type CreateAutoStoreReq struct {
-	UserID      int64    `json:"user_id"`
-	PhoneNumber string   `json:"phone_number"`
-	Email       string   `json:"email"`
-	StoreName   string   `json:"store_name" binding:"required"`
-	Images      []string `json:"images"`
-	LogoPath    string   `json:"logo_path"`
-	RegionID    int64    `json:"region_id"`
-	CityID      int64    `json:"city_id"`
-	Address     string   `json:"address"`
+	UserID      int64              `json:"user_id"`
+	PhoneNumber string             `json:"phone_number"`
+	Email       string             `json:"email"`
+	StoreName   string             `json:"store_name" binding:"required"`
+	Images      []string           `json:"images"`
+	LogoPath    string             `json:"logo_path"`
+	RegionID    int64              `json:"region_id"`
+	CityID      int64              `json:"city_id"`
+	DistrictID  int64              `json:"district_id"`
+	Address     string             `json:"address"`
+	Latitude    *float64           `json:"latitude" validate:"required_with=Longitude,omitempty,latitude"`
+	Longitude   *float64           `json:"longitude" validate:"required_with=Latitude,omitempty,longitude"`
+	Contacts    []AutoStoreContact `json:"contacts" validate:"max=20,dive"`
 }
 
+// UpdateAutoStoreReq changes a store. Without contacts the stored contact list
+// is kept and phone_number and email only set its first phone and email.
 type UpdateAutoStoreReq struct {
-	ID          int64    `json:"id"`
-	UserID      int64    `json:"user_id"`
-	PhoneNumber string   `json:"phone_number"`
-	Email       string   `json:"email"`
-	StoreName   string   `json:"store_name" binding:"required"`
-	Images      []string `json:"images"`
-	LogoPath    string   `json:"logo_path"`
-	RegionID    int64    `json:"region_id"`
-	CityID      int64    `json:"city_id"`
-	Address     string   `json:"address"`
+	ID          int64               `json:"id"`
+	UserID      int64               `json:"user_id"`
+	PhoneNumber string              `json:"phone_number"`
+	Email       string              `json:"email"`
+	StoreName   string              `json:"store_name" binding:"required"`
+	Images      []string            `json:"images"`
+	LogoPath    string              `json:"logo_path"`
+	RegionID    int64               `json:"region_id"`
+	CityID      int64               `json:"city_id"`
+	DistrictID  int64               `json:"district_id"`
+	Address     string              `json:"address"`
+	Latitude    *float64            `json:"latitude" validate:"required_with=Longitude,omitempty,latitude"`
+	Longitude   *float64            `json:"longitude" validate:"required_with=Latitude,omitempty,longitude"`
+	Contacts    *[]AutoStoreContact `json:"contacts" validate:"omitnil,max=20,dive"`
 }
 
 type AutoStore struct {
-	ID           int64    `json:"id"`
-	UserID       int64    `json:"user_id"`
-	PhoneNumber  string   `json:"phone_number"`
-	Email        string   `json:"email"`
-	StoreName    string   `json:"store_name"`
-	Images       []string `json:"images"`
-	LogoPath     string   `json:"logo_path"`
-	RegionID     int64    `json:"region_id"`
-	CityID       int64    `json:"city_id"`
-	Address      string   `json:"address"`
-	CityNameTM   string   `json:"city_name_tm"`
-	CityNameEN   string   `json:"city_name_en"`
-	CityNameRU   string   `json:"city_name_ru"`
-	RegionNameTM string   `json:"region_name_tm"`
-	RegionNameEN string   `json:"region_name_en"`
-	RegionNameRU string   `json:"region_name_ru"`
-	UserName     *string  `json:"user_name"`
+	ID                  int64              `json:"id"`
+	UserID              int64              `json:"user_id"`
+	PhoneNumber         string             `json:"phone_number"`
+	Email               string             `json:"email"`
+	StoreName           string             `json:"store_name"`
+	Slug                string             `json:"slug"`
+	Images              []string           `json:"images"`
+	LogoPath            string             `json:"logo_path"`
+	RegionID            int64              `json:"region_id"`
+	CityID              int64              `json:"city_id"`
+	Address             string             `json:"address"`
+	CityNameTM          string             `json:"city_name_tm"`
+	CityNameEN          string             `json:"city_name_en"`
+	CityNameRU          string             `json:"city_name_ru"`
+	RegionNameTM        string             `json:"region_name_tm"`
+	RegionNameEN        string             `json:"region_name_en"`
+	RegionNameRU        string             `json:"region_name_ru"`
+	DistrictID          int64              `json:"district_id"`
+	DistrictNameTM      string             `json:"district_name_tm"`
+	DistrictNameEN      string             `json:"district_name_en"`
+	DistrictNameRU      string             `json:"district_name_ru"`
+	UserName            *string            `json:"user_name"`
+	UserNameUnavailable bool               `json:"user_name_unavailable"`
+	Latitude            *float64           `json:"latitude"`
+	Longitude           *float64           `json:"longitude"`
+	DistanceKm          *float64           `json:"distance_km,omitempty"`
+	OpenNow             bool               `json:"open_now"`
+	NextOpeningAt       *time.Time         `json:"next_opening_at"`
+	Status              string             `json:"status"`
+	RejectionReason     string             `json:"rejection_reason,omitempty"`
+	ReviewedBy          *int64             `json:"reviewed_by"`
+	ReviewedAt          *time.Time         `json:"reviewed_at"`
+	IsExpired           bool               `json:"is_expired"`
+	Contacts            []AutoStoreContact `json:"contacts"`
+	Brands              []AutoStoreBrand   `json:"brands"`
+}
+
+// AutoStoreContact is one entry of a store's ordered contact list.
+type AutoStoreContact struct {
+	Type  string `json:"type" validate:"required,oneof=phone whatsapp telegram imo instagram email website"`
+	Value string `json:"value" validate:"required,max=255"`
+}
+
+// AutoStoresFilter holds the query parameters of the auto store listing.
+// CreatedFrom and CreatedTo are inclusive days.
+type AutoStoresFilter struct {
+	Limit       int64
+	Page        int64
+	Search      string
+	Status      string
+	RegionID    int64
+	CityID      int64
+	UserID      int64
+	BrandID     int64
+	Category    string
+	BrandRole   string `validate:"omitempty,oneof=official_dealer dealer service parts"`
+	Expired     *bool
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	SortBy      string `validate:"omitempty,oneof=name created_at updated_at"`
+	Order       string `validate:"omitempty,oneof=asc desc"`
 }
 
 type AutoStoresResult struct {
@@ -50,6 +106,13 @@ type AutoStoresResult struct {
 	Count      int64       `json:"count"`
 }
 
+type NearestAutoStoresReq struct {
+	Latitude  float64 `json:"latitude" validate:"latitude"`
+	Longitude float64 `json:"longitude" validate:"longitude"`
+	RadiusKm  float64 `json:"radius_km" validate:"gt=0,lte=1000"`
+	Limit     int64   `json:"limit" validate:"gte=1,lte=100"`
+}
+
 type GetUsers struct {
 	Id          int64   `json:"id"`
 	FullName    *string `json:"full_name"`
@@ -66,3 +129,66 @@ type GetUserResult struct {
 type GetUserByIDsReq struct {
 	Ids []int64 `json:"ids"`
 }
+
+// OpeningHours is one opening interval; times are HH:MM in the configured time
+// zone and closes_at may be 24:00. Weekday is ISO: 1 is Monday, 7 is Sunday.
+type OpeningHours struct {
+	Weekday  int    `json:"weekday" validate:"gte=1,lte=7"`
+	OpensAt  string `json:"opens_at" validate:"required"`
+	ClosesAt string `json:"closes_at" validate:"required"`
+}
+
+type StoreClosure struct {
+	Date   string `json:"date" validate:"required,datetime=2006-01-02"`
+	Reason string `json:"reason" validate:"max=255"`
+}
+
+// AutoStoreSchedule is the weekly schedule and upcoming closures of a store.
+// Setting it replaces the stored schedule.
+type AutoStoreSchedule struct {
+	AutoStoreID int64          `json:"auto_store_id" validate:"required"`
+	TimeZone    string         `json:"time_zone"`
+	Hours       []OpeningHours `json:"hours" validate:"dive"`
+	Closures    []StoreClosure `json:"closures" validate:"dive"`
+}
+
+// ReviewAutoStoreReq changes the review status of a store. ReviewerID is the
+// authenticated admin making the change, set by the handler from the token and
+// never read from the request body. Reason is required for rejections and
+// suspensions.
+type ReviewAutoStoreReq struct {
+	AutoStoreID int64  `json:"auto_store_id" validate:"required"`
+	ReviewerID  int64  `json:"-" validate:"required"`
+	Reason      string `json:"reason" validate:"max=1000"`
+}
+
+type AutoStoreStatusChange struct {
+	ID         int64     `json:"id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Reason     string    `json:"reason"`
+	ChangedBy  *int64    `json:"changed_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// AutoStoreBySlugResult is the public store lookup. Redirect is set when the
+// requested slug is a former one; clients should then move to AutoStore.Slug.
+type AutoStoreBySlugResult struct {
+	AutoStore AutoStore `json:"auto_store"`
+	Redirect  bool      `json:"redirect"`
+}
+
+// AutoStoreBrand links a store to a brand. Category is a category slug; an
+// empty category covers every category of the brand.
+type AutoStoreBrand struct {
+	BrandID   int64  `json:"brand_id" validate:"required"`
+	BrandName string `json:"brand_name"`
+	LogoPath  string `json:"logo_path"`
+	Category  string `json:"category"`
+	Role      string `json:"role" validate:"required,oneof=official_dealer dealer service parts"`
+}
+
+type SetAutoStoreBrandsReq struct {
+	AutoStoreID int64            `json:"auto_store_id" validate:"required"`
+	Brands      []AutoStoreBrand `json:"brands" validate:"max=100,dive"`
+}
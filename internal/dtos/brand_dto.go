@@ -4,29 +4,32 @@ type ID struct {
 	ID int64 `json:"id"`
 }
 type CreateBodyTypeReq struct {
-	NameTM    string `json:"name_tm"`
-	NameEN    string `json:"name_en"`
-	NameRU    string `json:"name_ru"`
-	ImagePath string `json:"image_path"`
-	Category  string `json:"category"`
+	NameTM    string            `json:"name_tm"`
+	NameEN    string            `json:"name_en"`
+	NameRU    string            `json:"name_ru"`
+	Names     map[string]string `json:"names"`
+	ImagePath string            `json:"image_path"`
+	Category  string            `json:"category"`
 }
 
 type UpdateBodyTypeReq struct {
-	ID        int64  `json:"id"`
-	NameTM    string `json:"name_tm"`
-	NameEN    string `json:"name_en"`
-	NameRU    string `json:"name_ru"`
-	ImagePath string `json:"image_path"`
-	Category  string `json:"category"`
+	ID        int64             `json:"id"`
+	NameTM    string            `json:"name_tm"`
+	NameEN    string            `json:"name_en"`
+	NameRU    string            `json:"name_ru"`
+	Names     map[string]string `json:"names"`
+	ImagePath string            `json:"image_path"`
+	Category  string            `json:"category"`
 }
 
 type BodyType struct {
-	ID        int64  `json:"id"`
-	NameTM    string `json:"name_tm"`
-	NameEN    string `json:"name_en"`
-	NameRU    string `json:"name_ru"`
-	ImagePath string `json:"image_path"`
-	Category  string `json:"category"`
+	ID        int64             `json:"id"`
+	NameTM    string            `json:"name_tm"`
+	NameEN    string            `json:"name_en"`
+	NameRU    string            `json:"name_ru"`
+	Names     map[string]string `json:"names"`
+	ImagePath string            `json:"image_path"`
+	Category  string            `json:"category"`
 }
 
 type BodyTypeResult struct {
@@ -36,14 +39,14 @@ type BodyTypeResult struct {
 type CreateBrandReq struct {
 	Name       string   `json:"name"`
 	LogoPath   string   `json:"logo_path"`
-	Categories []string `json:"categories" binding:"required,dive,oneof=auto moto truck"`
+	Categories []string `json:"categories" validate:"required,min=1"`
 }
 
 type UpdateBrandReq struct {
 	ID         int64    `json:"id"`
 	Name       string   `json:"name"`
 	LogoPath   string   `json:"logo_path"`
-	Categories []string `json:"categories" binding:"required,dive,oneof=auto moto truck"`
+	Categories []string `json:"categories" validate:"required,min=1"`
 }
 type Brand struct {
 	ID         int64    `json:"id"`
@@ -83,3 +86,62 @@ type ModelResult struct {
 	Models []Model `json:"models"`
 	Count  int64   `json:"count"`
 }
+
+type MergeReq struct {
+	SourceID int64 `json:"source_id" validate:"required"`
+	TargetID int64 `json:"target_id" validate:"required,nefield=SourceID"`
+}
+
+// MergeResult reports how many rows were re-pointed from the source to the
+// target per table, and how many duplicate rows were merged into the target and
+// deleted. Total counts the re-pointed rows only.
+type MergeResult struct {
+	SourceID  int64            `json:"source_id"`
+	TargetID  int64            `json:"target_id"`
+	Repointed map[string]int64 `json:"repointed"`
+	Merged    map[string]int64 `json:"merged"`
+	Total     int64            `json:"total"`
+}
+
+type CreateWMICodeReq struct {
+	Code         string `json:"code" validate:"required,min=2,max=3,alphanum"`
+	BrandID      int64  `json:"brand_id" validate:"required"`
+	Manufacturer string `json:"manufacturer" validate:"required,max=255"`
+	Country      string `json:"country" validate:"max=100"`
+}
+
+type UpdateWMICodeReq struct {
+	ID           int64  `json:"id" validate:"required"`
+	Code         string `json:"code" validate:"required,min=2,max=3,alphanum"`
+	BrandID      int64  `json:"brand_id" validate:"required"`
+	Manufacturer string `json:"manufacturer" validate:"required,max=255"`
+	Country      string `json:"country" validate:"max=100"`
+}
+
+type WMICode struct {
+	ID           int64  `json:"id"`
+	Code         string `json:"code"`
+	BrandID      int64  `json:"brand_id"`
+	BrandName    string `json:"brand_name"`
+	Manufacturer string `json:"manufacturer"`
+	Country      string `json:"country"`
+}
+
+type WMICodeResult struct {
+	WMICodes []WMICode `json:"wmi_codes"`
+	Count    int64     `json:"count"`
+}
+
+type VINDecodeResult struct {
+	VIN             string `json:"vin"`
+	WMI             string `json:"wmi"`
+	Manufacturer    string `json:"manufacturer"`
+	Country         string `json:"country"`
+	BrandID         int64  `json:"brand_id"`
+	BrandName       string `json:"brand_name"`
+	CheckDigit      string `json:"check_digit"`
+	CheckDigitValid bool   `json:"check_digit_valid"`
+	ModelYearCode   string `json:"model_year_code"`
+	ModelYears      []int  `json:"model_years"`
+	Region          string `json:"region"`
+}
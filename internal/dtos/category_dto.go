@@ -0,0 +1,38 @@
+package dtos
+
+type CreateCategoryReq struct {
+	Slug     string            `json:"slug" validate:"required,max=100"`
+	NameTM   string            `json:"name_tm" validate:"required"`
+	NameEN   string            `json:"name_en" validate:"required"`
+	NameRU   string            `json:"name_ru" validate:"required"`
+	Names    map[string]string `json:"names"`
+	IconPath string            `json:"icon_path"`
+	IsActive *bool             `json:"is_active"`
+}
+
+type UpdateCategoryReq struct {
+	ID       int64             `json:"id" validate:"required"`
+	Slug     string            `json:"slug" validate:"required,max=100"`
+	NameTM   string            `json:"name_tm" validate:"required"`
+	NameEN   string            `json:"name_en" validate:"required"`
+	NameRU   string            `json:"name_ru" validate:"required"`
+	Names    map[string]string `json:"names"`
+	IconPath string            `json:"icon_path"`
+	IsActive *bool             `json:"is_active"`
+}
+
+type Category struct {
+	ID       int64             `json:"id"`
+	Slug     string            `json:"slug"`
+	NameTM   string            `json:"name_tm"`
+	NameEN   string            `json:"name_en"`
+	NameRU   string            `json:"name_ru"`
+	Names    map[string]string `json:"names"`
+	IconPath string            `json:"icon_path"`
+	IsActive bool              `json:"is_active"`
+}
+
+type CategoryResult struct {
+	Categories []Category `json:"categories"`
+	Count      int64      `json:"count"`
+}
@@ -1,22 +1,25 @@
 package dtos
 
 type CreateRegionReq struct {
-	NameTM string `json:"name_tm"`
-	NameEN string `json:"name_en"`
-	NameRu string `json:"name_ru"`
+	NameTM string            `json:"name_tm"`
+	NameEN string            `json:"name_en"`
+	NameRu string            `json:"name_ru"`
+	Names  map[string]string `json:"names"`
 }
 
 type UpdateRegionReq struct {
-	ID     int64  `json:"id"`
-	NameTM string `json:"name_tm"`
-	NameEN string `json:"name_en"`
-	NameRu string `json:"name_ru"`
+	ID     int64             `json:"id"`
+	NameTM string            `json:"name_tm"`
+	NameEN string            `json:"name_en"`
+	NameRu string            `json:"name_ru"`
+	Names  map[string]string `json:"names"`
 }
 type Region struct {
-	ID     int64  `json:"id"`
-	NameTM string `json:"name_tm"`
-	NameEN string `json:"name_en"`
-	NameRu string `json:"name_ru"`
+	ID     int64             `json:"id"`
+	NameTM string            `json:"name_tm"`
+	NameEN string            `json:"name_en"`
+	NameRu string            `json:"name_ru"`
+	Names  map[string]string `json:"names"`
 }
 
 type RegionResult struct {
@@ -25,32 +28,97 @@ type RegionResult struct {
 }
 
 type CreateCityReq struct {
-	NameTM   string `json:"name_tm"`
-	NameEN   string `json:"name_en"`
-	NameRu   string `json:"name_ru"`
-	RegionID int64  `json:"region_id"`
+	NameTM    string            `json:"name_tm"`
+	NameEN    string            `json:"name_en"`
+	NameRu    string            `json:"name_ru"`
+	Names     map[string]string `json:"names"`
+	RegionID  int64             `json:"region_id"`
+	Latitude  *float64          `json:"latitude" validate:"required_with=Longitude,omitempty,latitude"`
+	Longitude *float64          `json:"longitude" validate:"required_with=Latitude,omitempty,longitude"`
 }
 
 type UpdateCityReq struct {
-	ID       int64  `json:"id"`
-	NameTM   string `json:"name_tm"`
-	NameEN   string `json:"name_en"`
-	NameRu   string `json:"name_ru"`
-	RegionID int64  `json:"region_id"`
+	ID        int64             `json:"id"`
+	NameTM    string            `json:"name_tm"`
+	NameEN    string            `json:"name_en"`
+	NameRu    string            `json:"name_ru"`
+	Names     map[string]string `json:"names"`
+	RegionID  int64             `json:"region_id"`
+	Latitude  *float64          `json:"latitude" validate:"required_with=Longitude,omitempty,latitude"`
+	Longitude *float64          `json:"longitude" validate:"required_with=Latitude,omitempty,longitude"`
 }
 
 type City struct {
-	ID           int64  `json:"id"`
-	NameTM       string `json:"name_tm"`
-	NameEN       string `json:"name_en"`
-	NameRu       string `json:"name_ru"`
-	RegionID     int64  `json:"region_id"`
-	RegionNameTM string `json:"region_name_tm"`
-	RegionNameEN string `json:"region_name_en"`
-	RegionNameRU string `json:"region_name_ru"`
+	ID           int64             `json:"id"`
+	NameTM       string            `json:"name_tm"`
+	NameEN       string            `json:"name_en"`
+	NameRu       string            `json:"name_ru"`
+	RegionID     int64             `json:"region_id"`
+	RegionNameTM string            `json:"region_name_tm"`
+	RegionNameEN string            `json:"region_name_en"`
+	RegionNameRU string            `json:"region_name_ru"`
+	Names        map[string]string `json:"names"`
+	Latitude     *float64          `json:"latitude"`
+	Longitude    *float64          `json:"longitude"`
 }
 
 type CityResult struct {
 	Cities []City `json:"cities"`
 	Count  int64  `json:"count"`
 }
+
+type RegionTreeDistrict struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type RegionTreeCity struct {
+	ID        int64                `json:"id"`
+	Name      string               `json:"name"`
+	Districts []RegionTreeDistrict `json:"districts"`
+}
+
+type CreateDistrictReq struct {
+	NameTM string            `json:"name_tm"`
+	NameEN string            `json:"name_en"`
+	NameRu string            `json:"name_ru"`
+	Names  map[string]string `json:"names"`
+	CityID int64             `json:"city_id" validate:"required"`
+}
+
+type UpdateDistrictReq struct {
+	ID     int64             `json:"id" validate:"required"`
+	NameTM string            `json:"name_tm"`
+	NameEN string            `json:"name_en"`
+	NameRu string            `json:"name_ru"`
+	Names  map[string]string `json:"names"`
+	CityID int64             `json:"city_id" validate:"required"`
+}
+
+type District struct {
+	ID         int64             `json:"id"`
+	NameTM     string            `json:"name_tm"`
+	NameEN     string            `json:"name_en"`
+	NameRu     string            `json:"name_ru"`
+	CityID     int64             `json:"city_id"`
+	CityNameTM string            `json:"city_name_tm"`
+	CityNameEN string            `json:"city_name_en"`
+	CityNameRU string            `json:"city_name_ru"`
+	Names      map[string]string `json:"names"`
+}
+
+type DistrictResult struct {
+	Districts []District `json:"districts"`
+	Count     int64      `json:"count"`
+}
+
+type RegionTreeNode struct {
+	ID     int64            `json:"id"`
+	Name   string           `json:"name"`
+	Cities []RegionTreeCity `json:"cities"`
+}
+
+type RegionTree struct {
+	Lang    string           `json:"lang"`
+	Regions []RegionTreeNode `json:"regions"`
+}
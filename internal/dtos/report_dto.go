@@ -0,0 +1,19 @@
+package dtos
+
+type TranslationIssue struct {
+	Count int     `json:"count"`
+	IDs   []int64 `json:"ids"`
+}
+
+type EntityTranslationReport struct {
+	Entity         string           `json:"entity"`
+	Total          int              `json:"total"`
+	Complete       int              `json:"complete"`
+	Missing        TranslationIssue `json:"missing"`
+	Identical      TranslationIssue `json:"identical"`
+	ScriptMismatch TranslationIssue `json:"script_mismatch"`
+}
+
+type TranslationReport struct {
+	Entities []EntityTranslationReport `json:"entities"`
+}
@@ -0,0 +1,45 @@
+package dtos
+
+type SeedBrand struct {
+	Name   string   `json:"name" validate:"required"`
+	Models []string `json:"models" validate:"dive,required"`
+}
+
+type SeedCategory struct {
+	Slug   string      `json:"slug" validate:"required,max=100"`
+	NameTM string      `json:"name_tm" validate:"required"`
+	NameEN string      `json:"name_en" validate:"required"`
+	NameRU string      `json:"name_ru" validate:"required"`
+	Brands []SeedBrand `json:"brands" validate:"dive"`
+}
+
+type SeedBodyType struct {
+	Category string `json:"category" validate:"required"`
+	NameTM   string `json:"name_tm" validate:"required"`
+	NameEN   string `json:"name_en" validate:"required"`
+	NameRU   string `json:"name_ru" validate:"required"`
+}
+
+type SeedCity struct {
+	NameTM string `json:"name_tm" validate:"required"`
+	NameEN string `json:"name_en" validate:"required"`
+	NameRU string `json:"name_ru" validate:"required"`
+}
+
+type SeedRegion struct {
+	NameTM string     `json:"name_tm" validate:"required"`
+	NameEN string     `json:"name_en" validate:"required"`
+	NameRU string     `json:"name_ru" validate:"required"`
+	Cities []SeedCity `json:"cities" validate:"dive"`
+}
+
+type SeedCatalog struct {
+	Categories []SeedCategory `json:"categories" validate:"dive"`
+	BodyTypes  []SeedBodyType `json:"body_types" validate:"dive"`
+	Regions    []SeedRegion   `json:"regions" validate:"dive"`
+}
+
+// SeedResult holds the number of rows created per table.
+type SeedResult struct {
+	Created map[string]int64 `json:"created"`
+}
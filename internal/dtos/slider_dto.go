@@ -1,28 +1,200 @@
 package dtos
 
+import "time"
+
 type CreateSliderReq struct {
-	ImagePathTM string `json:"image_path_tm" validate:"required"`
-	ImagePathEN string `json:"image_path_en" validate:"required"`
-	ImagePathRU string `json:"image_path_ru" validate:"required"`
-	Platform    string `json:"platform" validate:"required"`
+	ImagePathTM  string            `json:"image_path_tm" validate:"required"`
+	ImagePathEN  string            `json:"image_path_en" validate:"required"`
+	ImagePathRU  string            `json:"image_path_ru" validate:"required"`
+	ImagePaths   map[string]string `json:"image_paths"`
+	Platform     string            `json:"platform" validate:"required"`
+	StartsAt     *time.Time        `json:"starts_at"`
+	EndsAt       *time.Time        `json:"ends_at"`
+	IsActive     *bool             `json:"is_active"`
+	Position     *int64            `json:"position" validate:"omitempty,gte=0"`
+	TargetType   string            `json:"target_type" validate:"omitempty,oneof=none url brand model auto_store screen"`
+	TargetID     *int64            `json:"target_id" validate:"omitempty,gt=0"`
+	TargetURL    string            `json:"target_url" validate:"omitempty,max=2048"`
+	TargetURLs   map[string]string `json:"target_urls" validate:"omitempty,dive,max=2048"`
+	TargetScreen string            `json:"target_screen" validate:"omitempty,max=100"`
 }
 
+// UpdateSliderReq changes a slider. An omitted starts_at, ends_at, is_active,
+// position or target_type keeps its stored value, the target with all its
+// fields; ClearSchedule removes the stored starts_at and ends_at before the
+// given ones are applied and target_type none removes the target.
 type UpdateSliderReq struct {
-	ID          int64  `json:"id"`
-	ImagePathTM string `json:"image_path_tm"`
-	ImagePathEN string `json:"image_path_en"`
-	ImagePathRU string `json:"image_path_ru"`
-	Platform    string `json:"platform"`
+	ID            int64             `json:"id"`
+	ImagePathTM   string            `json:"image_path_tm"`
+	ImagePathEN   string            `json:"image_path_en"`
+	ImagePathRU   string            `json:"image_path_ru"`
+	ImagePaths    map[string]string `json:"image_paths"`
+	Platform      string            `json:"platform"`
+	StartsAt      *time.Time        `json:"starts_at"`
+	EndsAt        *time.Time        `json:"ends_at"`
+	ClearSchedule bool              `json:"clear_schedule"`
+	IsActive      *bool             `json:"is_active"`
+	Position      *int64            `json:"position" validate:"omitempty,gte=0"`
+	TargetType    string            `json:"target_type" validate:"omitempty,oneof=none url brand model auto_store screen"`
+	TargetID      *int64            `json:"target_id" validate:"omitempty,gt=0"`
+	TargetURL     string            `json:"target_url" validate:"omitempty,max=2048"`
+	TargetURLs    map[string]string `json:"target_urls" validate:"omitempty,dive,max=2048"`
+	TargetScreen  string            `json:"target_screen" validate:"omitempty,max=100"`
+}
+
+type ReorderSlidersReq struct {
+	Platform string  `json:"platform" validate:"required"`
+	IDs      []int64 `json:"ids" validate:"required,min=1,unique"`
 }
+
 type Slider struct {
-	ID          int64  `json:"id"`
-	ImagePathTM string `json:"image_path_tm"`
-	ImagePathEN string `json:"image_path_en"`
-	ImagePathRU string `json:"image_path_ru"`
-	Platform    string `json:"platform"`
+	ID          int64             `json:"id"`
+	ImagePathTM string            `json:"image_path_tm"`
+	ImagePathEN string            `json:"image_path_en"`
+	ImagePathRU string            `json:"image_path_ru"`
+	ImagePaths  map[string]string `json:"image_paths"`
+	Platform    string            `json:"platform"`
+	StartsAt    *time.Time        `json:"starts_at"`
+	EndsAt      *time.Time        `json:"ends_at"`
+	IsActive    bool              `json:"is_active"`
+	Position    int64             `json:"position"`
+	Target      SliderTarget      `json:"target"`
+	VariantID   *int64            `json:"variant_id,omitempty"`
+}
+
+// SliderTarget is the resolved click target of a slider. URLs holds the link for
+// every supported locale, falling back to URL where no localized link is set.
+type SliderTarget struct {
+	Type   string            `json:"type"`
+	ID     *int64            `json:"id,omitempty"`
+	Name   string            `json:"name,omitempty"`
+	URL    string            `json:"url,omitempty"`
+	URLs   map[string]string `json:"urls,omitempty"`
+	Screen string            `json:"screen,omitempty"`
 }
 
 type SliderResult struct {
 	Sliders []Slider `json:"sliders"`
 	Count   int64    `json:"count"`
 }
+
+// TrackSliderImpressionsReq records the sliders shown to a client. VariantIDs
+// holds the variant served for each slider in the same order; 0 or a missing
+// entry means the slider's own creative.
+type TrackSliderImpressionsReq struct {
+	SliderIDs  []int64 `json:"slider_ids" validate:"required,min=1,max=50,dive,gt=0"`
+	VariantIDs []int64 `json:"variant_ids" validate:"max=50,dive,gte=0"`
+	Platform   string  `json:"platform" validate:"required,max=100"`
+}
+
+type TrackSliderClickReq struct {
+	SliderID  int64  `json:"slider_id" validate:"required,gt=0"`
+	VariantID int64  `json:"variant_id" validate:"gte=0"`
+	Platform  string `json:"platform" validate:"required,max=100"`
+}
+
+type SliderStatsReq struct {
+	From     time.Time
+	To       time.Time
+	Platform string
+	SliderID int64
+}
+
+type SliderDayStats struct {
+	Day         string  `json:"day"`
+	Impressions int64   `json:"impressions"`
+	Clicks      int64   `json:"clicks"`
+	CTR         float64 `json:"ctr"`
+}
+
+type SliderVariantStats struct {
+	VariantID   int64   `json:"variant_id"`
+	Name        string  `json:"name"`
+	Impressions int64   `json:"impressions"`
+	Clicks      int64   `json:"clicks"`
+	CTR         float64 `json:"ctr"`
+}
+
+type SliderStats struct {
+	SliderID    int64                `json:"slider_id"`
+	Impressions int64                `json:"impressions"`
+	Clicks      int64                `json:"clicks"`
+	CTR         float64              `json:"ctr"`
+	Days        []SliderDayStats     `json:"days"`
+	Variants    []SliderVariantStats `json:"variants"`
+}
+
+// SliderStatsResult reports slider performance between From and To inclusive.
+// CTR is clicks divided by impressions.
+type SliderStatsResult struct {
+	From        string        `json:"from"`
+	To          string        `json:"to"`
+	Platform    string        `json:"platform,omitempty"`
+	Impressions int64         `json:"impressions"`
+	Clicks      int64         `json:"clicks"`
+	CTR         float64       `json:"ctr"`
+	Sliders     []SliderStats `json:"sliders"`
+}
+
+type CreatePlatformReq struct {
+	Code         string `json:"code" validate:"required,max=50,lowercase,alphanum"`
+	Name         string `json:"name" validate:"required,max=255"`
+	AspectWidth  int64  `json:"aspect_width" validate:"required_with=AspectHeight,gte=0"`
+	AspectHeight int64  `json:"aspect_height" validate:"required_with=AspectWidth,gte=0"`
+	MinWidth     int64  `json:"min_width" validate:"gte=0"`
+	MinHeight    int64  `json:"min_height" validate:"gte=0"`
+	IsActive     *bool  `json:"is_active"`
+}
+
+type UpdatePlatformReq struct {
+	ID           int64  `json:"id" validate:"required"`
+	Code         string `json:"code" validate:"required,max=50,lowercase,alphanum"`
+	Name         string `json:"name" validate:"required,max=255"`
+	AspectWidth  int64  `json:"aspect_width" validate:"required_with=AspectHeight,gte=0"`
+	AspectHeight int64  `json:"aspect_height" validate:"required_with=AspectWidth,gte=0"`
+	MinWidth     int64  `json:"min_width" validate:"gte=0"`
+	MinHeight    int64  `json:"min_height" validate:"gte=0"`
+	IsActive     *bool  `json:"is_active" validate:"required"`
+}
+
+type Platform struct {
+	ID           int64  `json:"id"`
+	Code         string `json:"code"`
+	Name         string `json:"name"`
+	AspectWidth  int64  `json:"aspect_width"`
+	AspectHeight int64  `json:"aspect_height"`
+	MinWidth     int64  `json:"min_width"`
+	MinHeight    int64  `json:"min_height"`
+	IsActive     bool   `json:"is_active"`
+}
+
+type CreateSliderVariantReq struct {
+	SliderID    int64             `json:"slider_id" validate:"required"`
+	Name        string            `json:"name" validate:"required,max=100"`
+	Weight      *int64            `json:"weight" validate:"omitempty,gte=0,lte=1000"`
+	ImagePathTM string            `json:"image_path_tm" validate:"required"`
+	ImagePathEN string            `json:"image_path_en" validate:"required"`
+	ImagePathRU string            `json:"image_path_ru" validate:"required"`
+	ImagePaths  map[string]string `json:"image_paths"`
+}
+
+type UpdateSliderVariantReq struct {
+	ID          int64             `json:"id" validate:"required"`
+	Name        string            `json:"name" validate:"required,max=100"`
+	Weight      *int64            `json:"weight" validate:"omitempty,gte=0,lte=1000"`
+	ImagePathTM string            `json:"image_path_tm" validate:"required"`
+	ImagePathEN string            `json:"image_path_en" validate:"required"`
+	ImagePathRU string            `json:"image_path_ru" validate:"required"`
+	ImagePaths  map[string]string `json:"image_paths"`
+}
+
+type SliderVariant struct {
+	ID          int64             `json:"id"`
+	SliderID    int64             `json:"slider_id"`
+	Name        string            `json:"name"`
+	Weight      int64             `json:"weight"`
+	ImagePathTM string            `json:"image_path_tm"`
+	ImagePathEN string            `json:"image_path_en"`
+	ImagePathRU string            `json:"image_path_ru"`
+	ImagePaths  map[string]string `json:"image_paths"`
+}
@@ -0,0 +1,61 @@
+package dtos
+
+import "time"
+
+type CreateSubscriptionPlanReq struct {
+	Name             string  `json:"name" validate:"required,max=255"`
+	Price            float64 `json:"price" validate:"gte=0"`
+	Currency         string  `json:"currency" validate:"omitempty,len=3,uppercase"`
+	DurationDays     int64   `json:"duration_days" validate:"required,gt=0"`
+	MaxGalleryImages int64   `json:"max_gallery_images" validate:"gte=0"`
+	IsActive         *bool   `json:"is_active"`
+}
+
+type UpdateSubscriptionPlanReq struct {
+	ID               int64   `json:"id" validate:"required"`
+	Name             string  `json:"name" validate:"required,max=255"`
+	Price            float64 `json:"price" validate:"gte=0"`
+	Currency         string  `json:"currency" validate:"omitempty,len=3,uppercase"`
+	DurationDays     int64   `json:"duration_days" validate:"required,gt=0"`
+	MaxGalleryImages int64   `json:"max_gallery_images" validate:"gte=0"`
+	IsActive         *bool   `json:"is_active" validate:"required"`
+}
+
+type SubscriptionPlan struct {
+	ID               int64   `json:"id"`
+	Name             string  `json:"name"`
+	Price            float64 `json:"price"`
+	Currency         string  `json:"currency"`
+	DurationDays     int64   `json:"duration_days"`
+	MaxGalleryImages int64   `json:"max_gallery_images"`
+	IsActive         bool    `json:"is_active"`
+}
+
+// AssignSubscriptionReq puts a store on a plan for the plan's duration.
+// StartsAt defaults to now, or to the end of the store's latest subscription
+// when it runs later.
+type AssignSubscriptionReq struct {
+	AutoStoreID int64      `json:"auto_store_id" validate:"required"`
+	PlanID      int64      `json:"plan_id" validate:"required"`
+	StartsAt    *time.Time `json:"starts_at"`
+}
+
+// ExtendSubscriptionReq moves the end of a subscription by Days days.
+type ExtendSubscriptionReq struct {
+	SubscriptionID int64 `json:"subscription_id" validate:"required"`
+	Days           int64 `json:"days" validate:"required,gt=0"`
+}
+
+// StoreSubscription is a subscription of a store. Status is one of active,
+// scheduled, expired and cancelled.
+type StoreSubscription struct {
+	ID          int64      `json:"id"`
+	AutoStoreID int64      `json:"auto_store_id"`
+	PlanID      int64      `json:"plan_id"`
+	PlanName    string     `json:"plan_name"`
+	StartsAt    time.Time  `json:"starts_at"`
+	EndsAt      time.Time  `json:"ends_at"`
+	Status      string     `json:"status"`
+	CancelledAt *time.Time `json:"cancelled_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
@@ -2,14 +2,20 @@ package http
 
 import (
 	"autotm-admin/internal/dtos"
+	"autotm-admin/internal/helpers"
 	"autotm-admin/internal/services/repository"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
 	shttp "github.com/salamsites/package-http"
 	slog "github.com/salamsites/package-log"
 	"io"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 type AutoStoreHandler struct {
@@ -30,13 +36,26 @@ func (h *AutoStoreHandler) AutoStoreRegisterRoutes(r chi.Router) {
 	r.Method("POST", "/create-auto-store", h.middleware.Base(h.v1CreateAutoStore))
 	r.Method("GET", "/get-users", h.middleware.Base(h.v1GetUsers))
 	r.Method("GET", "/get-auto-stores", h.middleware.Base(h.v1GetAutoStores))
+	r.Method("GET", "/get-nearest-auto-stores", h.middleware.Base(h.v1GetNearestAutoStores))
 	r.Method("PUT", "/update-auto-store", h.middleware.Base(h.v1UpdateAutoStore))
 	r.Method("DELETE", "/delete-auto-store", h.middleware.Base(h.v1DeleteAutoStore))
+	r.Method("PUT", "/set-auto-store-schedule", h.middleware.Base(h.v1SetAutoStoreSchedule))
+	r.Method("GET", "/get-auto-store-schedule", h.middleware.Base(h.v1GetAutoStoreSchedule))
+	r.Method("PUT", "/approve-auto-store", h.middleware.Auth(h.v1ApproveAutoStore))
+	r.Method("PUT", "/reject-auto-store", h.middleware.Auth(h.v1RejectAutoStore))
+	r.Method("PUT", "/suspend-auto-store", h.middleware.Auth(h.v1SuspendAutoStore))
+	r.Method("GET", "/get-auto-store-status-history", h.middleware.Base(h.v1GetAutoStoreStatusHistory))
+	r.Method("GET", "/get-auto-store-by-slug", h.middleware.Base(h.v1GetAutoStoreBySlug))
+	r.Method("PUT", "/set-auto-store-brands", h.middleware.Base(h.v1SetAutoStoreBrands))
+	r.Method("GET", "/get-auto-store-brands", h.middleware.Base(h.v1GetAutoStoreBrands))
 }
 
 // v1CreateAutoStore
 // @Summary Create a new auto store
-// @Description Creates a new auto store
+// @Description Creates a new auto store awaiting review. Contacts are kept in the given order; without
+// @Description contacts, phone_number and email become the first contacts. user_id must be a user of the
+// @Description user service who has not reached the configured store limit. The district must lie in the
+// @Description city and the city in the region.
 // @Tags Auto Store
 // @Accept json
 // @Produce json
@@ -45,6 +64,7 @@ func (h *AutoStoreHandler) AutoStoreRegisterRoutes(r chi.Router) {
 // @Failure 400 {object} string "Bad request"
 // @Failure 422 {object} string "Unprocessable entity"
 // @Failure 500 {object} string "Internal server error"
+// @Failure 503 {object} string "User service unavailable"
 // @Router /auto-store/create-auto-store [post]
 func (h *AutoStoreHandler) v1CreateAutoStore(w http.ResponseWriter, r *http.Request) shttp.Response {
 	var result shttp.Result
@@ -69,6 +89,12 @@ func (h *AutoStoreHandler) v1CreateAutoStore(w http.ResponseWriter, r *http.Requ
 	id, err := h.service.CreateAutoStore(r.Context(), autoStore)
 	if err != nil {
 		result.Message = err.Error()
+		if errors.Is(err, helpers.ErrInvalidContact) || errors.Is(err, helpers.ErrInvalidOwner) || errors.Is(err, helpers.ErrInvalidReference) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
+		if errors.Is(err, helpers.ErrUserServiceUnavailable) {
+			return shttp.ResultNew.SetStatusCode(http.StatusServiceUnavailable).SetData(result)
+		}
 		h.logger.Error("unable to create auto store", err)
 		return shttp.InternalServerError.SetData(result)
 	}
@@ -126,13 +152,27 @@ func (h *AutoStoreHandler) v1GetUsers(w http.ResponseWriter, r *http.Request) sh
 
 // v1GetAutoStores
 // @Summary Get AutoStores
-// @Description Get paginated list of auto stores filtered optional search string
+// @Description Get paginated list of auto stores filtered by an optional search string, review status,
+// @Description location, owner and creation day range. Dates sort newest first and names
+// @Description alphabetically unless an order is given.
 // @Tags Auto Store
 // @Accept json
 // @Produce json
 // @Param limit query int false "Limit number of users to return"
 // @Param page query int false "Page number"
 // @Param search query string false "Search string to filter auto stores by name"
+// @Param status query string false "Review status to filter by" Enums(pending, approved, rejected, suspended)
+// @Param region_id query int false "Region ID"
+// @Param city_id query int false "City ID"
+// @Param user_id query int false "Owner user ID"
+// @Param brand_id query int false "Brand the store works with"
+// @Param category query string false "Category slug of the brand link (auto, moto, truck)"
+// @Param brand_role query string false "Role of the brand link" Enums(official_dealer, dealer, service, parts)
+// @Param expired query bool false "Only stores whose subscription has (true) or has not (false) lapsed"
+// @Param created_from query string false "First creation day (YYYY-MM-DD)"
+// @Param created_to query string false "Last creation day (YYYY-MM-DD)"
+// @Param sort_by query string false "Sort key (default created_at)" Enums(name, created_at, updated_at)
+// @Param order query string false "Sort order" Enums(asc, desc)
 // @Success 200 {object} dtos.AutoStoresResult "List of auto stores with pagination info successfully"
 // @Failure 400 {object} string "Bad request"
 // @Failure 500 {object} string "Internal server error"
@@ -153,10 +193,56 @@ func (h *AutoStoreHandler) v1GetAutoStores(w http.ResponseWriter, r *http.Reques
 
 	var result shttp.Result
 
-	autoStores, err := h.service.GetAutoStores(r.Context(), limit, page, search)
+	filter := dtos.AutoStoresFilter{
+		Limit:     limit,
+		Page:      page,
+		Search:    search,
+		Status:    r.URL.Query().Get("status"),
+		Category:  r.URL.Query().Get("category"),
+		BrandRole: r.URL.Query().Get("brand_role"),
+		SortBy:    r.URL.Query().Get("sort_by"),
+		Order:     r.URL.Query().Get("order"),
+	}
+	for name, dst := range map[string]*int64{"region_id": &filter.RegionID, "city_id": &filter.CityID, "user_id": &filter.UserID, "brand_id": &filter.BrandID} {
+		value := r.URL.Query().Get(name)
+		if value == "" {
+			continue
+		}
+		*dst, err = strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			result.Message = fmt.Sprintf("invalid %s: %s", name, err.Error())
+			return shttp.BadRequest.SetData(result)
+		}
+	}
+	for name, dst := range map[string]**time.Time{"created_from": &filter.CreatedFrom, "created_to": &filter.CreatedTo} {
+		value := r.URL.Query().Get(name)
+		if value == "" {
+			continue
+		}
+		day, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			result.Message = fmt.Sprintf("invalid %s: %s", name, err.Error())
+			return shttp.BadRequest.SetData(result)
+		}
+		*dst = &day
+	}
+	if value := r.URL.Query().Get("expired"); value != "" {
+		expired, err := strconv.ParseBool(value)
+		if err != nil {
+			result.Message = fmt.Sprintf("invalid expired: %s", err.Error())
+			return shttp.BadRequest.SetData(result)
+		}
+		filter.Expired = &expired
+	}
+
+	autoStores, err := h.service.GetAutoStores(r.Context(), filter)
 	if err != nil {
 		result.Status = false
 		result.Message = err.Error()
+		var validationErrs validator.ValidationErrors
+		if errors.Is(err, helpers.ErrInvalidStatus) || errors.Is(err, helpers.ErrInvalidDateRange) || errors.As(err, &validationErrs) {
+			return shttp.BadRequest.SetData(result)
+		}
 		h.logger.Error("unable to get autoStores", err)
 		return shttp.InternalServerError.SetData(result)
 	}
@@ -167,9 +253,75 @@ func (h *AutoStoreHandler) v1GetAutoStores(w http.ResponseWriter, r *http.Reques
 	return shttp.Success.SetData(result)
 }
 
+// v1GetNearestAutoStores
+// @Summary Get nearest AutoStores
+// @Description Get auto stores within a radius of a point, sorted by distance
+// @Tags Auto Store
+// @Accept json
+// @Produce json
+// @Param lat query number true "Latitude of the point"
+// @Param lng query number true "Longitude of the point"
+// @Param radius query number false "Search radius in kilometers (default 10)"
+// @Param limit query int false "Maximum number of auto stores to return (default 20)"
+// @Success 200 {object} dtos.AutoStoresResult "Auto stores with distance_km, nearest first"
+// @Failure 400 {object} string "Bad request"
+// @Failure 500 {object} string "Internal server error"
+// @Router /auto-store/get-nearest-auto-stores [get]
+func (h *AutoStoreHandler) v1GetNearestAutoStores(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	lat, err := strconv.ParseFloat(r.URL.Query().Get("lat"), 64)
+	if err != nil {
+		result.Message = "valid lat is required"
+		return shttp.BadRequest.SetData(result)
+	}
+	lng, err := strconv.ParseFloat(r.URL.Query().Get("lng"), 64)
+	if err != nil {
+		result.Message = "valid lng is required"
+		return shttp.BadRequest.SetData(result)
+	}
+
+	radius, err := strconv.ParseFloat(r.URL.Query().Get("radius"), 64)
+	if err != nil || radius <= 0 {
+		radius = 10
+	}
+	limit, err := strconv.ParseInt(r.URL.Query().Get("limit"), 10, 64)
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+
+	req := dtos.NearestAutoStoresReq{
+		Latitude:  lat,
+		Longitude: lng,
+		RadiusKm:  radius,
+		Limit:     limit,
+	}
+
+	autoStores, err := h.service.GetNearestAutoStores(r.Context(), req)
+	if err != nil {
+		result.Message = err.Error()
+		var validationErrs validator.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			return shttp.BadRequest.SetData(result)
+		}
+		h.logger.Error("unable to get nearest autoStores", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "List of nearest auto stores successfully"
+	result.Data = autoStores
+	return shttp.Success.SetData(result)
+}
+
 // v1UpdateAutoStore
 // @Summary Update an existing auto store
-// @Description Updates auto store details by ID
+// @Description Updates auto store details by ID. A new owner must be a user of the user service who has
+// @Description not reached the configured store limit. A store on a subscription plan cannot have more
+// @Description gallery images than the plan allows. The district must lie in the city and the city in the region.
+// @Description Without contacts the stored contacts are kept; phone_number and email then only replace the first
+// @Description phone and email contact, and an empty one removes it.
 // @Tags Auto Store
 // @Accept json
 // @Produce json
@@ -178,6 +330,7 @@ func (h *AutoStoreHandler) v1GetAutoStores(w http.ResponseWriter, r *http.Reques
 // @Failure 400 {object} string "Bad request"
 // @Failure 422 {object} string "Unprocessable entity"
 // @Failure 500 {object} string "Internal server error"
+// @Failure 503 {object} string "User service unavailable"
 // @Router /auto-store/update-auto-store [put]
 func (h *AutoStoreHandler) v1UpdateAutoStore(w http.ResponseWriter, r *http.Request) shttp.Response {
 	var result shttp.Result
@@ -202,6 +355,13 @@ func (h *AutoStoreHandler) v1UpdateAutoStore(w http.ResponseWriter, r *http.Requ
 	id, err := h.service.UpdateAutoStore(r.Context(), autoStoreDTO)
 	if err != nil {
 		result.Message = err.Error()
+		if errors.Is(err, helpers.ErrInvalidContact) || errors.Is(err, helpers.ErrInvalidOwner) || errors.Is(err, helpers.ErrInvalidReference) ||
+			errors.Is(err, helpers.ErrPlanLimitExceeded) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
+		if errors.Is(err, helpers.ErrUserServiceUnavailable) {
+			return shttp.ResultNew.SetStatusCode(http.StatusServiceUnavailable).SetData(result)
+		}
 		h.logger.Error("unable to update auto store", err)
 		return shttp.InternalServerError.SetData(result)
 	}
@@ -252,3 +412,365 @@ func (h *AutoStoreHandler) v1DeleteAutoStore(w http.ResponseWriter, r *http.Requ
 	result.Message = "Auto Store Deleted Successfully"
 	return shttp.Success.SetData(result)
 }
+
+// v1SetAutoStoreSchedule
+// @Summary Set auto store schedule
+// @Description Replaces the weekly opening hours and closure days of an auto store.
+// @Description Times are HH:MM in the configured time zone; a weekday may have several intervals.
+// @Tags Auto Store
+// @Accept json
+// @Produce json
+// @Param schedule body dtos.AutoStoreSchedule true "Schedule"
+// @Success 200 {object} string "Schedule saved successfully"
+// @Failure 400 {object} string "Bad request"
+// @Failure 422 {object} string "Unprocessable entity"
+// @Failure 500 {object} string "Internal server error"
+// @Router /auto-store/set-auto-store-schedule [put]
+func (h *AutoStoreHandler) v1SetAutoStoreSchedule(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	body, errBody := io.ReadAll(r.Body)
+	if errBody != nil {
+		result.Message = errBody.Error()
+		h.logger.Error("unable to read request body", errBody)
+		return shttp.BadRequest.SetData(result)
+	}
+	defer r.Body.Close()
+
+	var scheduleDTO dtos.AutoStoreSchedule
+	errData := json.Unmarshal(body, &scheduleDTO)
+	if errData != nil {
+		result.Message = errData.Error()
+		h.logger.Error("unable to unmarshal request body", errData)
+		return shttp.UnprocessableEntity.SetData(result)
+	}
+
+	err := h.service.SetAutoStoreSchedule(r.Context(), scheduleDTO)
+	if err != nil {
+		result.Message = err.Error()
+		if errors.Is(err, helpers.ErrInvalidSchedule) || errors.Is(err, helpers.ErrInvalidReference) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
+		h.logger.Error("unable to set auto store schedule", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Schedule saved successfully"
+	return shttp.Success.SetData(result)
+}
+
+// v1GetAutoStoreSchedule
+// @Summary Get auto store schedule
+// @Description Returns the weekly opening hours of an auto store and its closure days from today on
+// @Tags Auto Store
+// @Accept json
+// @Produce json
+// @Param auto_store_id query int true "Auto store ID"
+// @Success 200 {object} dtos.AutoStoreSchedule
+// @Failure 400 {object} string "Bad request"
+// @Failure 500 {object} string "Internal server error"
+// @Router /auto-store/get-auto-store-schedule [get]
+func (h *AutoStoreHandler) v1GetAutoStoreSchedule(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	idStr := r.URL.Query().Get("auto_store_id")
+	if idStr == "" {
+		result.Message = "missing auto store ID"
+		return shttp.BadRequest.SetData(result)
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("invalid auto store ID", err)
+		return shttp.BadRequest.SetData(result)
+	}
+
+	schedule, err := h.service.GetAutoStoreSchedule(r.Context(), id)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("unable to get auto store schedule", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Auto store schedule"
+	result.Data = schedule
+	return shttp.Success.SetData(result)
+}
+
+// v1ApproveAutoStore
+// @Summary Approve auto store
+// @Description Publishes a pending, rejected or suspended auto store. The reason is ignored.
+// @Description The reviewer recorded in the status history is the authenticated admin.
+// @Tags Auto Store
+// @Accept json
+// @Produce json
+// @Param review body dtos.ReviewAutoStoreReq true "Review"
+// @Success 200 {object} string "Auto store approved successfully"
+// @Failure 400 {object} string "Bad request"
+// @Failure 401 {object} string "Unauthorized"
+// @Failure 409 {object} string "Status transition not allowed"
+// @Failure 422 {object} string "Unprocessable entity"
+// @Failure 500 {object} string "Internal server error"
+// @Security ApiKeyAuth
+// @Router /auto-store/approve-auto-store [put]
+func (h *AutoStoreHandler) v1ApproveAutoStore(w http.ResponseWriter, r *http.Request, claims shttp.AuthClaims) shttp.Response {
+	return h.reviewAutoStore(r, claims, h.service.ApproveAutoStore, "Auto store approved successfully")
+}
+
+// v1RejectAutoStore
+// @Summary Reject auto store
+// @Description Rejects a pending auto store. A reason is required.
+// @Description The reviewer recorded in the status history is the authenticated admin.
+// @Tags Auto Store
+// @Accept json
+// @Produce json
+// @Param review body dtos.ReviewAutoStoreReq true "Review"
+// @Success 200 {object} string "Auto store rejected successfully"
+// @Failure 400 {object} string "Bad request"
+// @Failure 401 {object} string "Unauthorized"
+// @Failure 409 {object} string "Status transition not allowed"
+// @Failure 422 {object} string "Unprocessable entity"
+// @Failure 500 {object} string "Internal server error"
+// @Security ApiKeyAuth
+// @Router /auto-store/reject-auto-store [put]
+func (h *AutoStoreHandler) v1RejectAutoStore(w http.ResponseWriter, r *http.Request, claims shttp.AuthClaims) shttp.Response {
+	return h.reviewAutoStore(r, claims, h.service.RejectAutoStore, "Auto store rejected successfully")
+}
+
+// v1SuspendAutoStore
+// @Summary Suspend auto store
+// @Description Hides an approved auto store until it is approved again. A reason is required.
+// @Description The reviewer recorded in the status history is the authenticated admin.
+// @Tags Auto Store
+// @Accept json
+// @Produce json
+// @Param review body dtos.ReviewAutoStoreReq true "Review"
+// @Success 200 {object} string "Auto store suspended successfully"
+// @Failure 400 {object} string "Bad request"
+// @Failure 401 {object} string "Unauthorized"
+// @Failure 409 {object} string "Status transition not allowed"
+// @Failure 422 {object} string "Unprocessable entity"
+// @Failure 500 {object} string "Internal server error"
+// @Security ApiKeyAuth
+// @Router /auto-store/suspend-auto-store [put]
+func (h *AutoStoreHandler) v1SuspendAutoStore(w http.ResponseWriter, r *http.Request, claims shttp.AuthClaims) shttp.Response {
+	return h.reviewAutoStore(r, claims, h.service.SuspendAutoStore, "Auto store suspended successfully")
+}
+
+// reviewAutoStore decodes a review request and applies it with change on behalf
+// of the authenticated admin.
+func (h *AutoStoreHandler) reviewAutoStore(r *http.Request, claims shttp.AuthClaims, change func(context.Context, dtos.ReviewAutoStoreReq) error, message string) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	body, errBody := io.ReadAll(r.Body)
+	if errBody != nil {
+		result.Message = errBody.Error()
+		h.logger.Error("unable to read request body", errBody)
+		return shttp.BadRequest.SetData(result)
+	}
+	defer r.Body.Close()
+
+	var review dtos.ReviewAutoStoreReq
+	errData := json.Unmarshal(body, &review)
+	if errData != nil {
+		result.Message = errData.Error()
+		h.logger.Error("unable to unmarshal request body", errData)
+		return shttp.UnprocessableEntity.SetData(result)
+	}
+	review.ReviewerID = claims.Id
+
+	err := change(r.Context(), review)
+	if err != nil {
+		result.Message = err.Error()
+		if errors.Is(err, helpers.ErrInvalidStatus) {
+			return shttp.BadRequest.SetData(result)
+		}
+		if errors.Is(err, helpers.ErrInvalidTransition) {
+			return shttp.Conflict.SetData(result)
+		}
+		if errors.Is(err, helpers.ErrInvalidReference) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
+		h.logger.Error("unable to change auto store status", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = message
+	return shttp.Success.SetData(result)
+}
+
+// v1GetAutoStoreStatusHistory
+// @Summary Get auto store status history
+// @Description Returns every review status change of an auto store, oldest first
+// @Tags Auto Store
+// @Accept json
+// @Produce json
+// @Param auto_store_id query int true "Auto store ID"
+// @Success 200 {array} dtos.AutoStoreStatusChange
+// @Failure 400 {object} string "Bad request"
+// @Failure 500 {object} string "Internal server error"
+// @Router /auto-store/get-auto-store-status-history [get]
+func (h *AutoStoreHandler) v1GetAutoStoreStatusHistory(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	idStr := r.URL.Query().Get("auto_store_id")
+	if idStr == "" {
+		result.Message = "missing auto store ID"
+		return shttp.BadRequest.SetData(result)
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("invalid auto store ID", err)
+		return shttp.BadRequest.SetData(result)
+	}
+
+	history, err := h.service.GetAutoStoreStatusHistory(r.Context(), id)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("unable to get auto store status history", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Auto store status history"
+	result.Data = history
+	return shttp.Success.SetData(result)
+}
+
+// v1GetAutoStoreBySlug
+// @Summary Get auto store by slug
+// @Description Public lookup of an approved auto store by its slug. Former slugs still resolve with
+// @Description redirect set to true; the current slug is auto_store.slug.
+// @Tags Auto Store
+// @Accept json
+// @Produce json
+// @Param slug query string true "Store slug"
+// @Success 200 {object} dtos.AutoStoreBySlugResult
+// @Failure 400 {object} string "Bad request"
+// @Failure 404 {object} string "Auto store not found"
+// @Failure 500 {object} string "Internal server error"
+// @Router /auto-store/get-auto-store-by-slug [get]
+func (h *AutoStoreHandler) v1GetAutoStoreBySlug(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	slug := r.URL.Query().Get("slug")
+	if slug == "" {
+		result.Message = "slug is required"
+		return shttp.BadRequest.SetData(result)
+	}
+
+	autoStore, err := h.service.GetAutoStoreBySlug(r.Context(), slug)
+	if err != nil {
+		result.Message = err.Error()
+		if errors.Is(err, helpers.ErrNotFound) {
+			return shttp.ResultNew.SetStatusCode(http.StatusNotFound).SetData(result)
+		}
+		h.logger.Error("unable to get auto store by slug", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Auto store"
+	result.Data = autoStore
+	return shttp.Success.SetData(result)
+}
+
+// v1SetAutoStoreBrands
+// @Summary Set auto store brands
+// @Description Replaces the brands an auto store works with, each in a role. A link without a category
+// @Description covers every category of the brand.
+// @Tags Auto Store
+// @Accept json
+// @Produce json
+// @Param brands body dtos.SetAutoStoreBrandsReq true "Brand links"
+// @Success 200 {object} string "Brands saved successfully"
+// @Failure 400 {object} string "Bad request"
+// @Failure 422 {object} string "Unprocessable entity"
+// @Failure 500 {object} string "Internal server error"
+// @Router /auto-store/set-auto-store-brands [put]
+func (h *AutoStoreHandler) v1SetAutoStoreBrands(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	body, errBody := io.ReadAll(r.Body)
+	if errBody != nil {
+		result.Message = errBody.Error()
+		h.logger.Error("unable to read request body", errBody)
+		return shttp.BadRequest.SetData(result)
+	}
+	defer r.Body.Close()
+
+	var brandsDTO dtos.SetAutoStoreBrandsReq
+	errData := json.Unmarshal(body, &brandsDTO)
+	if errData != nil {
+		result.Message = errData.Error()
+		h.logger.Error("unable to unmarshal request body", errData)
+		return shttp.UnprocessableEntity.SetData(result)
+	}
+
+	err := h.service.SetAutoStoreBrands(r.Context(), brandsDTO)
+	if err != nil {
+		result.Message = err.Error()
+		if errors.Is(err, helpers.ErrInvalidReference) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
+		h.logger.Error("unable to set auto store brands", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Brands saved successfully"
+	return shttp.Success.SetData(result)
+}
+
+// v1GetAutoStoreBrands
+// @Summary Get auto store brands
+// @Description Returns the brands an auto store works with, ordered by brand name
+// @Tags Auto Store
+// @Accept json
+// @Produce json
+// @Param auto_store_id query int true "Auto store ID"
+// @Success 200 {array} dtos.AutoStoreBrand
+// @Failure 400 {object} string "Bad request"
+// @Failure 500 {object} string "Internal server error"
+// @Router /auto-store/get-auto-store-brands [get]
+func (h *AutoStoreHandler) v1GetAutoStoreBrands(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	idStr := r.URL.Query().Get("auto_store_id")
+	if idStr == "" {
+		result.Message = "missing auto store ID"
+		return shttp.BadRequest.SetData(result)
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("invalid auto store ID", err)
+		return shttp.BadRequest.SetData(result)
+	}
+
+	brands, err := h.service.GetAutoStoreBrands(r.Context(), id)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("unable to get auto store brands", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Auto store brands"
+	result.Data = brands
+	return shttp.Success.SetData(result)
+}
@@ -2,9 +2,12 @@ package http
 
 import (
 	"autotm-admin/internal/dtos"
+	"autotm-admin/internal/helpers"
 	"autotm-admin/internal/services/repository"
 	"encoding/json"
+	"errors"
 	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
 	shttp "github.com/salamsites/package-http"
 	slog "github.com/salamsites/package-log"
 	"io"
@@ -44,6 +47,17 @@ func (h *BrandHandler) BrandRegisterRoutes(r chi.Router) {
 	r.Method("GET", "/get-models", h.middleware.Base(h.v1GetModels))
 	r.Method("PUT", "/update-model", h.middleware.Base(h.v1UpdateModel))
 	r.Method("DELETE", "/delete-model", h.middleware.Base(h.v1DeleteModel))
+
+	// WMI Code
+	r.Method("POST", "/create-wmi-code", h.middleware.Base(h.v1CreateWMICode))
+	r.Method("GET", "/get-wmi-codes", h.middleware.Base(h.v1GetWMICodes))
+	r.Method("PUT", "/update-wmi-code", h.middleware.Base(h.v1UpdateWMICode))
+	r.Method("DELETE", "/delete-wmi-code", h.middleware.Base(h.v1DeleteWMICode))
+	r.Method("GET", "/decode-vin", h.middleware.Base(h.v1DecodeVIN))
+
+	// Merge
+	r.Method("POST", "/merge-brands", h.middleware.Base(h.v1MergeBrands))
+	r.Method("POST", "/merge-models", h.middleware.Base(h.v1MergeModels))
 }
 
 // v1CreateBodyType
@@ -81,6 +95,9 @@ func (h *BrandHandler) v1CreateBodyType(w http.ResponseWriter, r *http.Request)
 	id, err := h.service.CreateBodyType(r.Context(), bodyTypeDTO)
 	if err != nil {
 		result.Message = err.Error()
+		if errors.Is(err, helpers.ErrInvalidReference) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
 		h.logger.Error("unable to create body type", err)
 		return shttp.InternalServerError.SetData(result)
 	}
@@ -97,7 +114,7 @@ func (h *BrandHandler) v1CreateBodyType(w http.ResponseWriter, r *http.Request)
 // @Tags Body Type
 // @Accept json
 // @Produce json
-// @Param category query string true "Category filter (auto, moto, truck)"
+// @Param category query string true "Category slug filter (e.g. auto, moto, truck)"
 // @Param limit query int false "Limit number of body types to return"
 // @Param page query int false "Page number"
 // @Param search query string false "Search string to filter body types by name"
@@ -175,6 +192,9 @@ func (h *BrandHandler) v1UpdateBodyType(w http.ResponseWriter, r *http.Request)
 	id, err := h.service.UpdateBodyType(r.Context(), bodyTypeDTO)
 	if err != nil {
 		result.Message = err.Error()
+		if errors.Is(err, helpers.ErrInvalidReference) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
 		h.logger.Error("unable to update body type ", err)
 		return shttp.InternalServerError.SetData(result)
 	}
@@ -261,6 +281,9 @@ func (h *BrandHandler) v1CreateBrand(w http.ResponseWriter, r *http.Request) sht
 	id, err := h.service.CreateBrand(r.Context(), brandDTO)
 	if err != nil {
 		result.Message = err.Error()
+		if errors.Is(err, helpers.ErrInvalidReference) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
 		h.logger.Error("unable to create brand", err)
 		return shttp.InternalServerError.SetData(result)
 	}
@@ -277,7 +300,7 @@ func (h *BrandHandler) v1CreateBrand(w http.ResponseWriter, r *http.Request) sht
 // @Tags Brand
 // @Accept json
 // @Produce json
-// @Param category query string true "Category filter (auto, moto, truck)"
+// @Param category query string true "Category slug filter (e.g. auto, moto, truck)"
 // @Param limit query int false "Limit number of brands to return"
 // @Param page query int false "Page number"
 // @Param search query string false "Search string to filter brands by name"
@@ -355,6 +378,9 @@ func (h *BrandHandler) v1UpdateBrand(w http.ResponseWriter, r *http.Request) sht
 	id, err := h.service.UpdateBrand(r.Context(), brandDTO)
 	if err != nil {
 		result.Message = err.Error()
+		if errors.Is(err, helpers.ErrInvalidReference) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
 		h.logger.Error("unable to update brand", err)
 		return shttp.InternalServerError.SetData(result)
 	}
@@ -372,7 +398,7 @@ func (h *BrandHandler) v1UpdateBrand(w http.ResponseWriter, r *http.Request) sht
 // @Accept json
 // @Produce json
 // @Param id query int true "Brand ID to delete"
-// @Param category query string true "Brand Category to delete (auto, moto, truck)"
+// @Param category query string true "Brand category slug to delete (e.g. auto, moto, truck)"
 // @Success 200 {object} string "Brand deleted successfully"
 // @Failure 400 {object} string "Bad request"
 // @Failure 404 {object} string "Brand not found"
@@ -447,6 +473,9 @@ func (h *BrandHandler) v1CreateModel(w http.ResponseWriter, r *http.Request) sht
 	id, err := h.service.CreateModel(r.Context(), modelDTO)
 	if err != nil {
 		result.Message = err.Error()
+		if errors.Is(err, helpers.ErrInvalidReference) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
 		h.logger.Error("unable to create model", err)
 		return shttp.InternalServerError.SetData(result)
 	}
@@ -463,7 +492,7 @@ func (h *BrandHandler) v1CreateModel(w http.ResponseWriter, r *http.Request) sht
 // @Tags Model
 // @Accept json
 // @Produce json
-// @Param category query string true "Category filter (auto, moto, truck)"
+// @Param category query string true "Category slug filter (e.g. auto, moto, truck)"
 // @Param limit query int false "Limit number of models to return"
 // @Param page query int false "Page number"
 // @Param search query string false "Search string to filter models or brands by name or body types by name"
@@ -541,6 +570,9 @@ func (h *BrandHandler) v1UpdateModel(w http.ResponseWriter, r *http.Request) sht
 	id, err := h.service.UpdateModel(r.Context(), modelDTO)
 	if err != nil {
 		result.Message = err.Error()
+		if errors.Is(err, helpers.ErrInvalidReference) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
 		h.logger.Error("unable to update model", err)
 		return shttp.InternalServerError.SetData(result)
 	}
@@ -591,3 +623,330 @@ func (h *BrandHandler) v1DeleteModel(w http.ResponseWriter, r *http.Request) sht
 	result.Message = "Model Deleted Successfully"
 	return shttp.Success.SetData(result)
 }
+
+// v1MergeBrands
+// @Summary Merge duplicate brands
+// @Description Moves every model, category and reference of the source brand to the target brand in one transaction, deletes the source and records the merge
+// @Tags Brand
+// @Accept json
+// @Produce json
+// @Param merge body dtos.MergeReq true "Source and target brand IDs"
+// @Success 200 {object} dtos.MergeResult "Number of re-pointed rows per table and of merged duplicate models"
+// @Failure 400 {object} string "Bad request, e.g. source and target are the same"
+// @Failure 422 {object} string "Source or target brand not found"
+// @Failure 500 {object} string "Internal server error"
+// @Router /brand/merge-brands [post]
+func (h *BrandHandler) v1MergeBrands(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	body, errBody := io.ReadAll(r.Body)
+	if errBody != nil {
+		result.Message = errBody.Error()
+		h.logger.Error("unable to read request body", errBody)
+		return shttp.BadRequest.SetData(result)
+	}
+	defer r.Body.Close()
+
+	var mergeDTO dtos.MergeReq
+	errData := json.Unmarshal(body, &mergeDTO)
+	if errData != nil {
+		result.Message = errData.Error()
+		h.logger.Error("unable to unmarshal request body", errData)
+		return shttp.UnprocessableEntity.SetData(result)
+	}
+
+	merged, err := h.service.MergeBrands(r.Context(), mergeDTO)
+	if err != nil {
+		result.Message = err.Error()
+		var validationErrs validator.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			return shttp.BadRequest.SetData(result)
+		}
+		if errors.Is(err, helpers.ErrInvalidReference) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
+		h.logger.Error("unable to merge brands", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Brands Merged Successfully"
+	result.Data = merged
+	return shttp.Success.SetData(result)
+}
+
+// v1MergeModels
+// @Summary Merge duplicate models
+// @Description Moves every reference of the source model to the target model in one transaction, deletes the source and records the merge
+// @Tags Model
+// @Accept json
+// @Produce json
+// @Param merge body dtos.MergeReq true "Source and target model IDs"
+// @Success 200 {object} dtos.MergeResult "Number of re-pointed rows per table"
+// @Failure 400 {object} string "Bad request, e.g. source and target are the same"
+// @Failure 422 {object} string "Source or target model not found"
+// @Failure 500 {object} string "Internal server error"
+// @Router /brand/merge-models [post]
+func (h *BrandHandler) v1MergeModels(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	body, errBody := io.ReadAll(r.Body)
+	if errBody != nil {
+		result.Message = errBody.Error()
+		h.logger.Error("unable to read request body", errBody)
+		return shttp.BadRequest.SetData(result)
+	}
+	defer r.Body.Close()
+
+	var mergeDTO dtos.MergeReq
+	errData := json.Unmarshal(body, &mergeDTO)
+	if errData != nil {
+		result.Message = errData.Error()
+		h.logger.Error("unable to unmarshal request body", errData)
+		return shttp.UnprocessableEntity.SetData(result)
+	}
+
+	merged, err := h.service.MergeModels(r.Context(), mergeDTO)
+	if err != nil {
+		result.Message = err.Error()
+		var validationErrs validator.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			return shttp.BadRequest.SetData(result)
+		}
+		if errors.Is(err, helpers.ErrInvalidReference) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
+		h.logger.Error("unable to merge models", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Models Merged Successfully"
+	result.Data = merged
+	return shttp.Success.SetData(result)
+}
+
+// v1CreateWMICode
+// @Summary Create a WMI code
+// @Description Links a World Manufacturer Identifier prefix (2 or 3 characters) to a brand
+// @Tags WMI Code
+// @Accept json
+// @Produce json
+// @Param wmi body dtos.CreateWMICodeReq true "WMI code data"
+// @Success 200 {object} dtos.ID "Returns created WMI code ID"
+// @Failure 400 {object} string "Bad request"
+// @Failure 422 {object} string "Unprocessable entity"
+// @Failure 500 {object} string "Internal server error"
+// @Router /brand/create-wmi-code [post]
+func (h *BrandHandler) v1CreateWMICode(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	body, errBody := io.ReadAll(r.Body)
+	if errBody != nil {
+		result.Message = errBody.Error()
+		h.logger.Error("unable to read request body", errBody)
+		return shttp.BadRequest.SetData(result)
+	}
+	defer r.Body.Close()
+
+	var wmiDTO dtos.CreateWMICodeReq
+	errData := json.Unmarshal(body, &wmiDTO)
+	if errData != nil {
+		result.Message = errData.Error()
+		h.logger.Error("unable to unmarshal request body", errData)
+		return shttp.UnprocessableEntity.SetData(result)
+	}
+
+	id, err := h.service.CreateWMICode(r.Context(), wmiDTO)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("unable to create wmi code", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "WMI Code Create Successfully"
+	result.Data = id
+	return shttp.Success.SetData(result)
+}
+
+// v1GetWMICodes
+// @Summary Get WMI codes
+// @Description Get a paginated list of WMI codes with optional brand and search filters
+// @Tags WMI Code
+// @Accept json
+// @Produce json
+// @Param brand_id query int false "Brand ID filter"
+// @Param limit query int false "Limit number of WMI codes to return"
+// @Param page query int false "Page number"
+// @Param search query string false "Search by code, manufacturer or brand name"
+// @Success 200 {object} dtos.WMICodeResult "List of WMI codes with pagination info"
+// @Failure 400 {object} string "Bad request"
+// @Failure 500 {object} string "Internal server error"
+// @Router /brand/get-wmi-codes [get]
+func (h *BrandHandler) v1GetWMICodes(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	limitStr := r.URL.Query().Get("limit")
+	pageStr := r.URL.Query().Get("page")
+	search := r.URL.Query().Get("search")
+
+	var brandID int64
+	if brandIDStr := r.URL.Query().Get("brand_id"); brandIDStr != "" {
+		id, err := strconv.ParseInt(brandIDStr, 10, 64)
+		if err != nil {
+			result.Message = err.Error()
+			h.logger.Error("invalid brand ID", err)
+			return shttp.BadRequest.SetData(result)
+		}
+		brandID = id
+	}
+
+	limit, err := strconv.ParseInt(limitStr, 10, 64)
+	if err != nil || limit <= 0 {
+		limit = 10
+	}
+	page, err := strconv.ParseInt(pageStr, 10, 64)
+	if err != nil || page <= 0 {
+		page = 1
+	}
+
+	codes, err := h.service.GetWMICodes(r.Context(), limit, page, brandID, search)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("unable to get wmi codes", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "WMI Codes Get Successfully"
+	result.Data = codes
+	return shttp.Success.SetData(result)
+}
+
+// v1UpdateWMICode
+// @Summary Update a WMI code
+// @Description Updates a WMI code by ID
+// @Tags WMI Code
+// @Accept json
+// @Produce json
+// @Param wmi body dtos.UpdateWMICodeReq true "WMI code data"
+// @Success 200 {object} dtos.ID "Returns updated WMI code ID"
+// @Failure 400 {object} string "Bad request"
+// @Failure 422 {object} string "Unprocessable entity"
+// @Failure 500 {object} string "Internal server error"
+// @Router /brand/update-wmi-code [put]
+func (h *BrandHandler) v1UpdateWMICode(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	body, errBody := io.ReadAll(r.Body)
+	if errBody != nil {
+		result.Message = errBody.Error()
+		h.logger.Error("unable to read request body", errBody)
+		return shttp.BadRequest.SetData(result)
+	}
+	defer r.Body.Close()
+
+	var wmiDTO dtos.UpdateWMICodeReq
+	errData := json.Unmarshal(body, &wmiDTO)
+	if errData != nil {
+		result.Message = errData.Error()
+		h.logger.Error("unable to unmarshal request body", errData)
+		return shttp.UnprocessableEntity.SetData(result)
+	}
+
+	id, err := h.service.UpdateWMICode(r.Context(), wmiDTO)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("unable to update wmi code", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "WMI Code Update Successfully"
+	result.Data = id
+	return shttp.Success.SetData(result)
+}
+
+// v1DeleteWMICode
+// @Summary Delete a WMI code
+// @Description Deletes a WMI code by ID
+// @Tags WMI Code
+// @Accept json
+// @Produce json
+// @Param id query int true "WMI code ID to delete"
+// @Success 200 {object} string "WMI code deleted successfully"
+// @Failure 400 {object} string "Bad request"
+// @Failure 500 {object} string "Internal server error"
+// @Router /brand/delete-wmi-code [delete]
+func (h *BrandHandler) v1DeleteWMICode(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	idStr := r.URL.Query().Get("id")
+	if idStr == "" {
+		result.Message = "id is required"
+		return shttp.BadRequest.SetData(result)
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("invalid wmi code ID", err)
+		return shttp.BadRequest.SetData(result)
+	}
+
+	err = h.service.DeleteWMICode(r.Context(), id)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("unable to delete wmi code", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "WMI Code Deleted Successfully"
+	return shttp.Success.SetData(result)
+}
+
+// v1DecodeVIN
+// @Summary Decode a VIN
+// @Description Validates a 17-character VIN and its check digit, and returns the manufacturer, the matched brand, the model year code and the region of manufacture
+// @Tags WMI Code
+// @Accept json
+// @Produce json
+// @Param vin query string true "Vehicle identification number"
+// @Success 200 {object} dtos.VINDecodeResult "Decoded VIN"
+// @Failure 400 {object} string "Bad request"
+// @Failure 422 {object} string "Invalid VIN"
+// @Failure 500 {object} string "Internal server error"
+// @Router /brand/decode-vin [get]
+func (h *BrandHandler) v1DecodeVIN(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	vin := r.URL.Query().Get("vin")
+	if vin == "" {
+		result.Message = "vin is required"
+		return shttp.BadRequest.SetData(result)
+	}
+
+	decoded, err := h.service.DecodeVIN(r.Context(), vin)
+	if err != nil {
+		result.Message = err.Error()
+		if errors.Is(err, helpers.ErrInvalidVIN) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
+		h.logger.Error("unable to decode vin", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "VIN Decoded Successfully"
+	result.Data = decoded
+	return shttp.Success.SetData(result)
+}
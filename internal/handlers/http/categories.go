@@ -0,0 +1,221 @@
+package http
+
+import (
+	"autotm-admin/internal/dtos"
+	"autotm-admin/internal/helpers"
+	"autotm-admin/internal/services/repository"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	shttp "github.com/salamsites/package-http"
+	slog "github.com/salamsites/package-log"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+type CategoryHandler struct {
+	logger     *slog.Logger
+	middleware *shttp.Middleware
+	service    repository.CategoryService
+}
+
+func NewCategoryHandler(logger *slog.Logger, middleware *shttp.Middleware, service repository.CategoryService) *CategoryHandler {
+	return &CategoryHandler{
+		logger:     logger,
+		middleware: middleware,
+		service:    service,
+	}
+}
+
+func (h *CategoryHandler) CategoryRegisterRoutes(r chi.Router) {
+	r.Method("POST", "/create-category", h.middleware.Base(h.v1CreateCategory))
+	r.Method("GET", "/get-categories", h.middleware.Base(h.v1GetCategories))
+	r.Method("PUT", "/update-category", h.middleware.Base(h.v1UpdateCategory))
+	r.Method("DELETE", "/delete-category", h.middleware.Base(h.v1DeleteCategory))
+}
+
+// v1CreateCategory
+// @Summary Create a new category
+// @Description Creates a new vehicle category with localized names, icon and active flag
+// @Tags Category
+// @Accept json
+// @Produce json
+// @Param category body dtos.CreateCategoryReq true "Category data"
+// @Success 200 {object} dtos.ID "Returns created category ID"
+// @Failure 400 {object} string "Bad request"
+// @Failure 422 {object} string "Unprocessable entity"
+// @Failure 500 {object} string "Internal server error"
+// @Router /categories/create-category [post]
+func (h *CategoryHandler) v1CreateCategory(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	body, errBody := io.ReadAll(r.Body)
+	if errBody != nil {
+		result.Message = errBody.Error()
+		h.logger.Error("unable to read request body", errBody)
+		return shttp.BadRequest.SetData(result)
+	}
+	defer r.Body.Close()
+
+	var categoryDTO dtos.CreateCategoryReq
+	errData := json.Unmarshal(body, &categoryDTO)
+	if errData != nil {
+		result.Message = errData.Error()
+		h.logger.Error("unable to unmarshal request body", errData)
+		return shttp.UnprocessableEntity.SetData(result)
+	}
+
+	id, err := h.service.CreateCategory(r.Context(), categoryDTO)
+	if err != nil {
+		result.Message = err.Error()
+		var validationErrs validator.ValidationErrors
+		if errors.Is(err, helpers.ErrUnsupportedLocale) || errors.As(err, &validationErrs) {
+			return shttp.BadRequest.SetData(result)
+		}
+		h.logger.Error("unable to create category", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Category Create Successfully"
+	result.Data = id
+	return shttp.Success.SetData(result)
+}
+
+// v1GetCategories
+// @Summary Get categories
+// @Description Get paginated list of vehicle categories filtered by optional search string
+// @Tags Category
+// @Accept json
+// @Produce json
+// @Param limit query int false "Limit number of categories to return"
+// @Param page query int false "Page number"
+// @Param search query string false "Search string to filter categories by slug or name"
+// @Param active query bool false "Return only active categories"
+// @Success 200 {object} dtos.CategoryResult "List of categories with pagination info"
+// @Failure 400 {object} string "Bad request"
+// @Failure 500 {object} string "Internal server error"
+// @Router /categories/get-categories [get]
+func (h *CategoryHandler) v1GetCategories(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	limitStr := r.URL.Query().Get("limit")
+	pageStr := r.URL.Query().Get("page")
+	search := r.URL.Query().Get("search")
+	activeOnly, _ := strconv.ParseBool(r.URL.Query().Get("active"))
+
+	limit, err := strconv.ParseInt(limitStr, 10, 64)
+	if err != nil || limit <= 0 {
+		limit = 10
+	}
+	page, err := strconv.ParseInt(pageStr, 10, 64)
+	if err != nil || page <= 0 {
+		page = 1
+	}
+
+	categories, err := h.service.GetCategories(r.Context(), limit, page, search, activeOnly)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("unable to get categories", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Categories Get Successfully"
+	result.Data = categories
+	return shttp.Success.SetData(result)
+}
+
+// v1UpdateCategory
+// @Summary Update an existing category
+// @Description Updates category details by ID
+// @Tags Category
+// @Accept json
+// @Produce json
+// @Param category body dtos.UpdateCategoryReq true "Category data with ID"
+// @Success 200 {object} dtos.ID "Returns updated category ID"
+// @Failure 400 {object} string "Bad request"
+// @Failure 422 {object} string "Unprocessable entity"
+// @Failure 500 {object} string "Internal server error"
+// @Router /categories/update-category [put]
+func (h *CategoryHandler) v1UpdateCategory(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	body, errBody := io.ReadAll(r.Body)
+	if errBody != nil {
+		result.Message = errBody.Error()
+		h.logger.Error("unable to read request body", errBody)
+		return shttp.BadRequest.SetData(result)
+	}
+	defer r.Body.Close()
+
+	var categoryDTO dtos.UpdateCategoryReq
+	errData := json.Unmarshal(body, &categoryDTO)
+	if errData != nil {
+		result.Message = errData.Error()
+		h.logger.Error("unable to unmarshal request body", errData)
+		return shttp.UnprocessableEntity.SetData(result)
+	}
+
+	id, err := h.service.UpdateCategory(r.Context(), categoryDTO)
+	if err != nil {
+		result.Message = err.Error()
+		var validationErrs validator.ValidationErrors
+		if errors.Is(err, helpers.ErrUnsupportedLocale) || errors.As(err, &validationErrs) {
+			return shttp.BadRequest.SetData(result)
+		}
+		h.logger.Error("unable to update category", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Category Update Successfully"
+	result.Data = id
+	return shttp.Success.SetData(result)
+}
+
+// v1DeleteCategory
+// @Summary Delete a category
+// @Description Deletes a category by ID. Categories still referenced by brands, models or body types cannot be deleted
+// @Tags Category
+// @Accept json
+// @Produce json
+// @Param id query int true "Category ID to delete"
+// @Success 200 {object} string "Category deleted successfully"
+// @Failure 400 {object} string "Bad request"
+// @Failure 404 {object} string "Category not found"
+// @Failure 500 {object} string "Internal server error"
+// @Router /categories/delete-category [delete]
+func (h *CategoryHandler) v1DeleteCategory(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	idStr := r.URL.Query().Get("id")
+	if idStr == "" {
+		result.Message = "id is required"
+		return shttp.BadRequest.SetData(result)
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("invalid category ID", err)
+		return shttp.BadRequest.SetData(result)
+	}
+
+	err = h.service.DeleteCategory(r.Context(), id)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("unable to delete category", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Category deleted successfully"
+	return shttp.Success.SetData(result)
+}
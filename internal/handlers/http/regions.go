@@ -2,8 +2,10 @@ package http
 
 import (
 	"autotm-admin/internal/dtos"
+	"autotm-admin/internal/helpers"
 	"autotm-admin/internal/services/repository"
 	"encoding/json"
+	"errors"
 	"github.com/go-chi/chi/v5"
 	shttp "github.com/salamsites/package-http"
 	slog "github.com/salamsites/package-log"
@@ -37,6 +39,14 @@ func (h *RegionsHandler) RegionsRegisterRoutes(r chi.Router) {
 	r.Method("GET", "/get-cities", h.middleware.Base(h.v1GetAllCities))
 	r.Method("PUT", "/update-city", h.middleware.Base(h.v1UpdateCity))
 	r.Method("DELETE", "/delete-city", h.middleware.Base(h.v1DeleteCity))
+
+	//Districts
+	r.Method("POST", "/create-district", h.middleware.Base(h.v1CreateDistrict))
+	r.Method("GET", "/get-districts", h.middleware.Base(h.v1GetAllDistricts))
+	r.Method("PUT", "/update-district", h.middleware.Base(h.v1UpdateDistrict))
+	r.Method("DELETE", "/delete-district", h.middleware.Base(h.v1DeleteDistrict))
+
+	r.Method("GET", "/get-region-tree", h.middleware.Base(h.v1GetRegionTree))
 }
 
 // v1CreateRegion
@@ -178,14 +188,16 @@ func (h *RegionsHandler) v1UpdateRegion(w http.ResponseWriter, r *http.Request)
 
 // v1DeleteRegion
 // @Summary Delete a region
-// @Description Delete a region by ID
+// @Description Delete a region by ID. The delete is refused with the referencing counts while cities and auto stores point to it, unless reassign_to moves them to another region in the same transaction
 // @Tags Region
 // @Accept json
 // @Produce json
 // @Param id query int true "Region ID to delete"
+// @Param reassign_to query int false "Region ID to move the referencing cities and auto stores to"
 // @Success 200 {object} string "Region deleted successfully"
 // @Failure 400 {object} string "Bad request"
-// @Failure 404 {object} string "Brand not found"
+// @Failure 409 {object} map[string]int64 "Region is still referenced; counts per table"
+// @Failure 422 {object} string "Invalid reassign target"
 // @Failure 500 {object} string "Internal server error"
 // @Router /regions/delete-region [delete]
 func (h *RegionsHandler) v1DeleteRegion(w http.ResponseWriter, r *http.Request) shttp.Response {
@@ -205,9 +217,27 @@ func (h *RegionsHandler) v1DeleteRegion(w http.ResponseWriter, r *http.Request)
 		return shttp.BadRequest.SetData(result)
 	}
 
-	err = h.service.DeleteRegion(r.Context(), id)
+	var reassignTo int64
+	if reassignStr := r.URL.Query().Get("reassign_to"); reassignStr != "" {
+		reassignTo, err = strconv.ParseInt(reassignStr, 10, 64)
+		if err != nil {
+			result.Message = err.Error()
+			h.logger.Error("invalid reassign region ID", err)
+			return shttp.BadRequest.SetData(result)
+		}
+	}
+
+	err = h.service.DeleteRegion(r.Context(), id, reassignTo)
 	if err != nil {
 		result.Message = err.Error()
+		var refErr *helpers.ReferencedError
+		if errors.As(err, &refErr) {
+			result.Data = refErr.References
+			return shttp.Conflict.SetData(result)
+		}
+		if errors.Is(err, helpers.ErrInvalidReference) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
 		h.logger.Error("unable to delete region", err)
 		return shttp.InternalServerError.SetData(result)
 	}
@@ -266,10 +296,11 @@ func (h *RegionsHandler) v1CreateCity(w http.ResponseWriter, r *http.Request) sh
 
 // v1GetAllCities
 // @Summary Get all cities
-// @Description Get a paginated list of cities with optional search
+// @Description Get a paginated list of cities with optional region filter and search
 // @Tags City
 // @Accept json
 // @Produce json
+// @Param region_id query int false "Region ID filter"
 // @Param limit query int false "Limit number of cities to return"
 // @Param page query int false "Page number"
 // @Param search query string false "Search string to filter cities by name"
@@ -285,6 +316,17 @@ func (h *RegionsHandler) v1GetAllCities(w http.ResponseWriter, r *http.Request)
 	pageStr := r.URL.Query().Get("page")
 	search := r.URL.Query().Get("search")
 
+	var regionID int64
+	if regionIDStr := r.URL.Query().Get("region_id"); regionIDStr != "" {
+		id, err := strconv.ParseInt(regionIDStr, 10, 64)
+		if err != nil {
+			result.Message = err.Error()
+			h.logger.Error("invalid region ID", err)
+			return shttp.BadRequest.SetData(result)
+		}
+		regionID = id
+	}
+
 	limit, err := strconv.ParseInt(limitStr, 10, 64)
 	if err != nil || limit <= 0 {
 		limit = 10
@@ -294,7 +336,7 @@ func (h *RegionsHandler) v1GetAllCities(w http.ResponseWriter, r *http.Request)
 		page = 1
 	}
 
-	cities, err := h.service.GetAllCities(r.Context(), limit, page, search)
+	cities, err := h.service.GetAllCities(r.Context(), limit, page, regionID, search)
 	if err != nil {
 		result.Message = err.Error()
 		h.logger.Error("unable to get cities", err)
@@ -356,14 +398,16 @@ func (h *RegionsHandler) v1UpdateCity(w http.ResponseWriter, r *http.Request) sh
 
 // v1DeleteCity
 // @Summary Delete a city
-// @Description Delete a city by ID
+// @Description Delete a city by ID. The delete is refused with the referencing counts while districts and auto stores point to it, unless reassign_to moves them to another city in the same transaction
 // @Tags City
 // @Accept json
 // @Produce json
 // @Param id query int true "City ID to delete"
+// @Param reassign_to query int false "City ID to move the referencing districts and auto stores to"
 // @Success 200 {object} string "City deleted successfully"
 // @Failure 400 {object} string "Bad request"
-// @Failure 404 {object} string "Brand not found"
+// @Failure 409 {object} map[string]int64 "City is still referenced; counts per table"
+// @Failure 422 {object} string "Invalid reassign target"
 // @Failure 500 {object} string "Internal server error"
 // @Router /regions/delete-city [delete]
 func (h *RegionsHandler) v1DeleteCity(w http.ResponseWriter, r *http.Request) shttp.Response {
@@ -383,9 +427,27 @@ func (h *RegionsHandler) v1DeleteCity(w http.ResponseWriter, r *http.Request) sh
 		return shttp.BadRequest.SetData(result)
 	}
 
-	err = h.service.DeleteCity(r.Context(), id)
+	var reassignTo int64
+	if reassignStr := r.URL.Query().Get("reassign_to"); reassignStr != "" {
+		reassignTo, err = strconv.ParseInt(reassignStr, 10, 64)
+		if err != nil {
+			result.Message = err.Error()
+			h.logger.Error("invalid reassign city ID", err)
+			return shttp.BadRequest.SetData(result)
+		}
+	}
+
+	err = h.service.DeleteCity(r.Context(), id, reassignTo)
 	if err != nil {
 		result.Message = err.Error()
+		var refErr *helpers.ReferencedError
+		if errors.As(err, &refErr) {
+			result.Data = refErr.References
+			return shttp.Conflict.SetData(result)
+		}
+		if errors.Is(err, helpers.ErrInvalidReference) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
 		h.logger.Error("unable to delete city", err)
 		return shttp.InternalServerError.SetData(result)
 	}
@@ -394,3 +456,246 @@ func (h *RegionsHandler) v1DeleteCity(w http.ResponseWriter, r *http.Request) sh
 	result.Message = "City deleted successfully"
 	return shttp.Success.SetData(result)
 }
+
+// v1CreateDistrict
+// @Summary Create a new district
+// @Description Creates a new district with the given name and city
+// @Tags District
+// @Accept json
+// @Produce json
+// @Param District body dtos.CreateDistrictReq true "District data"
+// @Success 200 {object} map[string]int64 "Returns created district ID"
+// @Failure 400 {object} string "Bad request"
+// @Failure 422 {object} string "Unprocessable entity"
+// @Failure 500 {object} string "Internal server error"
+// @Router /regions/create-district [post]
+func (h *RegionsHandler) v1CreateDistrict(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	body, errBody := io.ReadAll(r.Body)
+	if errBody != nil {
+		result.Message = errBody.Error()
+		h.logger.Error("unable to read request body", errBody)
+		return shttp.BadRequest.SetData(result)
+	}
+	defer r.Body.Close()
+
+	var districtDTO dtos.CreateDistrictReq
+	errData := json.Unmarshal(body, &districtDTO)
+	if errData != nil {
+		result.Message = errData.Error()
+		h.logger.Error("unable to unmarshal request body", errData)
+		return shttp.UnprocessableEntity.SetData(result)
+	}
+
+	id, err := h.service.CreateDistrict(r.Context(), districtDTO)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("unable to create district", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Returns created district ID"
+	result.Data = map[string]interface{}{
+		"id": id,
+	}
+	return shttp.Success.SetData(result)
+}
+
+// v1GetAllDistricts
+// @Summary Get all districts
+// @Description Get a paginated list of districts with optional city filter and search
+// @Tags District
+// @Accept json
+// @Produce json
+// @Param city_id query int false "City ID filter"
+// @Param limit query int false "Limit number of districts to return"
+// @Param page query int false "Page number"
+// @Param search query string false "Search string to filter districts by name"
+// @Success 200 {object} dtos.DistrictResult "List of districts with pagination info Successfully"
+// @Failure 400 {object} string "Bad request"
+// @Failure 500 {object} string "Internal server error"
+// @Router /regions/get-districts [get]
+func (h *RegionsHandler) v1GetAllDistricts(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	limitStr := r.URL.Query().Get("limit")
+	pageStr := r.URL.Query().Get("page")
+	search := r.URL.Query().Get("search")
+
+	var cityID int64
+	if cityIDStr := r.URL.Query().Get("city_id"); cityIDStr != "" {
+		id, err := strconv.ParseInt(cityIDStr, 10, 64)
+		if err != nil {
+			result.Message = err.Error()
+			h.logger.Error("invalid city ID", err)
+			return shttp.BadRequest.SetData(result)
+		}
+		cityID = id
+	}
+
+	limit, err := strconv.ParseInt(limitStr, 10, 64)
+	if err != nil || limit <= 0 {
+		limit = 10
+	}
+	page, err := strconv.ParseInt(pageStr, 10, 64)
+	if err != nil || page <= 0 {
+		page = 1
+	}
+
+	districts, err := h.service.GetAllDistricts(r.Context(), limit, page, cityID, search)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("unable to get districts", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "List of districts with pagination info Successfully"
+	result.Data = districts
+	return shttp.Success.SetData(result)
+}
+
+// v1UpdateDistrict handler
+// @Summary Update an existing district
+// @Description Updates district details by ID
+// @Tags District
+// @Accept json
+// @Produce json
+// @Param District body dtos.UpdateDistrictReq true "District data with ID"
+// @Success 200 {object} map[string]int64 "Returns updated district ID"
+// @Failure 400 {object} string "Bad request"
+// @Failure 422 {object} string "Unprocessable entity"
+// @Failure 500 {object} string "Internal server error"
+// @Router /regions/update-district [put]
+func (h *RegionsHandler) v1UpdateDistrict(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	body, errBody := io.ReadAll(r.Body)
+	if errBody != nil {
+		result.Message = errBody.Error()
+		h.logger.Error("unable to read request body", errBody)
+		return shttp.BadRequest.SetData(result)
+	}
+	defer r.Body.Close()
+
+	var districtDTO dtos.UpdateDistrictReq
+	errData := json.Unmarshal(body, &districtDTO)
+	if errData != nil {
+		result.Message = errData.Error()
+		h.logger.Error("unable to unmarshal request body", errData)
+		return shttp.UnprocessableEntity.SetData(result)
+	}
+
+	id, err := h.service.UpdateDistrict(r.Context(), districtDTO)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("unable to update district", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Returns updated district ID"
+	result.Data = map[string]interface{}{
+		"id": id,
+	}
+	return shttp.Success.SetData(result)
+}
+
+// v1DeleteDistrict
+// @Summary Delete a district
+// @Description Delete a district by ID. The delete is refused with the referencing counts while auto stores point to it, unless reassign_to moves them to another district in the same transaction
+// @Tags District
+// @Accept json
+// @Produce json
+// @Param id query int true "District ID to delete"
+// @Param reassign_to query int false "District ID to move the referencing auto stores to"
+// @Success 200 {object} string "District deleted successfully"
+// @Failure 400 {object} string "Bad request"
+// @Failure 409 {object} map[string]int64 "District is still referenced; counts per table"
+// @Failure 422 {object} string "Invalid reassign target"
+// @Failure 500 {object} string "Internal server error"
+// @Router /regions/delete-district [delete]
+func (h *RegionsHandler) v1DeleteDistrict(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	idStr := r.URL.Query().Get("id")
+	if idStr == "" {
+		result.Message = "id is required"
+		return shttp.BadRequest.SetData(result)
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("invalid district ID", err)
+		return shttp.BadRequest.SetData(result)
+	}
+
+	var reassignTo int64
+	if reassignStr := r.URL.Query().Get("reassign_to"); reassignStr != "" {
+		reassignTo, err = strconv.ParseInt(reassignStr, 10, 64)
+		if err != nil {
+			result.Message = err.Error()
+			h.logger.Error("invalid reassign district ID", err)
+			return shttp.BadRequest.SetData(result)
+		}
+	}
+
+	err = h.service.DeleteDistrict(r.Context(), id, reassignTo)
+	if err != nil {
+		result.Message = err.Error()
+		var refErr *helpers.ReferencedError
+		if errors.As(err, &refErr) {
+			result.Data = refErr.References
+			return shttp.Conflict.SetData(result)
+		}
+		if errors.Is(err, helpers.ErrInvalidReference) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
+		h.logger.Error("unable to delete district", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "District deleted successfully"
+	return shttp.Success.SetData(result)
+}
+
+// v1GetRegionTree handler
+// @Summary Get the region tree
+// @Description Returns every region with its cities nested, localized and sorted by name, for cascading selects
+// @Tags Region
+// @Accept json
+// @Produce json
+// @Param lang query string false "Locale of the names (tm, en, ru); defaults to the first configured locale"
+// @Success 200 {object} dtos.RegionTree "Regions with nested cities"
+// @Failure 400 {object} string "Bad request"
+// @Failure 500 {object} string "Internal server error"
+// @Router /regions/get-region-tree [get]
+func (h *RegionsHandler) v1GetRegionTree(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	lang := r.URL.Query().Get("lang")
+
+	tree, err := h.service.GetRegionTree(r.Context(), lang)
+	if err != nil {
+		result.Message = err.Error()
+		if errors.Is(err, helpers.ErrUnsupportedLocale) {
+			return shttp.BadRequest.SetData(result)
+		}
+		h.logger.Error("unable to get region tree", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Region Tree Get Successfully"
+	result.Data = tree
+	return shttp.Success.SetData(result)
+}
@@ -0,0 +1,62 @@
+package http
+
+import (
+	"autotm-admin/internal/helpers"
+	"autotm-admin/internal/services/repository"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	shttp "github.com/salamsites/package-http"
+	slog "github.com/salamsites/package-log"
+	"net/http"
+)
+
+type ReportHandler struct {
+	logger     *slog.Logger
+	middleware *shttp.Middleware
+	service    repository.ReportService
+}
+
+func NewReportHandler(logger *slog.Logger, middleware *shttp.Middleware, service repository.ReportService) *ReportHandler {
+	return &ReportHandler{
+		logger:     logger,
+		middleware: middleware,
+		service:    service,
+	}
+}
+
+func (h *ReportHandler) ReportRegisterRoutes(r chi.Router) {
+	r.Method("GET", "/get-translation-report", h.middleware.Base(h.v1GetTranslationReport))
+}
+
+// v1GetTranslationReport
+// @Summary Translation completeness report
+// @Description Scans localized entities for missing TM/EN/RU names, identical values across languages and script mismatches (e.g. Cyrillic in name_tm)
+// @Tags Report
+// @Accept json
+// @Produce json
+// @Param entity query string false "Limit the report to one entity (categories, body_types, regions, cities, districts)"
+// @Success 200 {object} dtos.TranslationReport "Translation issues grouped by entity"
+// @Failure 400 {object} string "Bad request"
+// @Failure 500 {object} string "Internal server error"
+// @Router /reports/get-translation-report [get]
+func (h *ReportHandler) v1GetTranslationReport(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	entity := r.URL.Query().Get("entity")
+
+	report, err := h.service.GetTranslationReport(r.Context(), entity)
+	if err != nil {
+		result.Message = err.Error()
+		if errors.Is(err, helpers.ErrUnknownEntity) {
+			return shttp.BadRequest.SetData(result)
+		}
+		h.logger.Error("unable to get translation report", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Translation Report Get Successfully"
+	result.Data = report
+	return shttp.Success.SetData(result)
+}
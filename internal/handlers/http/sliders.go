@@ -2,14 +2,17 @@ package http
 
 import (
 	"autotm-admin/internal/dtos"
+	"autotm-admin/internal/helpers"
 	"autotm-admin/internal/services/repository"
 	"encoding/json"
+	"errors"
 	"github.com/go-chi/chi/v5"
 	shttp "github.com/salamsites/package-http"
 	slog "github.com/salamsites/package-log"
 	"io"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 type SliderHandler struct {
@@ -31,6 +34,22 @@ func (h *SliderHandler) SliderRegisterRoutes(r chi.Router) {
 	r.Method("GET", "/get-sliders", h.middleware.Base(h.v1GetAllSliders))
 	r.Method("PUT", "/update-slider", h.middleware.Base(h.v1UpdateSlider))
 	r.Method("DELETE", "/delete-slider", h.middleware.Base(h.v1DeleteSlider))
+	r.Method("GET", "/get-active-sliders", h.middleware.Base(h.v1GetActiveSliders))
+	r.Method("PUT", "/reorder-sliders", h.middleware.Base(h.v1ReorderSliders))
+	r.Method("POST", "/track-impressions", h.middleware.Base(h.v1TrackSliderImpressions))
+	r.Method("POST", "/track-click", h.middleware.Base(h.v1TrackSliderClick))
+	r.Method("GET", "/get-slider-stats", h.middleware.Base(h.v1GetSliderStats))
+
+	r.Method("POST", "/create-platform", h.middleware.Base(h.v1CreatePlatform))
+	r.Method("GET", "/get-platforms", h.middleware.Base(h.v1GetPlatforms))
+	r.Method("PUT", "/update-platform", h.middleware.Base(h.v1UpdatePlatform))
+	r.Method("DELETE", "/delete-platform", h.middleware.Base(h.v1DeletePlatform))
+
+	r.Method("POST", "/create-slider-variant", h.middleware.Base(h.v1CreateSliderVariant))
+	r.Method("GET", "/get-slider-variants", h.middleware.Base(h.v1GetSliderVariants))
+	r.Method("PUT", "/update-slider-variant", h.middleware.Base(h.v1UpdateSliderVariant))
+	r.Method("DELETE", "/delete-slider-variant", h.middleware.Base(h.v1DeleteSliderVariant))
+	r.Method("POST", "/promote-slider-variant", h.middleware.Base(h.v1PromoteSliderVariant))
 }
 
 // v1CreateSlider
@@ -68,6 +87,13 @@ func (h *SliderHandler) v1CreateSlider(w http.ResponseWriter, r *http.Request) s
 	id, err := h.service.CreateSlider(r.Context(), sliderDTO)
 	if err != nil {
 		result.Message = err.Error()
+		if errors.Is(err, helpers.ErrUnsupportedLocale) {
+			return shttp.BadRequest.SetData(result)
+		}
+		if errors.Is(err, helpers.ErrInvalidSchedule) || errors.Is(err, helpers.ErrInvalidTarget) ||
+			errors.Is(err, helpers.ErrInvalidReference) || errors.Is(err, helpers.ErrInvalidImage) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
 		h.logger.Error("unable to create slider", err)
 		return shttp.InternalServerError.SetData(result)
 	}
@@ -125,7 +151,9 @@ func (h *SliderHandler) v1GetAllSliders(w http.ResponseWriter, r *http.Request)
 
 // v1UpdateSlider handler
 // @Summary Update an existing slider
-// @Description Updates slider details by ID
+// @Description Updates slider details by ID. Omitted starts_at, ends_at, is_active, position and target_type keep
+// @Description their stored values; set clear_schedule to remove the stored schedule and target_type none to
+// @Description remove the target.
 // @Tags Slider
 // @Accept json
 // @Produce json
@@ -158,6 +186,13 @@ func (h *SliderHandler) v1UpdateSlider(w http.ResponseWriter, r *http.Request) s
 	id, err := h.service.UpdateSlider(r.Context(), sliderDTO)
 	if err != nil {
 		result.Message = err.Error()
+		if errors.Is(err, helpers.ErrUnsupportedLocale) {
+			return shttp.BadRequest.SetData(result)
+		}
+		if errors.Is(err, helpers.ErrInvalidSchedule) || errors.Is(err, helpers.ErrInvalidTarget) ||
+			errors.Is(err, helpers.ErrInvalidReference) || errors.Is(err, helpers.ErrInvalidImage) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
 		h.logger.Error("unable to update slider", err)
 		return shttp.InternalServerError.SetData(result)
 	}
@@ -210,3 +245,653 @@ func (h *SliderHandler) v1DeleteSlider(w http.ResponseWriter, r *http.Request) s
 	result.Message = "Slider deleted Successfully"
 	return shttp.Success.SetData(result)
 }
+
+// v1GetActiveSliders
+// @Summary Get active sliders
+// @Description Returns the sliders of a platform that are active and inside their schedule window, in position order.
+// @Description Sliders with variants show the variant picked for client_id.
+// @Tags Slider
+// @Accept json
+// @Produce json
+// @Param platform query string true "Platform"
+// @Param client_id query string false "Stable client identifier used to pick variants"
+// @Success 200 {object} dtos.SliderResult
+// @Failure 400 {object} string "Bad request"
+// @Failure 500 {object} string "Internal server error"
+// @Router /sliders/get-active-sliders [get]
+func (h *SliderHandler) v1GetActiveSliders(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	platform := r.URL.Query().Get("platform")
+	if platform == "" {
+		result.Message = "missing platform"
+		return shttp.BadRequest.SetData(result)
+	}
+
+	sliders, err := h.service.GetActiveSliders(r.Context(), platform, r.URL.Query().Get("client_id"))
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("unable to get active sliders", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "List of active sliders"
+	result.Data = sliders
+	return shttp.Success.SetData(result)
+}
+
+// v1ReorderSliders
+// @Summary Reorder sliders
+// @Description Sets the display order of a platform's sliders; listed IDs come first, the rest keep their relative order after them
+// @Tags Slider
+// @Accept json
+// @Produce json
+// @Param order body dtos.ReorderSlidersReq true "Platform and slider IDs in display order"
+// @Success 200 {object} string "Sliders reordered successfully"
+// @Failure 400 {object} string "Bad request"
+// @Failure 422 {object} string "Unprocessable entity"
+// @Failure 500 {object} string "Internal server error"
+// @Router /sliders/reorder-sliders [put]
+func (h *SliderHandler) v1ReorderSliders(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	body, errBody := io.ReadAll(r.Body)
+	if errBody != nil {
+		result.Message = errBody.Error()
+		h.logger.Error("unable to read request body", errBody)
+		return shttp.BadRequest.SetData(result)
+	}
+	defer r.Body.Close()
+
+	var reorderDTO dtos.ReorderSlidersReq
+	errData := json.Unmarshal(body, &reorderDTO)
+	if errData != nil {
+		result.Message = errData.Error()
+		h.logger.Error("unable to unmarshal request body", errData)
+		return shttp.UnprocessableEntity.SetData(result)
+	}
+
+	err := h.service.ReorderSliders(r.Context(), reorderDTO)
+	if err != nil {
+		result.Message = err.Error()
+		if errors.Is(err, helpers.ErrInvalidReference) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
+		h.logger.Error("unable to reorder sliders", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Sliders reordered successfully"
+	return shttp.Success.SetData(result)
+}
+
+// v1TrackSliderImpressions
+// @Summary Track slider impressions
+// @Description Adds one impression to today's counters of every listed slider; unknown IDs and sliders of another
+// @Description platform are ignored
+// @Tags Slider
+// @Accept json
+// @Produce json
+// @Param impressions body dtos.TrackSliderImpressionsReq true "Shown slider IDs and platform"
+// @Success 200 {object} map[string]int64 "Returns number of recorded sliders"
+// @Failure 400 {object} string "Bad request"
+// @Failure 422 {object} string "Unprocessable entity"
+// @Failure 500 {object} string "Internal server error"
+// @Router /sliders/track-impressions [post]
+func (h *SliderHandler) v1TrackSliderImpressions(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	body, errBody := io.ReadAll(r.Body)
+	if errBody != nil {
+		result.Message = errBody.Error()
+		h.logger.Error("unable to read request body", errBody)
+		return shttp.BadRequest.SetData(result)
+	}
+	defer r.Body.Close()
+
+	var trackDTO dtos.TrackSliderImpressionsReq
+	errData := json.Unmarshal(body, &trackDTO)
+	if errData != nil {
+		result.Message = errData.Error()
+		h.logger.Error("unable to unmarshal request body", errData)
+		return shttp.UnprocessableEntity.SetData(result)
+	}
+
+	recorded, err := h.service.TrackSliderImpressions(r.Context(), trackDTO)
+	if err != nil {
+		result.Message = err.Error()
+		if errors.Is(err, helpers.ErrInvalidReference) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
+		h.logger.Error("unable to track slider impressions", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Slider impressions recorded"
+	result.Data = map[string]interface{}{
+		"recorded": recorded,
+	}
+	return shttp.Success.SetData(result)
+}
+
+// v1TrackSliderClick
+// @Summary Track a slider click
+// @Description Adds one click to today's counters of a slider; the slider must belong to the given platform
+// @Tags Slider
+// @Accept json
+// @Produce json
+// @Param click body dtos.TrackSliderClickReq true "Clicked slider ID and platform"
+// @Success 200 {object} string "Slider click recorded"
+// @Failure 400 {object} string "Bad request"
+// @Failure 422 {object} string "Unprocessable entity"
+// @Failure 500 {object} string "Internal server error"
+// @Router /sliders/track-click [post]
+func (h *SliderHandler) v1TrackSliderClick(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	body, errBody := io.ReadAll(r.Body)
+	if errBody != nil {
+		result.Message = errBody.Error()
+		h.logger.Error("unable to read request body", errBody)
+		return shttp.BadRequest.SetData(result)
+	}
+	defer r.Body.Close()
+
+	var trackDTO dtos.TrackSliderClickReq
+	errData := json.Unmarshal(body, &trackDTO)
+	if errData != nil {
+		result.Message = errData.Error()
+		h.logger.Error("unable to unmarshal request body", errData)
+		return shttp.UnprocessableEntity.SetData(result)
+	}
+
+	err := h.service.TrackSliderClick(r.Context(), trackDTO)
+	if err != nil {
+		result.Message = err.Error()
+		if errors.Is(err, helpers.ErrInvalidReference) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
+		h.logger.Error("unable to track slider click", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Slider click recorded"
+	return shttp.Success.SetData(result)
+}
+
+// v1GetSliderStats
+// @Summary Get slider stats
+// @Description Returns impressions, clicks and CTR per slider and day between from and to inclusive (defaults to the last 30 days)
+// @Tags Slider
+// @Accept json
+// @Produce json
+// @Param from query string false "Start day (YYYY-MM-DD)"
+// @Param to query string false "End day (YYYY-MM-DD)"
+// @Param platform query string false "Platform"
+// @Param slider_id query int false "Slider ID"
+// @Success 200 {object} dtos.SliderStatsResult
+// @Failure 400 {object} string "Bad request"
+// @Failure 500 {object} string "Internal server error"
+// @Router /sliders/get-slider-stats [get]
+func (h *SliderHandler) v1GetSliderStats(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	now := time.Now().UTC()
+	req := dtos.SliderStatsReq{
+		To:       time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+		Platform: r.URL.Query().Get("platform"),
+	}
+	req.From = req.To.AddDate(0, 0, -29)
+
+	var err error
+	if toStr := r.URL.Query().Get("to"); toStr != "" {
+		req.To, err = time.Parse(time.DateOnly, toStr)
+		if err != nil {
+			result.Message = err.Error()
+			h.logger.Error("invalid to date", err)
+			return shttp.BadRequest.SetData(result)
+		}
+		req.From = req.To.AddDate(0, 0, -29)
+	}
+	if fromStr := r.URL.Query().Get("from"); fromStr != "" {
+		req.From, err = time.Parse(time.DateOnly, fromStr)
+		if err != nil {
+			result.Message = err.Error()
+			h.logger.Error("invalid from date", err)
+			return shttp.BadRequest.SetData(result)
+		}
+	}
+	if sliderIDStr := r.URL.Query().Get("slider_id"); sliderIDStr != "" {
+		req.SliderID, err = strconv.ParseInt(sliderIDStr, 10, 64)
+		if err != nil {
+			result.Message = err.Error()
+			h.logger.Error("invalid slider ID", err)
+			return shttp.BadRequest.SetData(result)
+		}
+	}
+
+	stats, err := h.service.GetSliderStats(r.Context(), req)
+	if err != nil {
+		result.Message = err.Error()
+		if errors.Is(err, helpers.ErrInvalidDateRange) {
+			return shttp.BadRequest.SetData(result)
+		}
+		h.logger.Error("unable to get slider stats", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Slider stats"
+	result.Data = stats
+	return shttp.Success.SetData(result)
+}
+
+// v1CreatePlatform
+// @Summary Create a platform
+// @Description Registers a slider platform with its image requirements; zero requirements are not enforced
+// @Tags Slider
+// @Accept json
+// @Produce json
+// @Param platform body dtos.CreatePlatformReq true "Platform data"
+// @Success 200 {object} map[string]int64 "Returns created platform ID"
+// @Failure 400 {object} string "Bad request"
+// @Failure 422 {object} string "Unprocessable entity"
+// @Failure 500 {object} string "Internal server error"
+// @Router /sliders/create-platform [post]
+func (h *SliderHandler) v1CreatePlatform(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	body, errBody := io.ReadAll(r.Body)
+	if errBody != nil {
+		result.Message = errBody.Error()
+		h.logger.Error("unable to read request body", errBody)
+		return shttp.BadRequest.SetData(result)
+	}
+	defer r.Body.Close()
+
+	var platformDTO dtos.CreatePlatformReq
+	errData := json.Unmarshal(body, &platformDTO)
+	if errData != nil {
+		result.Message = errData.Error()
+		h.logger.Error("unable to unmarshal request body", errData)
+		return shttp.UnprocessableEntity.SetData(result)
+	}
+
+	id, err := h.service.CreatePlatform(r.Context(), platformDTO)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("unable to create platform", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Platform created"
+	result.Data = map[string]interface{}{
+		"id": id,
+	}
+	return shttp.Success.SetData(result)
+}
+
+// v1GetPlatforms
+// @Summary Get platforms
+// @Description Returns all slider platforms with their image requirements
+// @Tags Slider
+// @Accept json
+// @Produce json
+// @Success 200 {array} dtos.Platform
+// @Failure 500 {object} string "Internal server error"
+// @Router /sliders/get-platforms [get]
+func (h *SliderHandler) v1GetPlatforms(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	platforms, err := h.service.GetPlatforms(r.Context())
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("unable to get platforms", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "List of platforms"
+	result.Data = platforms
+	return shttp.Success.SetData(result)
+}
+
+// v1UpdatePlatform
+// @Summary Update a platform
+// @Description Updates a platform by ID; renaming the code also updates its sliders
+// @Tags Slider
+// @Accept json
+// @Produce json
+// @Param platform body dtos.UpdatePlatformReq true "Platform data with ID"
+// @Success 200 {object} map[string]int64 "Returns updated platform ID"
+// @Failure 400 {object} string "Bad request"
+// @Failure 422 {object} string "Unprocessable entity"
+// @Failure 500 {object} string "Internal server error"
+// @Router /sliders/update-platform [put]
+func (h *SliderHandler) v1UpdatePlatform(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	body, errBody := io.ReadAll(r.Body)
+	if errBody != nil {
+		result.Message = errBody.Error()
+		h.logger.Error("unable to read request body", errBody)
+		return shttp.BadRequest.SetData(result)
+	}
+	defer r.Body.Close()
+
+	var platformDTO dtos.UpdatePlatformReq
+	errData := json.Unmarshal(body, &platformDTO)
+	if errData != nil {
+		result.Message = errData.Error()
+		h.logger.Error("unable to unmarshal request body", errData)
+		return shttp.UnprocessableEntity.SetData(result)
+	}
+
+	id, err := h.service.UpdatePlatform(r.Context(), platformDTO)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("unable to update platform", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Platform updated successfully"
+	result.Data = map[string]interface{}{
+		"id": id,
+	}
+	return shttp.Success.SetData(result)
+}
+
+// v1DeletePlatform
+// @Summary Delete a platform
+// @Description Deletes a platform by ID; refused while sliders still use it
+// @Tags Slider
+// @Accept json
+// @Produce json
+// @Param id query int true "Platform ID to delete"
+// @Success 200 {object} string "Platform deleted successfully"
+// @Failure 400 {object} string "Bad request"
+// @Failure 409 {object} map[string]int64 "Platform is still used by sliders"
+// @Failure 500 {object} string "Internal server error"
+// @Router /sliders/delete-platform [delete]
+func (h *SliderHandler) v1DeletePlatform(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	idStr := r.URL.Query().Get("id")
+	if idStr == "" {
+		result.Message = "missing platform ID"
+		return shttp.BadRequest.SetData(result)
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("invalid platform ID", err)
+		return shttp.BadRequest.SetData(result)
+	}
+
+	err = h.service.DeletePlatform(r.Context(), id)
+	if err != nil {
+		result.Message = err.Error()
+		var refErr *helpers.ReferencedError
+		if errors.As(err, &refErr) {
+			result.Data = refErr.References
+			return shttp.Conflict.SetData(result)
+		}
+		h.logger.Error("unable to delete platform", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Platform deleted successfully"
+	return shttp.Success.SetData(result)
+}
+
+// v1CreateSliderVariant
+// @Summary Create a slider variant
+// @Description Adds a weighted creative variant to a slider; weight defaults to 1 and 0 pauses the variant
+// @Tags Slider
+// @Accept json
+// @Produce json
+// @Param variant body dtos.CreateSliderVariantReq true "Variant data"
+// @Success 200 {object} map[string]int64 "Returns created variant ID"
+// @Failure 400 {object} string "Bad request"
+// @Failure 422 {object} string "Unprocessable entity"
+// @Failure 500 {object} string "Internal server error"
+// @Router /sliders/create-slider-variant [post]
+func (h *SliderHandler) v1CreateSliderVariant(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	body, errBody := io.ReadAll(r.Body)
+	if errBody != nil {
+		result.Message = errBody.Error()
+		h.logger.Error("unable to read request body", errBody)
+		return shttp.BadRequest.SetData(result)
+	}
+	defer r.Body.Close()
+
+	var variantDTO dtos.CreateSliderVariantReq
+	errData := json.Unmarshal(body, &variantDTO)
+	if errData != nil {
+		result.Message = errData.Error()
+		h.logger.Error("unable to unmarshal request body", errData)
+		return shttp.UnprocessableEntity.SetData(result)
+	}
+
+	id, err := h.service.CreateSliderVariant(r.Context(), variantDTO)
+	if err != nil {
+		result.Message = err.Error()
+		if errors.Is(err, helpers.ErrUnsupportedLocale) {
+			return shttp.BadRequest.SetData(result)
+		}
+		if errors.Is(err, helpers.ErrInvalidReference) || errors.Is(err, helpers.ErrInvalidImage) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
+		h.logger.Error("unable to create slider variant", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Slider variant created"
+	result.Data = map[string]interface{}{
+		"id": id,
+	}
+	return shttp.Success.SetData(result)
+}
+
+// v1GetSliderVariants
+// @Summary Get slider variants
+// @Description Returns the creative variants of a slider
+// @Tags Slider
+// @Accept json
+// @Produce json
+// @Param slider_id query int true "Slider ID"
+// @Success 200 {array} dtos.SliderVariant
+// @Failure 400 {object} string "Bad request"
+// @Failure 500 {object} string "Internal server error"
+// @Router /sliders/get-slider-variants [get]
+func (h *SliderHandler) v1GetSliderVariants(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	sliderIDStr := r.URL.Query().Get("slider_id")
+	if sliderIDStr == "" {
+		result.Message = "missing slider ID"
+		return shttp.BadRequest.SetData(result)
+	}
+
+	sliderID, err := strconv.ParseInt(sliderIDStr, 10, 64)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("invalid slider ID", err)
+		return shttp.BadRequest.SetData(result)
+	}
+
+	variants, err := h.service.GetSliderVariants(r.Context(), sliderID)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("unable to get slider variants", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "List of slider variants"
+	result.Data = variants
+	return shttp.Success.SetData(result)
+}
+
+// v1UpdateSliderVariant
+// @Summary Update a slider variant
+// @Description Updates a slider variant by ID
+// @Tags Slider
+// @Accept json
+// @Produce json
+// @Param variant body dtos.UpdateSliderVariantReq true "Variant data with ID"
+// @Success 200 {object} map[string]int64 "Returns updated variant ID"
+// @Failure 400 {object} string "Bad request"
+// @Failure 422 {object} string "Unprocessable entity"
+// @Failure 500 {object} string "Internal server error"
+// @Router /sliders/update-slider-variant [put]
+func (h *SliderHandler) v1UpdateSliderVariant(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	body, errBody := io.ReadAll(r.Body)
+	if errBody != nil {
+		result.Message = errBody.Error()
+		h.logger.Error("unable to read request body", errBody)
+		return shttp.BadRequest.SetData(result)
+	}
+	defer r.Body.Close()
+
+	var variantDTO dtos.UpdateSliderVariantReq
+	errData := json.Unmarshal(body, &variantDTO)
+	if errData != nil {
+		result.Message = errData.Error()
+		h.logger.Error("unable to unmarshal request body", errData)
+		return shttp.UnprocessableEntity.SetData(result)
+	}
+
+	id, err := h.service.UpdateSliderVariant(r.Context(), variantDTO)
+	if err != nil {
+		result.Message = err.Error()
+		if errors.Is(err, helpers.ErrUnsupportedLocale) {
+			return shttp.BadRequest.SetData(result)
+		}
+		if errors.Is(err, helpers.ErrInvalidReference) || errors.Is(err, helpers.ErrInvalidImage) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
+		h.logger.Error("unable to update slider variant", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Slider variant updated successfully"
+	result.Data = map[string]interface{}{
+		"id": id,
+	}
+	return shttp.Success.SetData(result)
+}
+
+// v1DeleteSliderVariant
+// @Summary Delete a slider variant
+// @Description Deletes a slider variant by ID; its stats are kept
+// @Tags Slider
+// @Accept json
+// @Produce json
+// @Param id query int true "Variant ID to delete"
+// @Success 200 {object} string "Slider variant deleted successfully"
+// @Failure 400 {object} string "Bad request"
+// @Failure 422 {object} string "Unprocessable entity"
+// @Failure 500 {object} string "Internal server error"
+// @Router /sliders/delete-slider-variant [delete]
+func (h *SliderHandler) v1DeleteSliderVariant(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	idStr := r.URL.Query().Get("id")
+	if idStr == "" {
+		result.Message = "missing slider variant ID"
+		return shttp.BadRequest.SetData(result)
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("invalid slider variant ID", err)
+		return shttp.BadRequest.SetData(result)
+	}
+
+	err = h.service.DeleteSliderVariant(r.Context(), id)
+	if err != nil {
+		result.Message = err.Error()
+		if errors.Is(err, helpers.ErrInvalidReference) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
+		h.logger.Error("unable to delete slider variant", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Slider variant deleted successfully"
+	return shttp.Success.SetData(result)
+}
+
+// v1PromoteSliderVariant
+// @Summary Promote a slider variant
+// @Description Makes the variant's images the slider's own creative and removes all variants of the slider
+// @Tags Slider
+// @Accept json
+// @Produce json
+// @Param id query int true "Variant ID to promote"
+// @Success 200 {object} string "Slider variant promoted successfully"
+// @Failure 400 {object} string "Bad request"
+// @Failure 422 {object} string "Unprocessable entity"
+// @Failure 500 {object} string "Internal server error"
+// @Router /sliders/promote-slider-variant [post]
+func (h *SliderHandler) v1PromoteSliderVariant(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	idStr := r.URL.Query().Get("id")
+	if idStr == "" {
+		result.Message = "missing slider variant ID"
+		return shttp.BadRequest.SetData(result)
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("invalid slider variant ID", err)
+		return shttp.BadRequest.SetData(result)
+	}
+
+	err = h.service.PromoteSliderVariant(r.Context(), id)
+	if err != nil {
+		result.Message = err.Error()
+		if errors.Is(err, helpers.ErrInvalidReference) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
+		h.logger.Error("unable to promote slider variant", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Slider variant promoted successfully"
+	return shttp.Success.SetData(result)
+}
@@ -0,0 +1,414 @@
+package http
+
+import (
+	"autotm-admin/internal/dtos"
+	"autotm-admin/internal/helpers"
+	"autotm-admin/internal/services/repository"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	shttp "github.com/salamsites/package-http"
+	slog "github.com/salamsites/package-log"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+type SubscriptionHandler struct {
+	logger     *slog.Logger
+	middleware *shttp.Middleware
+	service    repository.SubscriptionService
+}
+
+func NewSubscriptionHandler(logger *slog.Logger, middleware *shttp.Middleware, service repository.SubscriptionService) *SubscriptionHandler {
+	return &SubscriptionHandler{
+		logger:     logger,
+		middleware: middleware,
+		service:    service,
+	}
+}
+
+func (h *SubscriptionHandler) SubscriptionRegisterRoutes(r chi.Router) {
+	r.Method("POST", "/create-plan", h.middleware.Base(h.v1CreatePlan))
+	r.Method("GET", "/get-plans", h.middleware.Base(h.v1GetPlans))
+	r.Method("PUT", "/update-plan", h.middleware.Base(h.v1UpdatePlan))
+	r.Method("DELETE", "/delete-plan", h.middleware.Base(h.v1DeletePlan))
+
+	r.Method("POST", "/assign-subscription", h.middleware.Base(h.v1AssignSubscription))
+	r.Method("PUT", "/extend-subscription", h.middleware.Base(h.v1ExtendSubscription))
+	r.Method("PUT", "/cancel-subscription", h.middleware.Base(h.v1CancelSubscription))
+	r.Method("GET", "/get-store-subscriptions", h.middleware.Base(h.v1GetStoreSubscriptions))
+}
+
+// v1CreatePlan
+// @Summary Create a subscription plan
+// @Description Creates a plan stores can be subscribed to. Currency defaults to TMT; a max_gallery_images of 0
+// @Description leaves the gallery unlimited.
+// @Tags Subscription
+// @Accept json
+// @Produce json
+// @Param plan body dtos.CreateSubscriptionPlanReq true "Plan data"
+// @Success 200 {object} dtos.ID "Returns created plan ID"
+// @Failure 400 {object} string "Bad request"
+// @Failure 422 {object} string "Unprocessable entity"
+// @Failure 500 {object} string "Internal server error"
+// @Router /subscriptions/create-plan [post]
+func (h *SubscriptionHandler) v1CreatePlan(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	body, errBody := io.ReadAll(r.Body)
+	if errBody != nil {
+		result.Message = errBody.Error()
+		h.logger.Error("unable to read request body", errBody)
+		return shttp.BadRequest.SetData(result)
+	}
+	defer r.Body.Close()
+
+	var planDTO dtos.CreateSubscriptionPlanReq
+	errData := json.Unmarshal(body, &planDTO)
+	if errData != nil {
+		result.Message = errData.Error()
+		h.logger.Error("unable to unmarshal request body", errData)
+		return shttp.UnprocessableEntity.SetData(result)
+	}
+
+	id, err := h.service.CreateSubscriptionPlan(r.Context(), planDTO)
+	if err != nil {
+		result.Message = err.Error()
+		var validationErrs validator.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			return shttp.BadRequest.SetData(result)
+		}
+		h.logger.Error("unable to create subscription plan", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Plan created successfully"
+	result.Data = id
+	return shttp.Success.SetData(result)
+}
+
+// v1GetPlans
+// @Summary Get subscription plans
+// @Description Returns the subscription plans, cheapest first
+// @Tags Subscription
+// @Accept json
+// @Produce json
+// @Param active query bool false "Return only active plans"
+// @Success 200 {array} dtos.SubscriptionPlan
+// @Failure 500 {object} string "Internal server error"
+// @Router /subscriptions/get-plans [get]
+func (h *SubscriptionHandler) v1GetPlans(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	activeOnly, _ := strconv.ParseBool(r.URL.Query().Get("active"))
+
+	plans, err := h.service.GetSubscriptionPlans(r.Context(), activeOnly)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("unable to get subscription plans", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Subscription plans"
+	result.Data = plans
+	return shttp.Success.SetData(result)
+}
+
+// v1UpdatePlan
+// @Summary Update a subscription plan
+// @Description Updates a plan by ID. Running subscriptions keep their dates; a new image limit applies to them at once.
+// @Tags Subscription
+// @Accept json
+// @Produce json
+// @Param plan body dtos.UpdateSubscriptionPlanReq true "Plan data with ID"
+// @Success 200 {object} dtos.ID "Returns updated plan ID"
+// @Failure 400 {object} string "Bad request"
+// @Failure 422 {object} string "Unprocessable entity"
+// @Failure 500 {object} string "Internal server error"
+// @Router /subscriptions/update-plan [put]
+func (h *SubscriptionHandler) v1UpdatePlan(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	body, errBody := io.ReadAll(r.Body)
+	if errBody != nil {
+		result.Message = errBody.Error()
+		h.logger.Error("unable to read request body", errBody)
+		return shttp.BadRequest.SetData(result)
+	}
+	defer r.Body.Close()
+
+	var planDTO dtos.UpdateSubscriptionPlanReq
+	errData := json.Unmarshal(body, &planDTO)
+	if errData != nil {
+		result.Message = errData.Error()
+		h.logger.Error("unable to unmarshal request body", errData)
+		return shttp.UnprocessableEntity.SetData(result)
+	}
+
+	id, err := h.service.UpdateSubscriptionPlan(r.Context(), planDTO)
+	if err != nil {
+		result.Message = err.Error()
+		var validationErrs validator.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			return shttp.BadRequest.SetData(result)
+		}
+		h.logger.Error("unable to update subscription plan", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Plan updated successfully"
+	result.Data = id
+	return shttp.Success.SetData(result)
+}
+
+// v1DeletePlan
+// @Summary Delete a subscription plan
+// @Description Deletes a plan by ID. Plans that any subscription used cannot be deleted; deactivate them instead.
+// @Tags Subscription
+// @Accept json
+// @Produce json
+// @Param id query int true "Plan ID to delete"
+// @Success 200 {object} string "Plan deleted successfully"
+// @Failure 400 {object} string "Bad request"
+// @Failure 409 {object} map[string]int64 "Plan is still used by subscriptions"
+// @Failure 500 {object} string "Internal server error"
+// @Router /subscriptions/delete-plan [delete]
+func (h *SubscriptionHandler) v1DeletePlan(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	idStr := r.URL.Query().Get("id")
+	if idStr == "" {
+		result.Message = "missing plan ID"
+		return shttp.BadRequest.SetData(result)
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("invalid plan ID", err)
+		return shttp.BadRequest.SetData(result)
+	}
+
+	err = h.service.DeleteSubscriptionPlan(r.Context(), id)
+	if err != nil {
+		result.Message = err.Error()
+		var refErr *helpers.ReferencedError
+		if errors.As(err, &refErr) {
+			result.Data = refErr.References
+			return shttp.Conflict.SetData(result)
+		}
+		h.logger.Error("unable to delete subscription plan", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Plan deleted successfully"
+	return shttp.Success.SetData(result)
+}
+
+// v1AssignSubscription
+// @Summary Assign a subscription
+// @Description Subscribes a store to an active plan for the plan's duration. Without starts_at the subscription
+// @Description starts now, or when the store's latest subscription ends. Subscriptions of a store may not overlap.
+// @Tags Subscription
+// @Accept json
+// @Produce json
+// @Param subscription body dtos.AssignSubscriptionReq true "Subscription data"
+// @Success 200 {object} dtos.ID "Returns created subscription ID"
+// @Failure 400 {object} string "Bad request"
+// @Failure 422 {object} string "Unprocessable entity"
+// @Failure 500 {object} string "Internal server error"
+// @Router /subscriptions/assign-subscription [post]
+func (h *SubscriptionHandler) v1AssignSubscription(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	body, errBody := io.ReadAll(r.Body)
+	if errBody != nil {
+		result.Message = errBody.Error()
+		h.logger.Error("unable to read request body", errBody)
+		return shttp.BadRequest.SetData(result)
+	}
+	defer r.Body.Close()
+
+	var subscriptionDTO dtos.AssignSubscriptionReq
+	errData := json.Unmarshal(body, &subscriptionDTO)
+	if errData != nil {
+		result.Message = errData.Error()
+		h.logger.Error("unable to unmarshal request body", errData)
+		return shttp.UnprocessableEntity.SetData(result)
+	}
+
+	id, err := h.service.AssignSubscription(r.Context(), subscriptionDTO)
+	if err != nil {
+		result.Message = err.Error()
+		var validationErrs validator.ValidationErrors
+		if errors.Is(err, helpers.ErrInvalidDateRange) || errors.As(err, &validationErrs) {
+			return shttp.BadRequest.SetData(result)
+		}
+		if errors.Is(err, helpers.ErrInvalidReference) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
+		h.logger.Error("unable to assign subscription", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Subscription assigned successfully"
+	result.Data = id
+	return shttp.Success.SetData(result)
+}
+
+// v1ExtendSubscription
+// @Summary Extend a subscription
+// @Description Moves the end of an active subscription by the given number of days
+// @Tags Subscription
+// @Accept json
+// @Produce json
+// @Param extension body dtos.ExtendSubscriptionReq true "Extension"
+// @Success 200 {object} string "Subscription extended successfully"
+// @Failure 400 {object} string "Bad request"
+// @Failure 409 {object} string "Subscription is cancelled"
+// @Failure 422 {object} string "Unprocessable entity"
+// @Failure 500 {object} string "Internal server error"
+// @Router /subscriptions/extend-subscription [put]
+func (h *SubscriptionHandler) v1ExtendSubscription(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	body, errBody := io.ReadAll(r.Body)
+	if errBody != nil {
+		result.Message = errBody.Error()
+		h.logger.Error("unable to read request body", errBody)
+		return shttp.BadRequest.SetData(result)
+	}
+	defer r.Body.Close()
+
+	var extensionDTO dtos.ExtendSubscriptionReq
+	errData := json.Unmarshal(body, &extensionDTO)
+	if errData != nil {
+		result.Message = errData.Error()
+		h.logger.Error("unable to unmarshal request body", errData)
+		return shttp.UnprocessableEntity.SetData(result)
+	}
+
+	err := h.service.ExtendSubscription(r.Context(), extensionDTO)
+	if err != nil {
+		result.Message = err.Error()
+		var validationErrs validator.ValidationErrors
+		if errors.Is(err, helpers.ErrInvalidDateRange) || errors.As(err, &validationErrs) {
+			return shttp.BadRequest.SetData(result)
+		}
+		if errors.Is(err, helpers.ErrInvalidTransition) {
+			return shttp.Conflict.SetData(result)
+		}
+		if errors.Is(err, helpers.ErrInvalidReference) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
+		h.logger.Error("unable to extend subscription", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Subscription extended successfully"
+	return shttp.Success.SetData(result)
+}
+
+// v1CancelSubscription
+// @Summary Cancel a subscription
+// @Description Ends an active subscription immediately. The store is hidden from public listings unless another
+// @Description subscription covers the current time.
+// @Tags Subscription
+// @Accept json
+// @Produce json
+// @Param id query int true "Subscription ID"
+// @Success 200 {object} string "Subscription cancelled successfully"
+// @Failure 400 {object} string "Bad request"
+// @Failure 409 {object} string "Subscription is already cancelled"
+// @Failure 422 {object} string "Unprocessable entity"
+// @Failure 500 {object} string "Internal server error"
+// @Router /subscriptions/cancel-subscription [put]
+func (h *SubscriptionHandler) v1CancelSubscription(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	idStr := r.URL.Query().Get("id")
+	if idStr == "" {
+		result.Message = "missing subscription ID"
+		return shttp.BadRequest.SetData(result)
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("invalid subscription ID", err)
+		return shttp.BadRequest.SetData(result)
+	}
+
+	err = h.service.CancelSubscription(r.Context(), id)
+	if err != nil {
+		result.Message = err.Error()
+		if errors.Is(err, helpers.ErrInvalidTransition) {
+			return shttp.Conflict.SetData(result)
+		}
+		if errors.Is(err, helpers.ErrInvalidReference) {
+			return shttp.UnprocessableEntity.SetData(result)
+		}
+		h.logger.Error("unable to cancel subscription", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Subscription cancelled successfully"
+	return shttp.Success.SetData(result)
+}
+
+// v1GetStoreSubscriptions
+// @Summary Get store subscriptions
+// @Description Returns the subscriptions of an auto store, latest first, each as active, scheduled, expired or cancelled
+// @Tags Subscription
+// @Accept json
+// @Produce json
+// @Param auto_store_id query int true "Auto store ID"
+// @Success 200 {array} dtos.StoreSubscription
+// @Failure 400 {object} string "Bad request"
+// @Failure 500 {object} string "Internal server error"
+// @Router /subscriptions/get-store-subscriptions [get]
+func (h *SubscriptionHandler) v1GetStoreSubscriptions(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+	result.Status = false
+
+	idStr := r.URL.Query().Get("auto_store_id")
+	if idStr == "" {
+		result.Message = "missing auto store ID"
+		return shttp.BadRequest.SetData(result)
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("invalid auto store ID", err)
+		return shttp.BadRequest.SetData(result)
+	}
+
+	subscriptions, err := h.service.GetStoreSubscriptions(r.Context(), id)
+	if err != nil {
+		result.Message = err.Error()
+		h.logger.Error("unable to get store subscriptions", err)
+		return shttp.InternalServerError.SetData(result)
+	}
+
+	result.Status = true
+	result.Message = "Store subscriptions"
+	result.Data = subscriptions
+	return shttp.Success.SetData(result)
+}
@@ -14,16 +14,20 @@ import (
 )
 
 const (
-	baseURL      = "/api/v1/autotm-admin"
-	filesURL     = baseURL + "/files"
-	brandURL     = baseURL + "/brand"
-	settingsURL  = baseURL + "/settings"
-	regionsURL   = baseURL + "/regions"
-	slidersURL   = baseURL + "/sliders"
-	autoStoreURL = baseURL + "/auto-store"
+	baseURL          = "/api/v1/autotm-admin"
+	filesURL         = baseURL + "/files"
+	brandURL         = baseURL + "/brand"
+	settingsURL      = baseURL + "/settings"
+	regionsURL       = baseURL + "/regions"
+	slidersURL       = baseURL + "/sliders"
+	autoStoreURL     = baseURL + "/auto-store"
+	categoriesURL    = baseURL + "/categories"
+	reportsURL       = baseURL + "/reports"
+	subscriptionsURL = baseURL + "/subscriptions"
 )
 
-func Manager(logger *slog.Logger, clientPsql spsql.Client, cfg *configs.Config) chi.Router {
+// Manager builds the router. Background jobs started here run until ctx is done.
+func Manager(ctx context.Context, logger *slog.Logger, clientPsql spsql.Client, cfg *configs.Config) chi.Router {
 	r := chi.NewRouter()
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
@@ -43,6 +47,13 @@ func Manager(logger *slog.Logger, clientPsql spsql.Client, cfg *configs.Config)
 		brandHandler.BrandRegisterRoutes(subRouter)
 	})
 
+	r.Route(categoriesURL, func(subRouter chi.Router) {
+		categoryRepo := repository.NewCategoryPsqlRepository(logger, clientPsql)
+		categoryService := services.NewCategoryService(logger, categoryRepo)
+		categoryHandler := http.NewCategoryHandler(logger, newMiddleware, categoryService)
+		categoryHandler.CategoryRegisterRoutes(subRouter)
+	})
+
 	r.Route(settingsURL, func(subRouter chi.Router) {
 		settingsRepo := repository.NewSettingsPsqlRepository(logger, clientPsql)
 		settingsService := services.NewSettingsService(logger, settingsRepo)
@@ -71,10 +82,26 @@ func Manager(logger *slog.Logger, clientPsql spsql.Client, cfg *configs.Config)
 	r.Route(autoStoreURL, func(subRouter chi.Router) {
 		autoStoreRepo := repository.NewAutoStorePsqlRepository(logger, clientPsql)
 		userService := services.NewUserService(cfg, logger)
-		autoStoreService := services.NewAutoStoreService(logger, autoStoreRepo, userService)
+		autoStoreService := services.NewAutoStoreService(cfg, logger, autoStoreRepo, userService)
 		autoStoreHandler := http.NewAutoStoreHandler(logger, newMiddleware, autoStoreService)
 		autoStoreHandler.AutoStoreRegisterRoutes(subRouter)
 	})
 
+	r.Route(reportsURL, func(subRouter chi.Router) {
+		reportRepo := repository.NewReportPsqlRepository(logger, clientPsql)
+		reportService := services.NewReportService(logger, reportRepo)
+		reportHandler := http.NewReportHandler(logger, newMiddleware, reportService)
+		reportHandler.ReportRegisterRoutes(subRouter)
+	})
+
+	r.Route(subscriptionsURL, func(subRouter chi.Router) {
+		subscriptionRepo := repository.NewSubscriptionPsqlRepository(logger, clientPsql)
+		subscriptionService := services.NewSubscriptionService(logger, subscriptionRepo)
+
+		go subscriptionService.RunExpiryJob(ctx)
+		subscriptionHandler := http.NewSubscriptionHandler(logger, newMiddleware, subscriptionService)
+		subscriptionHandler.SubscriptionRegisterRoutes(subRouter)
+	})
+
 	return r
 }
@@ -0,0 +1,77 @@
+package helpers
+
+import (
+	"autotm-admin/internal/models"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	// phonePattern accepts international numbers once spaces, dashes, dots and
+	// parentheses are removed.
+	phonePattern     = regexp.MustCompile(`^\+?[0-9]{6,15}$`)
+	telegramPattern  = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{4,31}$`)
+	instagramPattern = regexp.MustCompile(`^[A-Za-z0-9._]{1,30}$`)
+	phoneSeparators  = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+)
+
+// NormalizeContact validates a contact value for its type and returns it in
+// canonical form: phone numbers without separators, Telegram and Instagram
+// handles without "@" or profile URL, emails in lower case.
+func NormalizeContact(contactType, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", fmt.Errorf("%w: %s value is empty", ErrInvalidContact, contactType)
+	}
+
+	switch contactType {
+	case models.ContactPhone, models.ContactWhatsApp, models.ContactIMO:
+		phone := phoneSeparators.Replace(value)
+		if !phonePattern.MatchString(phone) {
+			return "", fmt.Errorf("%w: %q is not a valid %s number", ErrInvalidContact, value, contactType)
+		}
+		return phone, nil
+	case models.ContactTelegram:
+		handle := profileHandle(value, "t.me", "telegram.me")
+		if !telegramPattern.MatchString(handle) {
+			return "", fmt.Errorf("%w: %q is not a valid telegram username", ErrInvalidContact, value)
+		}
+		return handle, nil
+	case models.ContactInstagram:
+		handle := profileHandle(value, "instagram.com", "www.instagram.com")
+		if !instagramPattern.MatchString(handle) || strings.HasPrefix(handle, ".") || strings.HasSuffix(handle, ".") {
+			return "", fmt.Errorf("%w: %q is not a valid instagram username", ErrInvalidContact, value)
+		}
+		return strings.ToLower(handle), nil
+	case models.ContactEmail:
+		address, err := mail.ParseAddress(value)
+		if err != nil || address.Address != value {
+			return "", fmt.Errorf("%w: %q is not a valid email", ErrInvalidContact, value)
+		}
+		return strings.ToLower(value), nil
+	case models.ContactWebsite:
+		u, err := url.ParseRequestURI(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "", fmt.Errorf("%w: %q is not an http(s) url", ErrInvalidContact, value)
+		}
+		return value, nil
+	}
+	return "", fmt.Errorf("%w: unknown contact type %q", ErrInvalidContact, contactType)
+}
+
+// profileHandle strips a leading "@" or a profile URL on one of hosts from value.
+func profileHandle(value string, hosts ...string) string {
+	handle := strings.TrimPrefix(value, "@")
+	for _, scheme := range []string{"https://", "http://", ""} {
+		for _, host := range hosts {
+			if rest, ok := strings.CutPrefix(handle, scheme+host+"/"); ok {
+				handle, _, _ = strings.Cut(rest, "?")
+				return strings.TrimSuffix(handle, "/")
+			}
+		}
+	}
+	return handle
+}
@@ -0,0 +1,67 @@
+package helpers
+
+import (
+	"autotm-admin/internal/models"
+	"errors"
+	"testing"
+)
+
+func TestNormalizeContact(t *testing.T) {
+	tests := []struct {
+		name        string
+		contactType string
+		value       string
+		want        string
+		wantErr     bool
+	}{
+		{name: "phone with separators", contactType: models.ContactPhone, value: " +993 (65) 12-34.56 ", want: "+99365123456"},
+		{name: "whatsapp without plus", contactType: models.ContactWhatsApp, value: "99365123456", want: "99365123456"},
+		{name: "imo", contactType: models.ContactIMO, value: "+993 61 000001", want: "+99361000001"},
+		{name: "phone too short", contactType: models.ContactPhone, value: "12345", wantErr: true},
+		{name: "phone with letters", contactType: models.ContactPhone, value: "+993 65 ABC", wantErr: true},
+		{name: "empty value", contactType: models.ContactPhone, value: "   ", wantErr: true},
+
+		{name: "telegram handle", contactType: models.ContactTelegram, value: "autotm_store", want: "autotm_store"},
+		{name: "telegram at", contactType: models.ContactTelegram, value: "@autotm_store", want: "autotm_store"},
+		{name: "telegram url", contactType: models.ContactTelegram, value: "https://t.me/autotm_store", want: "autotm_store"},
+		{name: "telegram url with query and slash", contactType: models.ContactTelegram, value: "t.me/autotm_store/?start=1", want: "autotm_store"},
+		{name: "telegram.me url", contactType: models.ContactTelegram, value: "http://telegram.me/autotm_store", want: "autotm_store"},
+		{name: "telegram too short", contactType: models.ContactTelegram, value: "@auto", wantErr: true},
+		{name: "telegram starts with digit", contactType: models.ContactTelegram, value: "1autotm", wantErr: true},
+
+		{name: "instagram lower cased", contactType: models.ContactInstagram, value: "@AutoTM.Store", want: "autotm.store"},
+		{name: "instagram url", contactType: models.ContactInstagram, value: "https://www.instagram.com/autotm_store/", want: "autotm_store"},
+		{name: "instagram url with query", contactType: models.ContactInstagram, value: "instagram.com/autotm?igsh=abc", want: "autotm"},
+		{name: "instagram leading dot", contactType: models.ContactInstagram, value: ".autotm", wantErr: true},
+		{name: "instagram trailing dot", contactType: models.ContactInstagram, value: "autotm.", wantErr: true},
+		{name: "instagram other host", contactType: models.ContactInstagram, value: "https://facebook.com/autotm", wantErr: true},
+
+		{name: "email lower cased", contactType: models.ContactEmail, value: "Info@AutoTM.com", want: "info@autotm.com"},
+		{name: "email with name", contactType: models.ContactEmail, value: "AutoTM <info@autotm.com>", wantErr: true},
+		{name: "email without domain", contactType: models.ContactEmail, value: "info@", wantErr: true},
+
+		{name: "website", contactType: models.ContactWebsite, value: "https://autotm.com/store", want: "https://autotm.com/store"},
+		{name: "website without scheme", contactType: models.ContactWebsite, value: "autotm.com", wantErr: true},
+		{name: "website other scheme", contactType: models.ContactWebsite, value: "ftp://autotm.com", wantErr: true},
+
+		{name: "unknown type", contactType: "fax", value: "+99312000000", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeContact(tt.contactType, tt.value)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidContact) {
+					t.Fatalf("NormalizeContact(%q, %q) err = %v, want ErrInvalidContact", tt.contactType, tt.value, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeContact(%q, %q) err = %v", tt.contactType, tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeContact(%q, %q) = %q, want %q", tt.contactType, tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Sentinel errors the handlers map to client error statuses.
+var (
+	ErrInvalidVIN        = errors.New("invalid VIN")
+	ErrUnsupportedLocale = errors.New("unsupported locale")
+	ErrInvalidReference  = errors.New("invalid reference")
+	ErrInvalidSchedule   = errors.New("invalid schedule")
+	ErrInvalidTarget     = errors.New("invalid target")
+	ErrInvalidDateRange  = errors.New("invalid date range")
+	ErrInvalidImage      = errors.New("invalid image")
+	ErrInvalidTransition = errors.New("invalid status transition")
+	ErrInvalidStatus     = errors.New("invalid status")
+	ErrInvalidContact    = errors.New("invalid contact")
+	ErrNotFound          = errors.New("not found")
+	ErrInvalidOwner      = errors.New("invalid owner")
+	ErrPlanLimitExceeded = errors.New("plan limit exceeded")
+	ErrUnknownEntity     = errors.New("unknown entity")
+
+	ErrUserServiceUnavailable = errors.New("user service unavailable")
+)
+
+// ReferencedError is returned when a row cannot be deleted because other rows
+// still point to it. References holds the number of referencing rows per table.
+type ReferencedError struct {
+	Entity     string
+	ID         int64
+	References map[string]int64
+}
+
+func (e *ReferencedError) Error() string {
+	tables := make([]string, 0, len(e.References))
+	for table := range e.References {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	parts := make([]string, 0, len(tables))
+	for _, table := range tables {
+		parts = append(parts, fmt.Sprintf("%s: %d", table, e.References[table]))
+	}
+	return fmt.Sprintf("%s %d is still referenced (%s)", e.Entity, e.ID, strings.Join(parts, ", "))
+}
@@ -0,0 +1,53 @@
+package helpers
+
+import (
+	"autotm-admin/internal/configs"
+	"fmt"
+	_ "golang.org/x/image/bmp"
+	_ "golang.org/x/image/webp"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// aspectTolerance is the relative deviation from a required aspect ratio that is still accepted.
+const aspectTolerance = 0.02
+
+// ImageSize returns the pixel dimensions of an uploaded image given its public
+// path, e.g. "/images/1700000000.webp". Only the image header is decoded.
+func ImageSize(relativePath string) (int, int, error) {
+	cfg := configs.GetConfig()
+	fullPath := filepath.Join(cfg.FilePath, strings.TrimPrefix(relativePath, "/"))
+
+	file, err := os.Open(fullPath)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %s: %v", ErrInvalidImage, relativePath, err)
+	}
+	defer file.Close()
+
+	config, _, err := image.DecodeConfig(file)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %s: %v", ErrInvalidImage, relativePath, err)
+	}
+	return config.Width, config.Height, nil
+}
+
+// CheckImageSize reports whether width x height meets the minimum resolution and
+// aspect ratio. Zero requirements are not checked.
+func CheckImageSize(width, height, minWidth, minHeight, aspectWidth, aspectHeight int) error {
+	if width < minWidth || height < minHeight {
+		return fmt.Errorf("%w: %dx%d is below the minimum %dx%d", ErrInvalidImage, width, height, minWidth, minHeight)
+	}
+	if aspectWidth > 0 && aspectHeight > 0 {
+		want := float64(aspectWidth) / float64(aspectHeight)
+		got := float64(width) / float64(height)
+		if got < want*(1-aspectTolerance) || got > want*(1+aspectTolerance) {
+			return fmt.Errorf("%w: %dx%d does not match the %d:%d aspect ratio", ErrInvalidImage, width, height, aspectWidth, aspectHeight)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package helpers
+
+import (
+	"autotm-admin/internal/configs"
+	"fmt"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+	"slices"
+	"strings"
+)
+
+// SupportedLocales returns the configured locales, falling back to tm/en/ru.
+func SupportedLocales() []string {
+	cfg := configs.GetConfig()
+	if cfg == nil || len(cfg.Locales) == 0 {
+		return []string{"tm", "en", "ru"}
+	}
+	return cfg.Locales
+}
+
+// LocalizedValues merges the legacy flat tm/en/ru values into a locale map.
+// Values already present in the map win over the flat ones.
+func LocalizedValues(values map[string]string, tm, en, ru string) map[string]string {
+	result := make(map[string]string, len(values)+3)
+	for locale, value := range values {
+		result[strings.ToLower(strings.TrimSpace(locale))] = value
+	}
+	flat := map[string]string{"tm": tm, "en": en, "ru": ru}
+	for locale, value := range flat {
+		if result[locale] == "" && value != "" {
+			result[locale] = value
+		}
+	}
+	return result
+}
+
+// MergeLocalizedValues applies the locale values of an update to the stored
+// ones. Locales missing from values keep their stored value, so clients that
+// only send the flat tm/en/ru fields do not drop other locales; an empty value
+// removes the locale.
+func MergeLocalizedValues(stored, values map[string]string) map[string]string {
+	result := make(map[string]string, len(stored)+len(values))
+	for locale, value := range stored {
+		if value != "" {
+			result[locale] = value
+		}
+	}
+	for locale, value := range values {
+		if value == "" {
+			delete(result, locale)
+			continue
+		}
+		result[locale] = value
+	}
+	return result
+}
+
+// ValidateLocales rejects locale keys that are not configured.
+func ValidateLocales(values map[string]string) error {
+	supported := SupportedLocales()
+	for locale := range values {
+		if !slices.Contains(supported, locale) {
+			return fmt.Errorf("%w %q, supported: %s", ErrUnsupportedLocale, locale, strings.Join(supported, ", "))
+		}
+	}
+	return nil
+}
+
+// LocalizedName picks the value for locale, falling back to the other
+// configured locales in order when it is empty.
+func LocalizedName(values map[string]string, locale string) string {
+	if value := values[locale]; value != "" {
+		return value
+	}
+	for _, l := range SupportedLocales() {
+		if value := values[l]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// Collator returns a case-insensitive collator for locale. The app uses "tm"
+// for Turkmen, whose language tag is "tk".
+func Collator(locale string) *collate.Collator {
+	if locale == "tm" {
+		locale = "tk"
+	}
+	return collate.New(language.Make(locale), collate.IgnoreCase)
+}
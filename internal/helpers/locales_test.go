@@ -0,0 +1,50 @@
+package helpers
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestMergeLocalizedValues(t *testing.T) {
+	stored := map[string]string{"tm": "Aşgabat", "en": "Ashgabat", "ru": "Ашхабад", "tr": "Aşkabat"}
+
+	tests := []struct {
+		name   string
+		values map[string]string
+		want   map[string]string
+	}{
+		{
+			name:   "flat-only update keeps other locales",
+			values: LocalizedValues(nil, "Aşgabat şäheri", "Ashgabat city", "город Ашхабад"),
+			want:   map[string]string{"tm": "Aşgabat şäheri", "en": "Ashgabat city", "ru": "город Ашхабад", "tr": "Aşkabat"},
+		},
+		{
+			name:   "map update replaces its locales",
+			values: LocalizedValues(map[string]string{"tr": "Aşkabat şehri"}, "Aşgabat", "Ashgabat", "Ашхабад"),
+			want:   map[string]string{"tm": "Aşgabat", "en": "Ashgabat", "ru": "Ашхабад", "tr": "Aşkabat şehri"},
+		},
+		{
+			name:   "empty value removes the locale",
+			values: LocalizedValues(map[string]string{"tr": ""}, "Aşgabat", "Ashgabat", "Ашхабад"),
+			want:   map[string]string{"tm": "Aşgabat", "en": "Ashgabat", "ru": "Ашхабад"},
+		},
+		{
+			name:   "new locale is added",
+			values: map[string]string{"de": "Aschgabat"},
+			want:   map[string]string{"tm": "Aşgabat", "en": "Ashgabat", "ru": "Ашхабад", "tr": "Aşkabat", "de": "Aschgabat"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MergeLocalizedValues(stored, tt.values)
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("MergeLocalizedValues() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if stored["tr"] != "Aşkabat" || len(stored) != 4 {
+		t.Errorf("MergeLocalizedValues modified the stored values: %v", stored)
+	}
+}
@@ -0,0 +1,63 @@
+package helpers
+
+import (
+	"golang.org/x/text/unicode/norm"
+	"strings"
+	"unicode"
+)
+
+const maxSlugLength = 80
+
+// slugLetters transliterates the Turkmen and Russian letters that do not reduce
+// to a Latin letter by dropping their diacritics.
+var slugLetters = map[rune]string{
+	'ç': "ch", 'ň': "n", 'ş': "sh", 'ý': "y", 'ž': "zh",
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "h", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya",
+}
+
+// Slugify turns a name into a lowercase ASCII slug of letters, digits and
+// single hyphens, at most 80 characters long. Names without any letter or
+// digit give "store".
+func Slugify(name string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(name) {
+		s, ok := slugLetters[r]
+		if !ok {
+			s = strings.Map(func(r rune) rune {
+				if unicode.Is(unicode.Mn, r) {
+					return -1
+				}
+				return r
+			}, norm.NFD.String(string(r)))
+		}
+		for _, c := range s {
+			if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') {
+				if hyphen && b.Len() > 0 {
+					b.WriteByte('-')
+				}
+				hyphen = false
+				b.WriteRune(c)
+			} else {
+				hyphen = true
+			}
+		}
+	}
+
+	slug := b.String()
+	if len(slug) > maxSlugLength {
+		slug = slug[:maxSlugLength]
+		if i := strings.LastIndexByte(slug, '-'); i > maxSlugLength/2 {
+			slug = slug[:i]
+		}
+		slug = strings.TrimSuffix(slug, "-")
+	}
+	if slug == "" {
+		return "store"
+	}
+	return slug
+}
@@ -0,0 +1,41 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "turkmen", in: "Aşgabat Awto Merkezi", want: "ashgabat-awto-merkezi"},
+		{name: "turkmen letters", in: "Ňäme Çörek Žemçug ýol", want: "name-chorek-zhemchug-yol"},
+		{name: "cyrillic", in: "Автосалон Ёлка", want: "avtosalon-yolka"},
+		{name: "cyrillic signs are dropped", in: "Объявление Щит", want: "obyavlenie-shchit"},
+		{name: "diacritics", in: "Café Ünal", want: "cafe-unal"},
+		{name: "separators collapse", in: "  Auto -- & -- Moto!! ", want: "auto-moto"},
+		{name: "digits", in: "Store 24/7", want: "store-24-7"},
+		{name: "only separators", in: "  --  ", want: "store"},
+		{name: "empty", in: "", want: "store"},
+		{name: "no latin equivalent", in: "汽车", want: "store"},
+		{name: "cut at a hyphen", in: strings.Repeat("abcde ", 20), want: strings.TrimSuffix(strings.Repeat("abcde-", 13), "-")},
+		{name: "no trailing hyphen", in: strings.Repeat("a", 30) + " " + strings.Repeat("b", 48) + " cd", want: strings.Repeat("a", 30) + "-" + strings.Repeat("b", 48)},
+		{name: "hyphen too early is not cut at", in: strings.Repeat("a", 30) + " " + strings.Repeat("b", 60), want: strings.Repeat("a", 30) + "-" + strings.Repeat("b", 49)},
+		{name: "long word", in: strings.Repeat("a", 100), want: strings.Repeat("a", 80)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Slugify(tt.in)
+			if got != tt.want {
+				t.Errorf("Slugify(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+			if len(got) > maxSlugLength {
+				t.Errorf("Slugify(%q) has %d characters, want at most %d", tt.in, len(got), maxSlugLength)
+			}
+		})
+	}
+}
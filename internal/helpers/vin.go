@@ -0,0 +1,114 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const vinLength = 17
+
+// vinWeights are the ISO 3779 / FMVSS 115 position weights used for the check digit.
+var vinWeights = [vinLength]int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+
+// vinYearCodes lists the model year codes in order, starting from 1980 (A).
+// The sequence repeats every 30 years.
+const vinYearCodes = "ABCDEFGHJKLMNPRSTVWXY123456789"
+
+type VIN struct {
+	VIN             string
+	WMI             string
+	CheckDigit      string
+	CheckDigitValid bool
+	ModelYearCode   string
+	ModelYears      []int
+	Region          string
+}
+
+// DecodeVIN validates the VIN format and decodes the parts that do not depend
+// on the manufacturer. A wrong check digit is reported, not rejected, since
+// many non-North American VINs do not use it.
+func DecodeVIN(vin string) (VIN, error) {
+	vin = strings.ToUpper(strings.TrimSpace(vin))
+	if len(vin) != vinLength {
+		return VIN{}, fmt.Errorf("%w: must be %d characters, got %d", ErrInvalidVIN, vinLength, len(vin))
+	}
+
+	sum := 0
+	for i, ch := range vin {
+		value, ok := vinValue(ch)
+		if !ok {
+			return VIN{}, fmt.Errorf("%w: character %q at position %d is not allowed", ErrInvalidVIN, ch, i+1)
+		}
+		sum += value * vinWeights[i]
+	}
+
+	expected := "X"
+	if rem := sum % 11; rem < 10 {
+		expected = fmt.Sprint(rem)
+	}
+
+	yearCode := vin[9:10]
+	return VIN{
+		VIN:             vin,
+		WMI:             vin[:3],
+		CheckDigit:      vin[8:9],
+		CheckDigitValid: vin[8:9] == expected,
+		ModelYearCode:   yearCode,
+		ModelYears:      vinModelYears(yearCode, time.Now().Year()+1),
+		Region:          vinRegion(vin[0]),
+	}, nil
+}
+
+// vinValue transliterates a VIN character to its check digit value.
+// I, O and Q are never allowed.
+func vinValue(ch rune) (int, bool) {
+	switch {
+	case ch >= '0' && ch <= '9':
+		return int(ch - '0'), true
+	case ch >= 'A' && ch <= 'H':
+		return int(ch-'A') + 1, true
+	case ch >= 'J' && ch <= 'N':
+		return int(ch-'J') + 1, true
+	case ch == 'P':
+		return 7, true
+	case ch == 'R':
+		return 9, true
+	case ch >= 'S' && ch <= 'Z':
+		return int(ch-'S') + 2, true
+	}
+	return 0, false
+}
+
+// vinModelYears returns the model years a year code can stand for, up to
+// maxYear. DecodeVIN passes next year, the latest model year on sale.
+func vinModelYears(code string, maxYear int) []int {
+	index := strings.Index(vinYearCodes, code)
+	if index < 0 {
+		return nil
+	}
+
+	var years []int
+	for year := 1980 + index; year <= maxYear; year += len(vinYearCodes) {
+		years = append(years, year)
+	}
+	return years
+}
+
+func vinRegion(ch byte) string {
+	switch {
+	case ch >= 'A' && ch <= 'H':
+		return "Africa"
+	case ch >= 'J' && ch <= 'R':
+		return "Asia"
+	case ch >= 'S' && ch <= 'Z':
+		return "Europe"
+	case ch >= '1' && ch <= '5':
+		return "North America"
+	case ch == '6' || ch == '7':
+		return "Oceania"
+	case ch == '8' || ch == '9':
+		return "South America"
+	}
+	return "Unknown"
+}
@@ -0,0 +1,102 @@
+package helpers
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDecodeVIN(t *testing.T) {
+	tests := []struct {
+		name       string
+		vin        string
+		wantErr    string
+		checkValid bool
+		wmi        string
+		region     string
+	}{
+		{name: "check digit X", vin: "1M8GDM9AXKP042788", checkValid: true, wmi: "1M8", region: "North America"},
+		{name: "numeric check digit", vin: "1HGCM82633A004352", checkValid: true, wmi: "1HG", region: "North America"},
+		{name: "all ones", vin: "11111111111111111", checkValid: true, wmi: "111", region: "North America"},
+		{name: "lower case and spaces", vin: "  jhmcm56557c404453 ", checkValid: true, wmi: "JHM", region: "Asia"},
+		{name: "wrong check digit is reported", vin: "1M8GDM9A1KP042788", checkValid: false, wmi: "1M8", region: "North America"},
+		{name: "european", vin: "WVWZZZ1JZXW000001", checkValid: false, wmi: "WVW", region: "Europe"},
+		{name: "too short", vin: "1M8GDM9AXKP04278", wantErr: "must be 17 characters"},
+		{name: "too long", vin: "1M8GDM9AXKP0427888", wantErr: "must be 17 characters"},
+		{name: "letter I", vin: "1M8GDM9AXKP04278I", wantErr: "not allowed"},
+		{name: "letter O", vin: "1M8GDM9AXKPO42788", wantErr: "not allowed"},
+		{name: "letter Q", vin: "QM8GDM9AXKP042788", wantErr: "not allowed"},
+		// 15 ASCII characters and a two-byte letter pass the length check
+		{name: "non ASCII", vin: "1M8GDM9AXKP0427Ä", wantErr: "not allowed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeVIN(tt.vin)
+			if tt.wantErr != "" {
+				if !errors.Is(err, ErrInvalidVIN) || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("DecodeVIN(%q) err = %v, want ErrInvalidVIN containing %q", tt.vin, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DecodeVIN(%q) err = %v", tt.vin, err)
+			}
+			if got.CheckDigitValid != tt.checkValid {
+				t.Errorf("CheckDigitValid = %v, want %v", got.CheckDigitValid, tt.checkValid)
+			}
+			if got.WMI != tt.wmi {
+				t.Errorf("WMI = %q, want %q", got.WMI, tt.wmi)
+			}
+			if got.Region != tt.region {
+				t.Errorf("Region = %q, want %q", got.Region, tt.region)
+			}
+		})
+	}
+}
+
+func TestVINValue(t *testing.T) {
+	tests := []struct {
+		ch   rune
+		want int
+		ok   bool
+	}{
+		{'0', 0, true}, {'9', 9, true},
+		{'A', 1, true}, {'H', 8, true},
+		{'J', 1, true}, {'N', 5, true},
+		{'P', 7, true}, {'R', 9, true},
+		{'S', 2, true}, {'Z', 9, true},
+		{'I', 0, false}, {'O', 0, false}, {'Q', 0, false}, {'a', 0, false}, {'-', 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := vinValue(tt.ch)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("vinValue(%q) = %d, %v, want %d, %v", tt.ch, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestVINModelYears(t *testing.T) {
+	tests := []struct {
+		code    string
+		maxYear int
+		want    []int
+	}{
+		{"A", 2027, []int{1980, 2010}},
+		{"L", 2027, []int{1990, 2020}},
+		{"T", 2027, []int{1996, 2026}},
+		{"V", 2026, []int{1997}},
+		{"V", 2027, []int{1997, 2027}},
+		{"A", 2040, []int{1980, 2010, 2040}},
+		{"I", 2027, nil},
+		{"0", 2027, nil},
+	}
+
+	for _, tt := range tests {
+		if got := vinModelYears(tt.code, tt.maxYear); !slices.Equal(got, tt.want) {
+			t.Errorf("vinModelYears(%q, %d) = %v, want %v", tt.code, tt.maxYear, got, tt.want)
+		}
+	}
+}
@@ -1,21 +1,145 @@
 package models
 
+import "time"
+
+// Auto store review statuses.
+const (
+	AutoStoreStatusPending   = "pending"
+	AutoStoreStatusApproved  = "approved"
+	AutoStoreStatusRejected  = "rejected"
+	AutoStoreStatusSuspended = "suspended"
+)
+
+// Auto store contact types. Phone, WhatsApp and IMO contacts hold a phone
+// number; Telegram and Instagram contacts hold a username.
+const (
+	ContactPhone     = "phone"
+	ContactWhatsApp  = "whatsapp"
+	ContactTelegram  = "telegram"
+	ContactIMO       = "imo"
+	ContactInstagram = "instagram"
+	ContactEmail     = "email"
+	ContactWebsite   = "website"
+)
+
 type AutoStore struct {
-	ID           int64
-	UserID       int64
-	PhoneNumber  string
-	Email        string
-	StoreName    string
-	Images       []string
-	LogoPath     string
-	Address      string
-	RegionID     int64
-	CityID       int64
-	CityNameTM   string
-	CityNameEN   string
-	CityNameRU   string
-	RegionNameTM string
-	RegionNameEN string
-	RegionNameRU string
-	UserName     string
+	ID              int64
+	UserID          int64
+	PhoneNumber     string
+	Email           string
+	StoreName       string
+	Slug            string
+	Images          []string
+	LogoPath        string
+	Address         string
+	RegionID        int64
+	CityID          int64
+	DistrictID      int64
+	CityNameTM      string
+	CityNameEN      string
+	CityNameRU      string
+	RegionNameTM    string
+	RegionNameEN    string
+	RegionNameRU    string
+	DistrictNameTM  string
+	DistrictNameEN  string
+	DistrictNameRU  string
+	UserName        string
+	Latitude        *float64
+	Longitude       *float64
+	DistanceKm      float64
+	Status          string
+	RejectionReason string
+	ReviewedBy      *int64
+	ReviewedAt      *time.Time
+	IsExpired       bool
+	Contacts        []AutoStoreContact
+	Brands          []AutoStoreBrand
+}
+
+type AutoStoreContact struct {
+	Type  string
+	Value string
+}
+
+// Roles an auto store can have for a brand.
+const (
+	AutoStoreBrandOfficialDealer = "official_dealer"
+	AutoStoreBrandDealer         = "dealer"
+	AutoStoreBrandService        = "service"
+	AutoStoreBrandParts          = "parts"
+)
+
+// AutoStoreBrand links a store to a brand in a role. An empty Category means
+// every category of the brand.
+type AutoStoreBrand struct {
+	BrandID       int64
+	BrandName     string
+	BrandLogoPath string
+	Category      string
+	Role          string
+}
+
+// OpeningInterval is one opening period of a weekday. Weekday is ISO (1 is
+// Monday, 7 is Sunday); OpensAt and ClosesAt are minutes since midnight, and
+// ClosesAt may be 1440 for midnight.
+type OpeningInterval struct {
+	Weekday  int
+	OpensAt  int
+	ClosesAt int
 }
+
+// StoreClosure is a day on which a store stays closed regardless of its hours.
+type StoreClosure struct {
+	Date   time.Time
+	Reason string
+}
+
+type AutoStoreSchedule struct {
+	AutoStoreID int64
+	Hours       []OpeningInterval
+	Closures    []StoreClosure
+}
+
+// AutoStoreStatusChange is one entry of a store's review history. FromStatus is
+// empty for the status a store was created with.
+type AutoStoreStatusChange struct {
+	ID         int64
+	FromStatus string
+	ToStatus   string
+	Reason     string
+	ChangedBy  *int64
+	CreatedAt  time.Time
+}
+
+// AutoStoreFilter selects and orders a page of auto stores. Zero values match
+// every store; CreatedTo is exclusive and Slug also matches former slugs.
+// BrandID, Category and BrandRole match stores with one brand link satisfying
+// all of them; a link without a category matches every category. A nil
+// Expired matches stores with and without a lapsed subscription.
+// SortBy is one of the AutoStoreSort* constants.
+type AutoStoreFilter struct {
+	Search      string
+	Slug        string
+	Status      string
+	RegionID    int64
+	CityID      int64
+	UserID      int64
+	BrandID     int64
+	Category    string
+	BrandRole   string
+	Expired     *bool
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	SortBy      string
+	Desc        bool
+	Limit       int64
+	Offset      int64
+}
+
+// Auto store listing sort keys.
+const (
+	AutoStoreSortName      = "name"
+	AutoStoreSortCreatedAt = "created_at"
+	AutoStoreSortUpdatedAt = "updated_at"
+)
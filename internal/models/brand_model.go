@@ -1,18 +1,21 @@
 package models
 
 type BodyType struct {
-	ID        int64
-	NameTM    string
-	NameEN    string
-	NameRU    string
-	ImagePath string
-	Category  string
+	ID         int64
+	NameTM     string
+	NameEN     string
+	NameRU     string
+	ImagePath  string
+	CategoryID int64
+	Category   string
+	Names      map[string]string
 }
 type Brand struct {
-	ID         int64
-	Name       string
-	LogoPath   string
-	Categories []string
+	ID          int64
+	Name        string
+	LogoPath    string
+	CategoryIDs []int64
+	Categories  []string
 }
 
 type ID struct {
@@ -21,10 +24,20 @@ type ID struct {
 }
 
 type Model struct {
-	ID        int64
-	Name      string
-	LogoPath  string
-	BrandID   int64
-	BrandName string
-	Category  string
+	ID         int64
+	Name       string
+	LogoPath   string
+	BrandID    int64
+	BrandName  string
+	CategoryID int64
+	Category   string
+}
+
+type WMICode struct {
+	ID           int64
+	Code         string
+	BrandID      int64
+	BrandName    string
+	Manufacturer string
+	Country      string
 }
@@ -0,0 +1,12 @@
+package models
+
+type Category struct {
+	ID       int64
+	Slug     string
+	NameTM   string
+	NameEN   string
+	NameRU   string
+	IconPath string
+	IsActive bool
+	Names    map[string]string
+}
@@ -5,6 +5,8 @@ type Region struct {
 	NameTM string
 	NameEN string
 	NameRU string
+	Names  map[string]string
+	Cities []City
 }
 
 type City struct {
@@ -16,4 +18,20 @@ type City struct {
 	RegionNameTM string
 	RegionNameEN string
 	RegionNameRU string
+	Names        map[string]string
+	Latitude     *float64
+	Longitude    *float64
+	Districts    []District
+}
+
+type District struct {
+	ID         int64
+	NameTM     string
+	NameEN     string
+	NameRU     string
+	CityID     int64
+	CityNameTM string
+	CityNameEN string
+	CityNameRU string
+	Names      map[string]string
 }
@@ -0,0 +1,8 @@
+package models
+
+type LocalizedName struct {
+	ID     int64
+	NameTM string
+	NameEN string
+	NameRU string
+}
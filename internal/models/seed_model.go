@@ -0,0 +1,22 @@
+package models
+
+type SeedBrand struct {
+	Name   string
+	Models []string
+}
+
+type SeedCategory struct {
+	Category Category
+	Brands   []SeedBrand
+}
+
+type SeedRegion struct {
+	Region Region
+	Cities []City
+}
+
+type SeedCatalog struct {
+	Categories []SeedCategory
+	BodyTypes  []BodyType
+	Regions    []SeedRegion
+}
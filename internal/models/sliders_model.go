@@ -1,9 +1,74 @@
 package models
 
+import "time"
+
+// Slider target types.
+const (
+	SliderTargetNone      = "none"
+	SliderTargetURL       = "url"
+	SliderTargetBrand     = "brand"
+	SliderTargetModel     = "model"
+	SliderTargetAutoStore = "auto_store"
+	SliderTargetScreen    = "screen"
+)
+
 type Slider struct {
 	ID          int64
 	ImagePathTM string
 	ImagePathEN string
 	ImagePathRU string
 	Platform    string
+	ImagePaths  map[string]string
+	StartsAt    *time.Time
+	EndsAt      *time.Time
+	IsActive    bool
+	Position    int64
+	Target      SliderTarget
+}
+
+// SliderTarget is what opens when a slider is tapped. ID is set for brand, model
+// and auto store targets; Name is the resolved name of the referenced row.
+type SliderTarget struct {
+	Type   string
+	ID     *int64
+	URL    string
+	URLs   map[string]string
+	Screen string
+	Name   string
+}
+
+// SliderStat holds the aggregated impressions and clicks of a slider for one day.
+type SliderStat struct {
+	SliderID    int64
+	VariantID   int64
+	VariantName string
+	Day         time.Time
+	Impressions int64
+	Clicks      int64
+}
+
+// Platform is a client platform sliders are published to, with the image
+// requirements of its banners. Zero requirements are not enforced.
+type Platform struct {
+	ID           int64
+	Code         string
+	Name         string
+	AspectWidth  int64
+	AspectHeight int64
+	MinWidth     int64
+	MinHeight    int64
+	IsActive     bool
+}
+
+// SliderVariant is an alternative creative of a slider served to a share of
+// clients proportional to its weight.
+type SliderVariant struct {
+	ID          int64
+	SliderID    int64
+	Name        string
+	Weight      int64
+	ImagePathTM string
+	ImagePathEN string
+	ImagePathRU string
+	ImagePaths  map[string]string
 }
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// Store subscription statuses. Only active and cancelled are stored; an active
+// subscription is reported as scheduled before it starts and as expired after
+// it ends.
+const (
+	SubscriptionStatusActive    = "active"
+	SubscriptionStatusCancelled = "cancelled"
+	SubscriptionStatusScheduled = "scheduled"
+	SubscriptionStatusExpired   = "expired"
+)
+
+// SubscriptionPlan is a paid presence on the marketplace. MaxGalleryImages
+// limits the images of a store on the plan; 0 means no limit.
+type SubscriptionPlan struct {
+	ID               int64
+	Name             string
+	Price            float64
+	Currency         string
+	DurationDays     int64
+	MaxGalleryImages int64
+	IsActive         bool
+}
+
+type StoreSubscription struct {
+	ID          int64
+	AutoStoreID int64
+	PlanID      int64
+	PlanName    string
+	StartsAt    time.Time
+	EndsAt      time.Time
+	Status      string
+	CancelledAt *time.Time
+	CreatedAt   time.Time
+}
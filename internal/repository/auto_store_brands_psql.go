@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"autotm-admin/internal/helpers"
+	"autotm-admin/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"strings"
+)
+
+// SetAutoStoreBrands replaces the brand links of a store. Each linked brand must
+// exist and, when a category is given, be sold in that category.
+func (r *AutoStorePsqlRepository) SetAutoStoreBrands(ctx context.Context, autoStoreID int64, brands []models.AutoStoreBrand) error {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var exists bool
+	err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM auto_stores WHERE id = $1)`, autoStoreID).Scan(&exists)
+	if err != nil {
+		r.logger.Errorf("check auto store err: %v", err)
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: auto store %d not found", helpers.ErrInvalidReference, autoStoreID)
+	}
+
+	if _, err = tx.Exec(ctx, `DELETE FROM auto_store_brands WHERE auto_store_id = $1`, autoStoreID); err != nil {
+		r.logger.Errorf("delete auto store brands err: %v", err)
+		return err
+	}
+
+	for _, brand := range brands {
+		var categoryID *int64
+		if brand.Category == "" {
+			err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM brands WHERE id = $1)`, brand.BrandID).Scan(&exists)
+			if err != nil {
+				r.logger.Errorf("check brand err: %v", err)
+				return err
+			}
+			if !exists {
+				return fmt.Errorf("%w: brand %d not found", helpers.ErrInvalidReference, brand.BrandID)
+			}
+		} else {
+			var id int64
+			err = tx.QueryRow(ctx, `
+				SELECT c.id
+				FROM categories c
+					JOIN brand_categories bc ON bc.category_id = c.id AND bc.brand_id = $1
+				WHERE c.slug = $2
+			`, brand.BrandID, brand.Category).Scan(&id)
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("%w: brand %d is not in category %q", helpers.ErrInvalidReference, brand.BrandID, brand.Category)
+			}
+			if err != nil {
+				r.logger.Errorf("check brand category err: %v", err)
+				return err
+			}
+			categoryID = &id
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO auto_store_brands (auto_store_id, brand_id, category_id, role) VALUES ($1, $2, $3, $4)
+		`, autoStoreID, brand.BrandID, categoryID, brand.Role)
+		if err != nil {
+			r.logger.Errorf("insert auto store brand err: %v", err)
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// GetAutoStoreBrands returns the brand links of the given stores keyed by store ID,
+// ordered by brand name.
+func (r *AutoStorePsqlRepository) GetAutoStoreBrands(ctx context.Context, ids []int64) (map[int64][]models.AutoStoreBrand, error) {
+	brands := make(map[int64][]models.AutoStoreBrand, len(ids))
+
+	rows, err := r.client.Query(ctx, `
+		SELECT sb.auto_store_id, sb.brand_id, b.name, COALESCE(b.logo_path, ''), COALESCE(c.slug, ''), sb.role
+		FROM auto_store_brands sb
+			JOIN brands b ON b.id = sb.brand_id
+			LEFT JOIN categories c ON c.id = sb.category_id
+		WHERE sb.auto_store_id = ANY($1::bigint[])
+		ORDER BY sb.auto_store_id, b.name, c.slug NULLS FIRST, sb.role
+	`, ids)
+	if err != nil {
+		r.logger.Errorf("get auto store brands err: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			autoStoreID int64
+			brand       models.AutoStoreBrand
+		)
+		err = rows.Scan(&autoStoreID, &brand.BrandID, &brand.BrandName, &brand.BrandLogoPath, &brand.Category, &brand.Role)
+		if err != nil {
+			r.logger.Errorf("scan auto store brand err: %v", err)
+			return nil, err
+		}
+		brands[autoStoreID] = append(brands[autoStoreID], brand)
+	}
+	return brands, rows.Err()
+}
+
+// autoStoreBrandCondition returns an EXISTS condition matching stores with a
+// brand link that satisfies every set brand filter, or "" when none is set.
+func autoStoreBrandCondition(filter models.AutoStoreFilter, args map[string]any) string {
+	var conditions []string
+	if filter.BrandID != 0 {
+		conditions = append(conditions, "sb.brand_id = @brand_id")
+		args["brand_id"] = filter.BrandID
+	}
+	if filter.Category != "" {
+		conditions = append(conditions, "(sb.category_id IS NULL OR sb.category_id = (SELECT id FROM categories WHERE slug = @brand_category))")
+		args["brand_category"] = filter.Category
+	}
+	if filter.BrandRole != "" {
+		conditions = append(conditions, "sb.role = @brand_role")
+		args["brand_role"] = filter.BrandRole
+	}
+	if len(conditions) == 0 {
+		return ""
+	}
+	return "EXISTS (SELECT 1 FROM auto_store_brands sb WHERE sb.auto_store_id = ast.id AND " + strings.Join(conditions, " AND ") + ")"
+}
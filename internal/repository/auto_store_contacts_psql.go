@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"autotm-admin/internal/models"
+	"context"
+)
+
+// GetAutoStoreContacts returns the ordered contacts of the given stores keyed by store ID.
+func (r *AutoStorePsqlRepository) GetAutoStoreContacts(ctx context.Context, ids []int64) (map[int64][]models.AutoStoreContact, error) {
+	contacts := make(map[int64][]models.AutoStoreContact, len(ids))
+
+	rows, err := r.client.Query(ctx, `
+		SELECT auto_store_id, type, value
+		FROM auto_store_contacts
+		WHERE auto_store_id = ANY($1::bigint[])
+		ORDER BY auto_store_id, position
+	`, ids)
+	if err != nil {
+		r.logger.Errorf("get auto store contacts err: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			autoStoreID int64
+			contact     models.AutoStoreContact
+		)
+		if err = rows.Scan(&autoStoreID, &contact.Type, &contact.Value); err != nil {
+			r.logger.Errorf("scan auto store contact err: %v", err)
+			return nil, err
+		}
+		contacts[autoStoreID] = append(contacts[autoStoreID], contact)
+	}
+	return contacts, rows.Err()
+}
+
+// saveAutoStoreContacts replaces the contacts of a store, keeping their order.
+func saveAutoStoreContacts(ctx context.Context, db execer, autoStoreID int64, contacts []models.AutoStoreContact) error {
+	if _, err := db.Exec(ctx, `DELETE FROM auto_store_contacts WHERE auto_store_id = $1`, autoStoreID); err != nil {
+		return err
+	}
+	for i, contact := range contacts {
+		_, err := db.Exec(ctx, `
+			INSERT INTO auto_store_contacts (auto_store_id, type, value, position) VALUES ($1, $2, $3, $4)
+		`, autoStoreID, contact.Type, contact.Value, i+1)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
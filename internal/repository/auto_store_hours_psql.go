@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"autotm-admin/internal/helpers"
+	"autotm-admin/internal/models"
+	"context"
+	"fmt"
+	"time"
+)
+
+// SetAutoStoreSchedule replaces the opening hours and closures of a store.
+func (r *AutoStorePsqlRepository) SetAutoStoreSchedule(ctx context.Context, schedule models.AutoStoreSchedule) error {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var exists bool
+	err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM auto_stores WHERE id = $1)`, schedule.AutoStoreID).Scan(&exists)
+	if err != nil {
+		r.logger.Errorf("check auto store err: %v", err)
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: auto store %d not found", helpers.ErrInvalidReference, schedule.AutoStoreID)
+	}
+
+	if _, err = tx.Exec(ctx, `DELETE FROM auto_store_hours WHERE auto_store_id = $1`, schedule.AutoStoreID); err != nil {
+		r.logger.Errorf("delete auto store hours err: %v", err)
+		return err
+	}
+	for _, interval := range schedule.Hours {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO auto_store_hours (auto_store_id, weekday, opens_at, closes_at) VALUES ($1, $2, $3::time, $4::time)
+		`, schedule.AutoStoreID, interval.Weekday, clock(interval.OpensAt), clock(interval.ClosesAt))
+		if err != nil {
+			r.logger.Errorf("insert auto store hours err: %v", err)
+			return err
+		}
+	}
+
+	if _, err = tx.Exec(ctx, `DELETE FROM auto_store_closures WHERE auto_store_id = $1`, schedule.AutoStoreID); err != nil {
+		r.logger.Errorf("delete auto store closures err: %v", err)
+		return err
+	}
+	for _, closure := range schedule.Closures {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO auto_store_closures (auto_store_id, date, reason) VALUES ($1, $2, $3)
+		`, schedule.AutoStoreID, closure.Date, closure.Reason)
+		if err != nil {
+			r.logger.Errorf("insert auto store closure err: %v", err)
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// GetAutoStoreSchedules returns the schedules of the given stores keyed by store ID.
+// Only closures from the given day on are returned.
+func (r *AutoStorePsqlRepository) GetAutoStoreSchedules(ctx context.Context, ids []int64, from time.Time) (map[int64]models.AutoStoreSchedule, error) {
+	schedules := make(map[int64]models.AutoStoreSchedule, len(ids))
+	for _, id := range ids {
+		schedules[id] = models.AutoStoreSchedule{AutoStoreID: id}
+	}
+
+	rows, err := r.client.Query(ctx, `
+		SELECT
+		    auto_store_id, weekday,
+		    (EXTRACT(EPOCH FROM opens_at) / 60)::int, (EXTRACT(EPOCH FROM closes_at) / 60)::int
+		FROM auto_store_hours
+		WHERE auto_store_id = ANY($1::bigint[])
+		ORDER BY auto_store_id, weekday, opens_at
+	`, ids)
+	if err != nil {
+		r.logger.Errorf("get auto store hours query err: %v", err)
+		return nil, err
+	}
+	for rows.Next() {
+		var (
+			storeID  int64
+			interval models.OpeningInterval
+		)
+		if err = rows.Scan(&storeID, &interval.Weekday, &interval.OpensAt, &interval.ClosesAt); err != nil {
+			rows.Close()
+			r.logger.Errorf("get auto store hours scan err: %v", err)
+			return nil, err
+		}
+		schedule := schedules[storeID]
+		schedule.Hours = append(schedule.Hours, interval)
+		schedules[storeID] = schedule
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = r.client.Query(ctx, `
+		SELECT auto_store_id, date, reason
+		FROM auto_store_closures
+		WHERE auto_store_id = ANY($1::bigint[]) AND date >= $2
+		ORDER BY auto_store_id, date
+	`, ids, from)
+	if err != nil {
+		r.logger.Errorf("get auto store closures query err: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			storeID int64
+			closure models.StoreClosure
+		)
+		if err = rows.Scan(&storeID, &closure.Date, &closure.Reason); err != nil {
+			r.logger.Errorf("get auto store closures scan err: %v", err)
+			return nil, err
+		}
+		schedule := schedules[storeID]
+		schedule.Closures = append(schedule.Closures, closure)
+		schedules[storeID] = schedule
+	}
+	return schedules, rows.Err()
+}
+
+// clock formats minutes since midnight as HH:MM.
+func clock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
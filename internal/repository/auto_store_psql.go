@@ -1,11 +1,15 @@
 package repository
 
 import (
+	"autotm-admin/internal/helpers"
 	"autotm-admin/internal/models"
 	"context"
+	"errors"
+	"fmt"
 	"github.com/jackc/pgx/v5"
 	slog "github.com/salamsites/package-log"
 	spsql "github.com/salamsites/package-psql"
+	"strings"
 )
 
 type AutoStorePsqlRepository struct {
@@ -20,13 +24,36 @@ func NewAutoStorePsqlRepository(logger *slog.Logger, client spsql.Client) *AutoS
 	}
 }
 
-func (r *AutoStorePsqlRepository) CreateAutoStore(ctx context.Context, autoStore models.AutoStore) (int64, error) {
+// CreateAutoStore inserts a store awaiting review and records its initial
+// status in the history. autoStore.Slug is the base slug; a numeric suffix is
+// added when another store already uses it. When maxStores is positive the
+// owner may not have more stores than that.
+func (r *AutoStorePsqlRepository) CreateAutoStore(ctx context.Context, autoStore models.AutoStore, maxStores int) (int64, error) {
 	var id int64
 
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return id, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = checkUserAutoStoreLimit(ctx, tx, autoStore.UserID, maxStores); err != nil {
+		return id, err
+	}
+	if err = checkAutoStoreLocation(ctx, tx, autoStore.RegionID, autoStore.CityID, autoStore.DistrictID); err != nil {
+		return id, err
+	}
+
+	slug, err := uniqueAutoStoreSlug(ctx, tx, autoStore.Slug, 0)
+	if err != nil {
+		r.logger.Errorf("Error generating auto store slug: %s", err.Error())
+		return id, err
+	}
+
 	query := ` 
 			INSERT INTO auto_stores 
-			    (user_id, phone_number, email, store_name, images, logo_path, address, region_id, city_id) 
-			VALUES (@user_id, @phone_number, @email, @store_name, @images, @logo_path, @address, @region_id, @city_id) 
+			    (user_id, phone_number, email, store_name, slug, images, logo_path, address, region_id, city_id, district_id, latitude, longitude, status) 
+			VALUES (@user_id, @phone_number, @email, @store_name, @slug, @images, @logo_path, @address, @region_id, @city_id, NULLIF(@district_id, 0), @latitude, @longitude, @status) 
 			RETURNING id;
 	`
 
@@ -35,47 +62,134 @@ func (r *AutoStorePsqlRepository) CreateAutoStore(ctx context.Context, autoStore
 		"phone_number": autoStore.PhoneNumber,
 		"email":        autoStore.Email,
 		"store_name":   autoStore.StoreName,
+		"slug":         slug,
 		"images":       autoStore.Images,
 		"logo_path":    autoStore.LogoPath,
 		"address":      autoStore.Address,
 		"region_id":    autoStore.RegionID,
 		"city_id":      autoStore.CityID,
+		"district_id":  autoStore.DistrictID,
+		"latitude":     autoStore.Latitude,
+		"longitude":    autoStore.Longitude,
+		"status":       models.AutoStoreStatusPending,
 	}
 
-	err := r.client.QueryRow(ctx, query, args).Scan(&id)
+	err = tx.QueryRow(ctx, query, args).Scan(&id)
 	if err != nil {
 		r.logger.Errorf("Error creating auto store: %s", err.Error())
 		return id, err
 	}
-	return id, nil
+
+	if err = saveAutoStoreContacts(ctx, tx, id, autoStore.Contacts); err != nil {
+		r.logger.Errorf("Error saving auto store contacts: %s", err.Error())
+		return id, err
+	}
+
+	change := models.AutoStoreStatusChange{ToStatus: models.AutoStoreStatusPending}
+	if err = insertAutoStoreStatusChange(ctx, tx, id, change); err != nil {
+		r.logger.Errorf("Error recording auto store status: %s", err.Error())
+		return id, err
+	}
+	return id, tx.Commit(ctx)
 }
 
-func (r *AutoStorePsqlRepository) GetAutoStores(ctx context.Context, limit, page int64, search string) ([]models.AutoStore, int64, error) {
+// autoStoreSortColumns maps the listing sort keys to their columns.
+var autoStoreSortColumns = map[string]string{
+	models.AutoStoreSortName:      "ast.store_name",
+	models.AutoStoreSortCreatedAt: "ast.created_at",
+	models.AutoStoreSortUpdatedAt: "ast.updated_at",
+}
+
+// autoStoreFilterWhere builds the WHERE clause shared by the auto store
+// listing and its count, so both always select the same rows.
+func autoStoreFilterWhere(filter models.AutoStoreFilter) (string, pgx.NamedArgs) {
+	conditions := []string{`ast.store_name ILIKE '%' || @search || '%'`}
+	args := pgx.NamedArgs{"search": filter.Search}
+
+	if filter.Slug != "" {
+		conditions = append(conditions, "(ast.slug = @slug OR ast.id IN (SELECT auto_store_id FROM auto_store_slugs WHERE slug = @slug))")
+		args["slug"] = filter.Slug
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, "ast.status = @status")
+		args["status"] = filter.Status
+	}
+	if filter.RegionID != 0 {
+		conditions = append(conditions, "ast.region_id = @region_id")
+		args["region_id"] = filter.RegionID
+	}
+	if filter.CityID != 0 {
+		conditions = append(conditions, "ast.city_id = @city_id")
+		args["city_id"] = filter.CityID
+	}
+	if filter.UserID != 0 {
+		conditions = append(conditions, "ast.user_id = @user_id")
+		args["user_id"] = filter.UserID
+	}
+	if filter.Expired != nil {
+		conditions = append(conditions, autoStoreExpiredSQL+" = @is_expired")
+		args["is_expired"] = *filter.Expired
+	}
+	if condition := autoStoreBrandCondition(filter, args); condition != "" {
+		conditions = append(conditions, condition)
+	}
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, "ast.created_at >= @created_from")
+		args["created_from"] = *filter.CreatedFrom
+	}
+	if filter.CreatedTo != nil {
+		conditions = append(conditions, "ast.created_at < @created_to")
+		args["created_to"] = *filter.CreatedTo
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// GetAutoStores returns a page of stores matching the filter and the number of
+// matching stores. An unknown sort key sorts by creation time; the ID breaks
+// ties so pages stay stable.
+func (r *AutoStorePsqlRepository) GetAutoStores(ctx context.Context, filter models.AutoStoreFilter) ([]models.AutoStore, int64, error) {
 	var (
 		autoStores []models.AutoStore
 		count      int64
 	)
 
+	where, args := autoStoreFilterWhere(filter)
+
+	sortColumn, ok := autoStoreSortColumns[filter.SortBy]
+	if !ok {
+		sortColumn = autoStoreSortColumns[models.AutoStoreSortCreatedAt]
+	}
+	direction := "ASC"
+	if filter.Desc {
+		direction = "DESC"
+	}
+
 	query := `
 			SELECT
-				ast.id, ast.user_id, ast.phone_number, ast.email, ast.store_name, 
+				ast.id, ast.user_id, ast.phone_number, ast.email, ast.store_name, ast.slug, 
 				ast.images, ast.logo_path, ast.address, ast.city_id, c.name_tm,
-				c.name_en, c.name_ru, ast.region_id, r.name_tm, r.name_en, r.name_ru
+				c.name_en, c.name_ru, ast.region_id, r.name_tm, r.name_en, r.name_ru,
+				COALESCE(ast.district_id, 0), COALESCE(d.name_tm, ''), COALESCE(d.name_en, ''), COALESCE(d.name_ru, ''),
+				ast.latitude, ast.longitude, ast.status, COALESCE(ast.rejection_reason, ''), ast.reviewed_by, ast.reviewed_at,
+				` + autoStoreExpiredSQL + `
            FROM auto_stores ast
            LEFT JOIN cities c ON c.id = ast.city_id
            LEFT JOIN regions r on r.id = ast.region_id
-		   WHERE ast.store_name ILIKE '%' || @search || '%'
-		   ORDER BY ast.created_at DESC
+           LEFT JOIN districts d ON d.id = ast.district_id
+		   ` + where + `
+		   ORDER BY ` + sortColumn + ` ` + direction + `, ast.id ` + direction + `
 		   LIMIT @limit OFFSET @page
 		`
 
-	args := pgx.NamedArgs{
-		"search": search,
-		"limit":  limit,
-		"page":   page,
+	listArgs := pgx.NamedArgs{
+		"limit": filter.Limit,
+		"page":  filter.Offset,
+	}
+	for name, value := range args {
+		listArgs[name] = value
 	}
 
-	rows, err := r.client.Query(ctx, query, args)
+	rows, err := r.client.Query(ctx, query, listArgs)
 	if err != nil {
 		r.logger.Errorf("Error getting auto-store: %s", err)
 		return nil, 0, err
@@ -90,6 +204,7 @@ func (r *AutoStorePsqlRepository) GetAutoStores(ctx context.Context, limit, page
 			&store.PhoneNumber,
 			&store.Email,
 			&store.StoreName,
+			&store.Slug,
 			&store.Images,
 			&store.LogoPath,
 			&store.Address,
@@ -101,6 +216,17 @@ func (r *AutoStorePsqlRepository) GetAutoStores(ctx context.Context, limit, page
 			&store.RegionNameTM,
 			&store.RegionNameEN,
 			&store.RegionNameRU,
+			&store.DistrictID,
+			&store.DistrictNameTM,
+			&store.DistrictNameEN,
+			&store.DistrictNameRU,
+			&store.Latitude,
+			&store.Longitude,
+			&store.Status,
+			&store.RejectionReason,
+			&store.ReviewedBy,
+			&store.ReviewedAt,
+			&store.IsExpired,
 		)
 		if err != nil {
 			r.logger.Errorf("Error scanning auto-store: %s", err)
@@ -109,12 +235,9 @@ func (r *AutoStorePsqlRepository) GetAutoStores(ctx context.Context, limit, page
 		autoStores = append(autoStores, store)
 	}
 
-	queryCount := `SELECT COUNT(*) FROM auto_stores WHERE store_name ILIKE '%' || @search || '%'`
-	argsCount := pgx.NamedArgs{
-		"search": search,
-	}
+	queryCount := `SELECT COUNT(*) FROM auto_stores ast ` + where
 
-	err = r.client.QueryRow(ctx, queryCount, argsCount).Scan(&count)
+	err = r.client.QueryRow(ctx, queryCount, args).Scan(&count)
 	if err != nil {
 		r.logger.Errorf("Error getting auto-store count: %s", err)
 		return nil, 0, err
@@ -123,13 +246,148 @@ func (r *AutoStorePsqlRepository) GetAutoStores(ctx context.Context, limit, page
 	return autoStores, count, nil
 }
 
-func (r *AutoStorePsqlRepository) UpdateAutoStore(ctx context.Context, autoStore models.AutoStore) (int64, error) {
+// GetNearestAutoStores returns the approved, unexpired stores within radiusKm of a point, nearest first.
+// The great-circle distance is computed with the haversine formula; a latitude
+// band around the point narrows the rows before the distance is evaluated.
+func (r *AutoStorePsqlRepository) GetNearestAutoStores(ctx context.Context, lat, lng, radiusKm float64, limit int64) ([]models.AutoStore, error) {
+	var autoStores []models.AutoStore
+
+	query := `
+			SELECT
+				ast.id, ast.user_id, ast.phone_number, ast.email, ast.store_name, ast.slug,
+				ast.images, ast.logo_path, ast.address, ast.city_id, c.name_tm,
+				c.name_en, c.name_ru, ast.region_id, r.name_tm, r.name_en, r.name_ru,
+				COALESCE(ast.district_id, 0), COALESCE(d.name_tm, ''), COALESCE(d.name_en, ''), COALESCE(d.name_ru, ''),
+				ast.latitude, ast.longitude, ast.status, COALESCE(ast.rejection_reason, ''), ast.reviewed_by, ast.reviewed_at,
+				` + autoStoreExpiredSQL + `, dist.distance_km
+			FROM auto_stores ast
+			LEFT JOIN cities c ON c.id = ast.city_id
+			LEFT JOIN regions r on r.id = ast.region_id
+			LEFT JOIN districts d ON d.id = ast.district_id
+			CROSS JOIN LATERAL (
+				SELECT 2 * 6371 * asin(LEAST(1, sqrt(
+					power(sin(radians(ast.latitude - @lat) / 2), 2) +
+					cos(radians(@lat)) * cos(radians(ast.latitude)) *
+					power(sin(radians(ast.longitude - @lng) / 2), 2)
+				))) AS distance_km
+			) dist
+			WHERE ast.status = @status AND NOT ` + autoStoreExpiredSQL + `
+				AND ast.latitude IS NOT NULL AND ast.longitude IS NOT NULL
+				AND ast.latitude BETWEEN @lat - @radius / 111.045 AND @lat + @radius / 111.045
+				AND dist.distance_km <= @radius
+			ORDER BY dist.distance_km
+			LIMIT @limit
+		`
+
+	args := pgx.NamedArgs{
+		"lat":    lat,
+		"lng":    lng,
+		"radius": radiusKm,
+		"status": models.AutoStoreStatusApproved,
+		"limit":  limit,
+	}
+
+	rows, err := r.client.Query(ctx, query, args)
+	if err != nil {
+		r.logger.Errorf("Error getting nearest auto-stores: %s", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var store models.AutoStore
+		err = rows.Scan(
+			&store.ID,
+			&store.UserID,
+			&store.PhoneNumber,
+			&store.Email,
+			&store.StoreName,
+			&store.Slug,
+			&store.Images,
+			&store.LogoPath,
+			&store.Address,
+			&store.CityID,
+			&store.CityNameTM,
+			&store.CityNameEN,
+			&store.CityNameRU,
+			&store.RegionID,
+			&store.RegionNameTM,
+			&store.RegionNameEN,
+			&store.RegionNameRU,
+			&store.DistrictID,
+			&store.DistrictNameTM,
+			&store.DistrictNameEN,
+			&store.DistrictNameRU,
+			&store.Latitude,
+			&store.Longitude,
+			&store.Status,
+			&store.RejectionReason,
+			&store.ReviewedBy,
+			&store.ReviewedAt,
+			&store.IsExpired,
+			&store.DistanceKm,
+		)
+		if err != nil {
+			r.logger.Errorf("Error scanning nearest auto-store: %s", err)
+			return nil, err
+		}
+		autoStores = append(autoStores, store)
+	}
+	return autoStores, nil
+}
+
+// UpdateAutoStore saves a store. When the new name gives a different base slug
+// (autoStore.Slug), the store gets a new unique slug and keeps the old one as a
+// redirect. A store changing hands must stay within the new owner's maxStores;
+// stores keeping their owner are never rejected, so lowering the limit does not
+// lock existing stores.
+func (r *AutoStorePsqlRepository) UpdateAutoStore(ctx context.Context, autoStore models.AutoStore, maxStores int) (int64, error) {
 	var autoStoreID int64
 
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return autoStoreID, err
+	}
+	defer tx.Rollback(ctx)
+
+	var oldName, oldSlug string
+	var oldUserID int64
+	err = tx.QueryRow(ctx, `SELECT store_name, slug, COALESCE(user_id, 0) FROM auto_stores WHERE id = $1 FOR UPDATE`, autoStore.ID).
+		Scan(&oldName, &oldSlug, &oldUserID)
+	if err != nil {
+		r.logger.Errorf("lock auto store err: %v", err)
+		return autoStoreID, err
+	}
+
+	if oldUserID != autoStore.UserID {
+		if err = checkUserAutoStoreLimit(ctx, tx, autoStore.UserID, maxStores); err != nil {
+			return autoStoreID, err
+		}
+	}
+	if err = checkAutoStoreLocation(ctx, tx, autoStore.RegionID, autoStore.CityID, autoStore.DistrictID); err != nil {
+		return autoStoreID, err
+	}
+
+	slug := oldSlug
+	if helpers.Slugify(oldName) != autoStore.Slug {
+		slug, err = uniqueAutoStoreSlug(ctx, tx, autoStore.Slug, autoStore.ID)
+		if err != nil {
+			r.logger.Errorf("generate auto store slug err: %v", err)
+			return autoStoreID, err
+		}
+		if slug != oldSlug {
+			if err = renameAutoStoreSlug(ctx, tx, autoStore.ID, oldSlug, slug); err != nil {
+				r.logger.Errorf("rename auto store slug err: %v", err)
+				return autoStoreID, err
+			}
+		}
+	}
+
 	query := `
 		UPDATE auto_stores SET 
-		    user_id = @user_id, phone_number = @phone_number, email = @email, store_name = @store_name, images = @images,
-		    logo_path = @logo_path, address = @address, region_id = @region_id, city_id = @city_id, updated_at = NOW()
+		    user_id = @user_id, phone_number = @phone_number, email = @email, store_name = @store_name, slug = @slug, images = @images,
+		    logo_path = @logo_path, address = @address, region_id = @region_id, city_id = @city_id,
+		    district_id = NULLIF(@district_id, 0), latitude = @latitude, longitude = @longitude, updated_at = NOW()
 		WHERE id = @id
 		RETURNING id
 	`
@@ -139,31 +397,150 @@ func (r *AutoStorePsqlRepository) UpdateAutoStore(ctx context.Context, autoStore
 		"phone_number": autoStore.PhoneNumber,
 		"email":        autoStore.Email,
 		"store_name":   autoStore.StoreName,
+		"slug":         slug,
 		"images":       autoStore.Images,
 		"logo_path":    autoStore.LogoPath,
 		"address":      autoStore.Address,
 		"region_id":    autoStore.RegionID,
 		"city_id":      autoStore.CityID,
+		"district_id":  autoStore.DistrictID,
+		"latitude":     autoStore.Latitude,
+		"longitude":    autoStore.Longitude,
 		"id":           autoStore.ID,
 	}
-	err := r.client.QueryRow(ctx, query, args).Scan(&autoStoreID)
+	err = tx.QueryRow(ctx, query, args).Scan(&autoStoreID)
 	if err != nil {
 		r.logger.Errorf("update body types err: %v", err)
 		return autoStoreID, err
 	}
-	return autoStoreID, nil
+
+	if err = saveAutoStoreContacts(ctx, tx, autoStoreID, autoStore.Contacts); err != nil {
+		r.logger.Errorf("save auto store contacts err: %v", err)
+		return autoStoreID, err
+	}
+	return autoStoreID, tx.Commit(ctx)
 }
 
 func (r *AutoStorePsqlRepository) DeleteAutoStore(ctx context.Context, id models.ID) error {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
 	query := ` DELETE FROM auto_stores WHERE id = @id `
 
 	args := pgx.NamedArgs{
 		"id": id.ID,
 	}
-	_, err := r.client.Exec(ctx, query, args)
+	_, err = tx.Exec(ctx, query, args)
 	if err != nil {
 		r.logger.Errorf("delete autoStore err: %v", err)
 		return err
 	}
+
+	if err = clearSliderTargets(ctx, tx, models.SliderTargetAutoStore, id.ID); err != nil {
+		r.logger.Errorf("clear auto store slider targets err: %v", err)
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// checkUserAutoStoreLimit returns ErrInvalidOwner when userID already owns
+// maxStores stores. It holds a per-user lock until tx ends, so concurrent
+// creates for the same user are counted one after another. A maxStores of 0
+// means no limit.
+func checkUserAutoStoreLimit(ctx context.Context, tx pgx.Tx, userID int64, maxStores int) error {
+	if maxStores <= 0 {
+		return nil
+	}
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, userID); err != nil {
+		return err
+	}
+
+	var count int64
+	if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM auto_stores WHERE user_id = $1`, userID).Scan(&count); err != nil {
+		return err
+	}
+	if count >= int64(maxStores) {
+		return fmt.Errorf("%w: user %d already owns %d auto stores, the limit is %d", helpers.ErrInvalidOwner, userID, count, maxStores)
+	}
+	return nil
+}
+
+// GetAutoStoreGalleryLimit returns the number of gallery images of a store and
+// the image limit of the plan it is currently subscribed to. The limit is nil
+// when the store has no running subscription or its plan is unlimited.
+func (r *AutoStorePsqlRepository) GetAutoStoreGalleryLimit(ctx context.Context, id int64) (int64, *int64, error) {
+	var (
+		images int64
+		limit  *int64
+	)
+
+	query := `
+		SELECT
+		    COALESCE(cardinality(ast.images), 0),
+		    (SELECT NULLIF(p.max_gallery_images, 0)
+		     FROM store_subscriptions ss
+		     JOIN subscription_plans p ON p.id = ss.plan_id
+		     WHERE ss.auto_store_id = ast.id AND ss.status = $2 AND ss.starts_at <= NOW() AND ss.ends_at > NOW()
+		     ORDER BY ss.ends_at DESC
+		     LIMIT 1)
+		FROM auto_stores ast
+		WHERE ast.id = $1
+	`
+	err := r.client.QueryRow(ctx, query, id, models.SubscriptionStatusActive).Scan(&images, &limit)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil, fmt.Errorf("%w: auto store %d not found", helpers.ErrInvalidReference, id)
+	}
+	if err != nil {
+		r.logger.Errorf("get auto store gallery limit err: %v", err)
+		return 0, nil, err
+	}
+	return images, limit, nil
+}
+
+// checkAutoStoreLocation makes sure the district of a store lies in its city
+// and the city in its region. Unknown IDs and mismatches are ErrInvalidReference
+// errors; a zero district means the store has none.
+func checkAutoStoreLocation(ctx context.Context, tx pgx.Tx, regionID, cityID, districtID int64) error {
+	if districtID != 0 {
+		var districtCityID int64
+		err := tx.QueryRow(ctx, `SELECT city_id FROM districts WHERE id = $1`, districtID).Scan(&districtCityID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: district %d not found", helpers.ErrInvalidReference, districtID)
+		}
+		if err != nil {
+			return err
+		}
+		if districtCityID != cityID {
+			return fmt.Errorf("%w: district %d is not in city %d", helpers.ErrInvalidReference, districtID, cityID)
+		}
+	}
+
+	if cityID != 0 {
+		var cityRegionID int64
+		err := tx.QueryRow(ctx, `SELECT COALESCE(region_id, 0) FROM cities WHERE id = $1`, cityID).Scan(&cityRegionID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: city %d not found", helpers.ErrInvalidReference, cityID)
+		}
+		if err != nil {
+			return err
+		}
+		if cityRegionID != regionID {
+			return fmt.Errorf("%w: city %d is not in region %d", helpers.ErrInvalidReference, cityID, regionID)
+		}
+	}
+
+	if regionID != 0 {
+		var exists bool
+		err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM regions WHERE id = $1)`, regionID).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("%w: region %d not found", helpers.ErrInvalidReference, regionID)
+		}
+	}
 	return nil
 }
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+)
+
+// uniqueAutoStoreSlug returns base, or base with the lowest free numeric suffix,
+// such that no other store uses it as its current or a former slug.
+// Slugs that used to belong to autoStoreID itself may be taken back.
+func uniqueAutoStoreSlug(ctx context.Context, tx pgx.Tx, base string, autoStoreID int64) (string, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT slug FROM auto_stores
+		WHERE id <> $2 AND (slug = $1 OR slug LIKE $1 || '-%')
+		UNION
+		SELECT slug FROM auto_store_slugs
+		WHERE auto_store_id <> $2 AND (slug = $1 OR slug LIKE $1 || '-%')
+	`, base, autoStoreID)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	used := make(map[string]struct{})
+	for rows.Next() {
+		var slug string
+		if err = rows.Scan(&slug); err != nil {
+			return "", err
+		}
+		used[slug] = struct{}{}
+	}
+	if err = rows.Err(); err != nil {
+		return "", err
+	}
+
+	slug := base
+	for n := 2; ; n++ {
+		if _, ok := used[slug]; !ok {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, n)
+	}
+}
+
+// renameAutoStoreSlug moves a store to a new slug, keeping the old one as a
+// redirect. A former slug of the store that becomes current again is removed
+// from the redirects.
+func renameAutoStoreSlug(ctx context.Context, tx pgx.Tx, autoStoreID int64, oldSlug, newSlug string) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO auto_store_slugs (slug, auto_store_id) VALUES ($1, $2)
+		ON CONFLICT (slug) DO NOTHING
+	`, oldSlug, autoStoreID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `DELETE FROM auto_store_slugs WHERE slug = $1 AND auto_store_id = $2`, newSlug, autoStoreID)
+	return err
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"autotm-admin/internal/helpers"
+	"autotm-admin/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"slices"
+)
+
+// ChangeAutoStoreStatus moves a store to a new review status and records the
+// change in its history. The store must currently be in one of the from
+// statuses; otherwise ErrInvalidTransition is returned.
+func (r *AutoStorePsqlRepository) ChangeAutoStoreStatus(ctx context.Context, id int64, from []string, change models.AutoStoreStatusChange) error {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var current string
+	err = tx.QueryRow(ctx, `SELECT status FROM auto_stores WHERE id = $1 FOR UPDATE`, id).Scan(&current)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: auto store %d not found", helpers.ErrInvalidReference, id)
+	}
+	if err != nil {
+		r.logger.Errorf("lock auto store err: %v", err)
+		return err
+	}
+	if !slices.Contains(from, current) {
+		return fmt.Errorf("%w: auto store %d is %s and cannot become %s", helpers.ErrInvalidTransition, id, current, change.ToStatus)
+	}
+
+	if change.ChangedBy != nil {
+		var exists bool
+		err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1)`, *change.ChangedBy).Scan(&exists)
+		if err != nil {
+			r.logger.Errorf("check reviewer err: %v", err)
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("%w: user %d not found", helpers.ErrInvalidReference, *change.ChangedBy)
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE auto_stores SET
+		    status = $2, rejection_reason = NULLIF($3, ''), reviewed_by = $4, reviewed_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+	`, id, change.ToStatus, change.Reason, change.ChangedBy)
+	if err != nil {
+		r.logger.Errorf("update auto store status err: %v", err)
+		return err
+	}
+
+	change.FromStatus = current
+	if err = insertAutoStoreStatusChange(ctx, tx, id, change); err != nil {
+		r.logger.Errorf("insert auto store status history err: %v", err)
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// GetAutoStoreStatusHistory returns the status changes of a store, oldest first.
+func (r *AutoStorePsqlRepository) GetAutoStoreStatusHistory(ctx context.Context, id int64) ([]models.AutoStoreStatusChange, error) {
+	var history []models.AutoStoreStatusChange
+
+	rows, err := r.client.Query(ctx, `
+		SELECT id, COALESCE(from_status, ''), to_status, COALESCE(reason, ''), changed_by, created_at
+		FROM auto_store_status_history
+		WHERE auto_store_id = $1
+		ORDER BY created_at, id
+	`, id)
+	if err != nil {
+		r.logger.Errorf("get auto store status history err: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var change models.AutoStoreStatusChange
+		err = rows.Scan(
+			&change.ID,
+			&change.FromStatus,
+			&change.ToStatus,
+			&change.Reason,
+			&change.ChangedBy,
+			&change.CreatedAt,
+		)
+		if err != nil {
+			r.logger.Errorf("scan auto store status history err: %v", err)
+			return nil, err
+		}
+		history = append(history, change)
+	}
+	return history, rows.Err()
+}
+
+func insertAutoStoreStatusChange(ctx context.Context, db execer, autoStoreID int64, change models.AutoStoreStatusChange) error {
+	_, err := db.Exec(ctx, `
+		INSERT INTO auto_store_status_history (auto_store_id, from_status, to_status, reason, changed_by)
+		VALUES ($1, NULLIF($2, ''), $3, NULLIF($4, ''), $5)
+	`, autoStoreID, change.FromStatus, change.ToStatus, change.Reason, change.ChangedBy)
+	return err
+}
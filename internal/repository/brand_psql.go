@@ -22,13 +22,28 @@ func NewBrandPsqlRepository(logger *slog.Logger, client spsql.Client) *BrandPsql
 func (r *BrandPsqlRepository) CreateBodyType(ctx context.Context, bodyType models.BodyType) (int64, error) {
 	var id int64
 
-	query := ` INSERT INTO body_types (name_tm, name_en, name_ru, image_path, category) VALUES ($1, $2, $3, $4, $5) RETURNING id `
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := ` INSERT INTO body_types (name_tm, name_en, name_ru, image_path, category_id) VALUES ($1, $2, $3, $4, $5) RETURNING id `
 
-	err := r.client.QueryRow(ctx, query, bodyType.NameTM, bodyType.NameEN, bodyType.NameRU, bodyType.ImagePath, bodyType.Category).Scan(&id)
+	err = tx.QueryRow(ctx, query, bodyType.NameTM, bodyType.NameEN, bodyType.NameRU, bodyType.ImagePath, bodyType.CategoryID).Scan(&id)
 	if err != nil {
 		r.logger.Errorf("Error creating body type: %s", err.Error())
 		return id, err
 	}
+
+	if err = saveTranslations(ctx, tx, entityBodyTypes, id, fieldName, bodyType.Names); err != nil {
+		r.logger.Errorf("save body type translations err: %v", err)
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
 	return id, nil
 }
 
@@ -40,11 +55,13 @@ func (r *BrandPsqlRepository) GetBodyType(ctx context.Context, limit, page int64
 
 	query := `
 			SELECT 
-				id, name_tm, name_en, name_ru, category, image_path
-            FROM body_types
-			WHERE category = $1 AND 
-			    (name_tm ILIKE '%' || $2 || '%' OR name_en ILIKE '%' || $2 || '%' OR name_ru ILIKE '%' || $2 || '%')
-			ORDER BY created_at DESC
+				bt.id, bt.name_tm, bt.name_en, bt.name_ru, bt.category_id, c.slug, bt.image_path,
+				` + translationsColumn(entityBodyTypes, "bt.id", fieldName) + `
+            FROM body_types bt
+				JOIN categories c ON c.id = bt.category_id
+			WHERE c.slug = $1 AND 
+			    (bt.name_tm ILIKE '%' || $2 || '%' OR bt.name_en ILIKE '%' || $2 || '%' OR bt.name_ru ILIKE '%' || $2 || '%')
+			ORDER BY bt.created_at DESC
 			LIMIT $3 OFFSET $4;
 		`
 
@@ -56,7 +73,8 @@ func (r *BrandPsqlRepository) GetBodyType(ctx context.Context, limit, page int64
 	defer rows.Close()
 	for rows.Next() {
 		var bodyType models.BodyType
-		if err = rows.Scan(&bodyType.ID, &bodyType.NameTM, &bodyType.NameEN, &bodyType.NameRU, &bodyType.Category, &bodyType.ImagePath); err != nil {
+		if err = rows.Scan(&bodyType.ID, &bodyType.NameTM, &bodyType.NameEN, &bodyType.NameRU, &bodyType.CategoryID, &bodyType.Category,
+			&bodyType.ImagePath, &bodyType.Names); err != nil {
 			r.logger.Errorf("get body types scan err : %v", err)
 			return nil, 0, err
 		}
@@ -65,10 +83,11 @@ func (r *BrandPsqlRepository) GetBodyType(ctx context.Context, limit, page int64
 
 	queryCount := `
 			SELECT 
-			    COUNT(*) 
-			FROM body_types 
-			WHERE category = $1 AND 
-				(name_tm ILIKE '%' || $2 || '%' OR name_en ILIKE '%' || $2 || '%' OR name_ru ILIKE '%' || $2 || '%')	
+			    COUNT(bt.id) 
+			FROM body_types bt
+				JOIN categories c ON c.id = bt.category_id
+			WHERE c.slug = $1 AND 
+				(bt.name_tm ILIKE '%' || $2 || '%' OR bt.name_en ILIKE '%' || $2 || '%' OR bt.name_ru ILIKE '%' || $2 || '%')	
 		`
 	errCount := r.client.QueryRow(ctx, queryCount, category, search).Scan(&count)
 	if errCount != nil {
@@ -83,12 +102,14 @@ func (r *BrandPsqlRepository) GetBodyTypeByID(ctx context.Context, id int64) (mo
 
 	query := `
 		SELECT
-			id, name_tm, name_en, name_ru, image_path, category
-		FROM body_types
-		WHERE id = $1
+			bt.id, bt.name_tm, bt.name_en, bt.name_ru, bt.image_path, COALESCE(bt.category_id, 0), COALESCE(c.slug, '')
+		FROM body_types bt
+			LEFT JOIN categories c ON c.id = bt.category_id
+		WHERE bt.id = $1
 		`
 
-	err := r.client.QueryRow(ctx, query, id).Scan(&bodyType.ID, &bodyType.NameTM, &bodyType.NameEN, &bodyType.NameRU, &bodyType.ImagePath, &bodyType.Category)
+	err := r.client.QueryRow(ctx, query, id).Scan(&bodyType.ID, &bodyType.NameTM, &bodyType.NameEN, &bodyType.NameRU, &bodyType.ImagePath,
+		&bodyType.CategoryID, &bodyType.Category)
 	if err != nil {
 		r.logger.Errorf("get body type by id query err : %v", err)
 		return bodyType, err
@@ -99,28 +120,56 @@ func (r *BrandPsqlRepository) GetBodyTypeByID(ctx context.Context, id int64) (mo
 func (r *BrandPsqlRepository) UpdateBodyType(ctx context.Context, bodyType models.BodyType) (int64, error) {
 	var bodyTypeID int64
 
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	names, err := mergeTranslations(ctx, tx, entityBodyTypes, bodyType.ID, fieldName, bodyType.Names)
+	if err != nil {
+		r.logger.Errorf("save body type translations err: %v", err)
+		return 0, err
+	}
+	bodyType.NameTM, bodyType.NameEN, bodyType.NameRU = names["tm"], names["en"], names["ru"]
+
 	query := `
 		UPDATE body_types SET 
-		    name_tm = $1, name_en = $2, name_ru = $3, image_path = $4, category = $5, updated_at = NOW()
+		    name_tm = $1, name_en = $2, name_ru = $3, image_path = $4, category_id = $5, updated_at = NOW()
 		WHERE id = $6
 		RETURNING id
 	`
-	err := r.client.QueryRow(ctx, query, bodyType.NameTM, bodyType.NameEN, bodyType.NameRU, bodyType.ImagePath, bodyType.Category, bodyType.ID).Scan(&bodyTypeID)
+	err = tx.QueryRow(ctx, query, bodyType.NameTM, bodyType.NameEN, bodyType.NameRU, bodyType.ImagePath, bodyType.CategoryID, bodyType.ID).Scan(&bodyTypeID)
 	if err != nil {
 		r.logger.Errorf("update body types err: %v", err)
 		return bodyTypeID, err
 	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
 	return bodyTypeID, nil
 }
 
 func (r *BrandPsqlRepository) DeleteBodyType(ctx context.Context, id models.ID) error {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
 	query := `DELETE FROM body_types WHERE id = $1`
-	_, err := r.client.Exec(ctx, query, id.ID)
+	_, err = tx.Exec(ctx, query, id.ID)
 	if err != nil {
 		r.logger.Errorf("delete body types err: %v", err)
 		return err
 	}
-	return nil
+
+	if err = deleteTranslations(ctx, tx, entityBodyTypes, id.ID); err != nil {
+		r.logger.Errorf("delete body type translations err: %v", err)
+		return err
+	}
+	return tx.Commit(ctx)
 }
 
 func (r *BrandPsqlRepository) CreateBrand(ctx context.Context, brand models.Brand) (int64, error) {
@@ -140,10 +189,10 @@ func (r *BrandPsqlRepository) CreateBrand(ctx context.Context, brand models.Bran
 		return brandID, err
 	}
 
-	for _, category := range brand.Categories {
+	for _, categoryID := range brand.CategoryIDs {
 		_, err = tx.Exec(ctx,
-			`INSERT INTO brand_categories (brand_id, category) VALUES ($1, $2)`,
-			brandID, category,
+			`INSERT INTO brand_categories (brand_id, category_id) VALUES ($1, $2)`,
+			brandID, categoryID,
 		)
 		if err != nil {
 			r.logger.Errorf("create brand_categorys err: %v", err)
@@ -168,10 +217,11 @@ func (r *BrandPsqlRepository) GetBrands(ctx context.Context, limit, page int64,
 	query := `
 		SELECT 
 		    b.id, b.name, b.logo_path,
-		    ARRAY_AGG(bc.category) AS categories
+		    ARRAY_AGG(c.slug) AS categories
 		FROM brands b
 		LEFT JOIN brand_categories bc ON bc.brand_id = b.id
-		WHERE  bc.category = $1 AND
+		LEFT JOIN categories c ON c.id = bc.category_id
+		WHERE  c.slug = $1 AND
 			b.name ILIKE '%' || $2 || '%'
 		GROUP BY b.id
 		ORDER BY b.created_at DESC
@@ -198,7 +248,8 @@ func (r *BrandPsqlRepository) GetBrands(ctx context.Context, limit, page int64,
 			    COUNT(b.id) 
 			FROM brands b
 			LEFT JOIN brand_categories bc ON bc.brand_id = b.id
-			WHERE  bc.category = $1 AND
+			LEFT JOIN categories c ON c.id = bc.category_id
+			WHERE  c.slug = $1 AND
 				b.name ILIKE '%' || $2 || '%'
 		`
 	errCount := r.client.QueryRow(ctx, queryCount, categoryType, search).Scan(&count)
@@ -236,10 +287,10 @@ func (r *BrandPsqlRepository) UpdateBrand(ctx context.Context, brand models.Bran
 		return 0, err
 	}
 
-	for _, category := range brand.Categories {
+	for _, categoryID := range brand.CategoryIDs {
 		_, err = tx.Exec(ctx,
-			`INSERT INTO brand_categories (brand_id, category) VALUES ($1, $2)`,
-			brand.ID, category,
+			`INSERT INTO brand_categories (brand_id, category_id) VALUES ($1, $2)`,
+			brand.ID, categoryID,
 		)
 		if err != nil {
 			r.logger.Errorf("update brand_categorys err: %v", err)
@@ -259,11 +310,12 @@ func (r *BrandPsqlRepository) GetBrandByID(ctx context.Context, id int64) (model
 
 	query := `
 		SELECT
-			id, name, logo_path
+			id, name, logo_path,
+			COALESCE((SELECT array_agg(category_id) FROM brand_categories WHERE brand_id = brands.id), '{}')
 		FROM brands
 		WHERE id = $1
 	`
-	err := r.client.QueryRow(ctx, query, id).Scan(&brand.ID, &brand.Name, &brand.LogoPath)
+	err := r.client.QueryRow(ctx, query, id).Scan(&brand.ID, &brand.Name, &brand.LogoPath, &brand.CategoryIDs)
 	if err != nil {
 		r.logger.Errorf("get brand by id query err : %v", err)
 		return brand, err
@@ -272,7 +324,10 @@ func (r *BrandPsqlRepository) GetBrandByID(ctx context.Context, id int64) (model
 }
 
 func (r *BrandPsqlRepository) DeleteBrandCategory(ctx context.Context, id models.ID) error {
-	query := `DELETE FROM brand_categories WHERE brand_id = $1 AND category = $2`
+	query := `
+		DELETE FROM brand_categories
+		WHERE brand_id = $1 AND category_id = (SELECT id FROM categories WHERE slug = $2)
+	`
 	_, err := r.client.Exec(ctx, query, id.ID, id.Category)
 	if err != nil {
 		r.logger.Errorf("delete brand category err: %v", err)
@@ -284,9 +339,9 @@ func (r *BrandPsqlRepository) DeleteBrandCategory(ctx context.Context, id models
 func (r *BrandPsqlRepository) CreateModel(ctx context.Context, model models.Model) (int64, error) {
 	var id int64
 
-	query := ` INSERT INTO models (name, brand_id, category) VALUES ($1, $2, $3) RETURNING id `
+	query := ` INSERT INTO models (name, brand_id, category_id) VALUES ($1, $2, $3) RETURNING id `
 
-	err := r.client.QueryRow(ctx, query, model.Name, model.BrandID, model.Category).Scan(&id)
+	err := r.client.QueryRow(ctx, query, model.Name, model.BrandID, model.CategoryID).Scan(&id)
 	if err != nil {
 		r.logger.Errorf("create model err : %v", err)
 		return id, err
@@ -303,10 +358,11 @@ func (r *BrandPsqlRepository) GetModels(ctx context.Context, limit, page int64,
 	query := `
 		SELECT 
 		    m.id, m.name, b.logo_path, m.brand_id, b.name,
-		    m.category
+		    m.category_id, c.slug
 		FROM models m
 			LEFT JOIN brands b ON m.brand_id = b.id
-		WHERE  m.category = $1 AND 
+			JOIN categories c ON c.id = m.category_id
+		WHERE  c.slug = $1 AND 
 		    ( m.name ILIKE '%' || $2 || '%' OR b.name ILIKE '%' || $2 || '%' )
 		ORDER BY m.created_at DESC
 		LIMIT $3 OFFSET $4;
@@ -321,7 +377,7 @@ func (r *BrandPsqlRepository) GetModels(ctx context.Context, limit, page int64,
 	for rows.Next() {
 		var brandModel models.Model
 		if err = rows.Scan(&brandModel.ID, &brandModel.Name, &brandModel.LogoPath,
-			&brandModel.BrandID, &brandModel.BrandName, &brandModel.Category,
+			&brandModel.BrandID, &brandModel.BrandName, &brandModel.CategoryID, &brandModel.Category,
 		); err != nil {
 			r.logger.Errorf("get models scan err : %v", err)
 			return nil, 0, err
@@ -334,7 +390,8 @@ func (r *BrandPsqlRepository) GetModels(ctx context.Context, limit, page int64,
 			COUNT(m.id) 
 		FROM models m
 			LEFT JOIN brands b ON m.brand_id = b.id
-		WHERE  m.category = $1 AND 
+			JOIN categories c ON c.id = m.category_id
+		WHERE  c.slug = $1 AND 
 		    ( m.name ILIKE '%' || $2 || '%' OR b.name ILIKE '%' || $2 || '%' )
 		`
 	errCount := r.client.QueryRow(ctx, queryCount, category, search).Scan(&count)
@@ -350,11 +407,11 @@ func (r *BrandPsqlRepository) UpdateModel(ctx context.Context, model models.Mode
 
 	query := `
 		UPDATE models SET 
-		    name = $1, brand_id = $2, category = $3, updated_at = NOW()
+		    name = $1, brand_id = $2, category_id = $3, updated_at = NOW()
 		WHERE id = $4
 		RETURNING id;
 	`
-	err := r.client.QueryRow(ctx, query, model.Name, model.BrandID, model.Category, model.ID).Scan(&id)
+	err := r.client.QueryRow(ctx, query, model.Name, model.BrandID, model.CategoryID, model.ID).Scan(&id)
 	if err != nil {
 		r.logger.Errorf("update brand model err: %v", err)
 		return id, err
@@ -362,12 +419,58 @@ func (r *BrandPsqlRepository) UpdateModel(ctx context.Context, model models.Mode
 	return id, nil
 }
 
+func (r *BrandPsqlRepository) GetModelByID(ctx context.Context, id int64) (models.Model, error) {
+	var model models.Model
+
+	query := `
+		SELECT
+			m.id, m.name, m.brand_id, COALESCE(m.category_id, 0), COALESCE(c.slug, '')
+		FROM models m
+			LEFT JOIN categories c ON c.id = m.category_id
+		WHERE m.id = $1
+	`
+	err := r.client.QueryRow(ctx, query, id).Scan(&model.ID, &model.Name, &model.BrandID, &model.CategoryID, &model.Category)
+	if err != nil {
+		r.logger.Errorf("get model by id query err : %v", err)
+		return model, err
+	}
+	return model, nil
+}
+
 func (r *BrandPsqlRepository) DeleteModel(ctx context.Context, id models.ID) error {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
 	query := `DELETE FROM models WHERE id = $1`
-	_, err := r.client.Exec(ctx, query, id.ID)
+	_, err = tx.Exec(ctx, query, id.ID)
 	if err != nil {
 		r.logger.Errorf("delete model err: %v", err)
 		return err
 	}
-	return nil
+
+	if err = clearSliderTargets(ctx, tx, models.SliderTargetModel, id.ID); err != nil {
+		r.logger.Errorf("clear model slider targets err: %v", err)
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// GetCategoryIDBySlug returns the id of the category with the given slug and
+// whether the category is active.
+func (r *BrandPsqlRepository) GetCategoryIDBySlug(ctx context.Context, slug string) (int64, bool, error) {
+	var (
+		id       int64
+		isActive bool
+	)
+
+	query := `SELECT id, is_active FROM categories WHERE slug = $1`
+	err := r.client.QueryRow(ctx, query, slug).Scan(&id, &isActive)
+	if err != nil {
+		r.logger.Errorf("get category id by slug err: %v", err)
+		return id, false, err
+	}
+	return id, isActive, nil
 }
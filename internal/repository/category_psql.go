@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"autotm-admin/internal/models"
+	"context"
+	slog "github.com/salamsites/package-log"
+	spsql "github.com/salamsites/package-psql"
+)
+
+type CategoryPsqlRepository struct {
+	logger *slog.Logger
+	client spsql.Client
+}
+
+func NewCategoryPsqlRepository(logger *slog.Logger, client spsql.Client) *CategoryPsqlRepository {
+	return &CategoryPsqlRepository{
+		logger: logger,
+		client: client,
+	}
+}
+
+func (r *CategoryPsqlRepository) CreateCategory(ctx context.Context, category models.Category) (int64, error) {
+	var id int64
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO categories (slug, name_tm, name_en, name_ru, icon_path, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+
+	err = tx.QueryRow(ctx, query, category.Slug, category.NameTM, category.NameEN, category.NameRU,
+		category.IconPath, category.IsActive).Scan(&id)
+	if err != nil {
+		r.logger.Errorf("create category err: %v", err)
+		return id, err
+	}
+
+	if err = saveTranslations(ctx, tx, entityCategories, id, fieldName, category.Names); err != nil {
+		r.logger.Errorf("save category translations err: %v", err)
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *CategoryPsqlRepository) GetCategories(ctx context.Context, limit, page int64, search string, activeOnly bool) ([]models.Category, int64, error) {
+	var (
+		categories []models.Category
+		count      int64
+	)
+
+	query := `
+		SELECT
+		    id, slug, name_tm, name_en, name_ru, COALESCE(icon_path, ''), is_active,
+		    ` + translationsColumn(entityCategories, "categories.id", fieldName) + `
+		FROM categories
+		WHERE (slug ILIKE '%' || $1 || '%' OR name_tm ILIKE '%' || $1 || '%' OR name_en ILIKE '%' || $1 || '%' OR name_ru ILIKE '%' || $1 || '%')
+			AND ($2 = FALSE OR is_active = TRUE)
+		ORDER BY id
+		LIMIT $3 OFFSET $4;
+	`
+
+	rows, err := r.client.Query(ctx, query, search, activeOnly, limit, page)
+	if err != nil {
+		r.logger.Errorf("get categories query err : %v", err)
+		return nil, 0, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var category models.Category
+		if err = rows.Scan(&category.ID, &category.Slug, &category.NameTM, &category.NameEN, &category.NameRU,
+			&category.IconPath, &category.IsActive, &category.Names); err != nil {
+			r.logger.Errorf("get categories scan err : %v", err)
+			return nil, 0, err
+		}
+		categories = append(categories, category)
+	}
+
+	queryCount := `
+		SELECT
+		    COUNT(*)
+		FROM categories
+		WHERE (slug ILIKE '%' || $1 || '%' OR name_tm ILIKE '%' || $1 || '%' OR name_en ILIKE '%' || $1 || '%' OR name_ru ILIKE '%' || $1 || '%')
+			AND ($2 = FALSE OR is_active = TRUE)
+	`
+	err = r.client.QueryRow(ctx, queryCount, search, activeOnly).Scan(&count)
+	if err != nil {
+		r.logger.Errorf("get categories count err : %v", err)
+		return nil, 0, err
+	}
+	return categories, count, nil
+}
+
+func (r *CategoryPsqlRepository) GetCategoryByID(ctx context.Context, id int64) (models.Category, error) {
+	var category models.Category
+
+	query := `
+		SELECT
+			id, slug, name_tm, name_en, name_ru, COALESCE(icon_path, ''), is_active
+		FROM categories
+		WHERE id = $1
+	`
+	err := r.client.QueryRow(ctx, query, id).Scan(&category.ID, &category.Slug, &category.NameTM, &category.NameEN,
+		&category.NameRU, &category.IconPath, &category.IsActive)
+	if err != nil {
+		r.logger.Errorf("get category by id query err : %v", err)
+		return category, err
+	}
+	return category, nil
+}
+
+func (r *CategoryPsqlRepository) UpdateCategory(ctx context.Context, category models.Category) (int64, error) {
+	var id int64
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	names, err := mergeTranslations(ctx, tx, entityCategories, category.ID, fieldName, category.Names)
+	if err != nil {
+		r.logger.Errorf("save category translations err: %v", err)
+		return 0, err
+	}
+	category.NameTM, category.NameEN, category.NameRU = names["tm"], names["en"], names["ru"]
+
+	query := `
+		UPDATE categories SET
+		    slug = $1, name_tm = $2, name_en = $3, name_ru = $4, icon_path = $5, is_active = $6, updated_at = NOW()
+		WHERE id = $7
+		RETURNING id
+	`
+	err = tx.QueryRow(ctx, query, category.Slug, category.NameTM, category.NameEN, category.NameRU,
+		category.IconPath, category.IsActive, category.ID).Scan(&id)
+	if err != nil {
+		r.logger.Errorf("update category err: %v", err)
+		return id, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *CategoryPsqlRepository) DeleteCategory(ctx context.Context, id models.ID) error {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `DELETE FROM categories WHERE id = $1`
+	_, err = tx.Exec(ctx, query, id.ID)
+	if err != nil {
+		r.logger.Errorf("delete category err: %v", err)
+		return err
+	}
+
+	if err = deleteTranslations(ctx, tx, entityCategories, id.ID); err != nil {
+		r.logger.Errorf("delete category translations err: %v", err)
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"autotm-admin/internal/helpers"
+	"autotm-admin/internal/models"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+)
+
+// MergeBrands moves every model, category and reference of the source brand to
+// the target brand, deletes the source and records the merge. Source models that
+// duplicate a target model (same name and category) are merged into it. It
+// returns the re-pointed rows per table and, separately, the merged rows.
+func (r *BrandPsqlRepository) MergeBrands(ctx context.Context, sourceID, targetID int64) (map[string]int64, map[string]int64, error) {
+	repointed := make(map[string]int64)
+	merged := make(map[string]int64)
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	sourceName, targetName, err := lockMergeRows(ctx, tx, "brands", "brand", sourceID, targetID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT s.id, t.id
+		FROM models s
+			JOIN models t ON t.brand_id = $2 AND t.category_id = s.category_id AND lower(t.name) = lower(s.name)
+		WHERE s.brand_id = $1
+	`, sourceID, targetID)
+	if err != nil {
+		r.logger.Errorf("merge brands duplicate models query err: %v", err)
+		return nil, nil, err
+	}
+	var duplicates [][2]int64
+	for rows.Next() {
+		var pair [2]int64
+		if err = rows.Scan(&pair[0], &pair[1]); err != nil {
+			rows.Close()
+			r.logger.Errorf("merge brands duplicate models scan err: %v", err)
+			return nil, nil, err
+		}
+		duplicates = append(duplicates, pair)
+	}
+	rows.Close()
+
+	for _, pair := range duplicates {
+		n, err := repointSliderTargets(ctx, tx, models.SliderTargetModel, pair[0], pair[1])
+		if err != nil {
+			r.logger.Errorf("merge brands repoint duplicate model sliders err: %v", err)
+			return nil, nil, err
+		}
+		repointed["sliders"] += n
+
+		if _, err = tx.Exec(ctx, `DELETE FROM models WHERE id = $1`, pair[0]); err != nil {
+			r.logger.Errorf("merge brands delete duplicate model err: %v", err)
+			return nil, nil, err
+		}
+		merged["models"]++
+	}
+
+	tag, err := tx.Exec(ctx, `UPDATE models SET brand_id = $2, updated_at = NOW() WHERE brand_id = $1`, sourceID, targetID)
+	if err != nil {
+		r.logger.Errorf("merge brands move models err: %v", err)
+		return nil, nil, err
+	}
+	repointed["models"] += tag.RowsAffected()
+
+	tag, err = tx.Exec(ctx, `
+		INSERT INTO brand_categories (brand_id, category_id)
+		SELECT $2, category_id FROM brand_categories WHERE brand_id = $1
+		ON CONFLICT DO NOTHING
+	`, sourceID, targetID)
+	if err != nil {
+		r.logger.Errorf("merge brands copy categories err: %v", err)
+		return nil, nil, err
+	}
+	repointed["brand_categories"] += tag.RowsAffected()
+
+	tag, err = tx.Exec(ctx, `UPDATE wmi_codes SET brand_id = $2, updated_at = NOW() WHERE brand_id = $1`, sourceID, targetID)
+	if err != nil {
+		r.logger.Errorf("merge brands move wmi codes err: %v", err)
+		return nil, nil, err
+	}
+	repointed["wmi_codes"] += tag.RowsAffected()
+
+	tag, err = tx.Exec(ctx, `
+		UPDATE auto_store_brands s SET brand_id = $2
+		WHERE s.brand_id = $1 AND NOT EXISTS (
+			SELECT 1 FROM auto_store_brands t
+			WHERE t.auto_store_id = s.auto_store_id AND t.brand_id = $2
+				AND COALESCE(t.category_id, 0) = COALESCE(s.category_id, 0) AND t.role = s.role
+		)
+	`, sourceID, targetID)
+	if err != nil {
+		r.logger.Errorf("merge brands move auto store brands err: %v", err)
+		return nil, nil, err
+	}
+	repointed["auto_store_brands"] += tag.RowsAffected()
+
+	n, err := repointSliderTargets(ctx, tx, models.SliderTargetBrand, sourceID, targetID)
+	if err != nil {
+		r.logger.Errorf("merge brands repoint sliders err: %v", err)
+		return nil, nil, err
+	}
+	repointed["sliders"] += n
+
+	if _, err = tx.Exec(ctx, `DELETE FROM brands WHERE id = $1`, sourceID); err != nil {
+		r.logger.Errorf("merge brands delete source err: %v", err)
+		return nil, nil, err
+	}
+
+	if err = logMerge(ctx, tx, "brands", sourceID, sourceName, targetID, targetName, repointed, merged); err != nil {
+		r.logger.Errorf("merge brands log err: %v", err)
+		return nil, nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, nil, err
+	}
+	return repointed, merged, nil
+}
+
+// MergeModels moves every reference of the source model to the target model,
+// deletes the source and records the merge. Nothing is merged besides the
+// source itself, so the merged counts are empty.
+func (r *BrandPsqlRepository) MergeModels(ctx context.Context, sourceID, targetID int64) (map[string]int64, map[string]int64, error) {
+	repointed := make(map[string]int64)
+	merged := make(map[string]int64)
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	sourceName, targetName, err := lockMergeRows(ctx, tx, "models", "model", sourceID, targetID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	n, err := repointSliderTargets(ctx, tx, models.SliderTargetModel, sourceID, targetID)
+	if err != nil {
+		r.logger.Errorf("merge models repoint sliders err: %v", err)
+		return nil, nil, err
+	}
+	repointed["sliders"] += n
+
+	if _, err = tx.Exec(ctx, `DELETE FROM models WHERE id = $1`, sourceID); err != nil {
+		r.logger.Errorf("merge models delete source err: %v", err)
+		return nil, nil, err
+	}
+
+	if err = logMerge(ctx, tx, "models", sourceID, sourceName, targetID, targetName, repointed, merged); err != nil {
+		r.logger.Errorf("merge models log err: %v", err)
+		return nil, nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, nil, err
+	}
+	return repointed, merged, nil
+}
+
+// lockMergeRows locks the source and target rows of a merge and returns their
+// names. Both rows are locked in id order, so opposite merges of the same pair
+// wait for each other instead of deadlocking. table must be a constant.
+func lockMergeRows(ctx context.Context, tx pgx.Tx, table, entity string, sourceID, targetID int64) (string, string, error) {
+	query := fmt.Sprintf(`SELECT id, name FROM %s WHERE id IN ($1, $2) ORDER BY id FOR UPDATE`, table)
+
+	rows, err := tx.Query(ctx, query, sourceID, targetID)
+	if err != nil {
+		return "", "", err
+	}
+	defer rows.Close()
+
+	names := make(map[int64]string, 2)
+	for rows.Next() {
+		var id int64
+		var name string
+		if err = rows.Scan(&id, &name); err != nil {
+			return "", "", err
+		}
+		names[id] = name
+	}
+	if err = rows.Err(); err != nil {
+		return "", "", err
+	}
+
+	sourceName, ok := names[sourceID]
+	if !ok {
+		return "", "", fmt.Errorf("%w: source %s %d not found", helpers.ErrInvalidReference, entity, sourceID)
+	}
+	targetName, ok := names[targetID]
+	if !ok {
+		return "", "", fmt.Errorf("%w: target %s %d not found", helpers.ErrInvalidReference, entity, targetID)
+	}
+	return sourceName, targetName, nil
+}
+
+func logMerge(ctx context.Context, tx pgx.Tx, entity string, sourceID int64, sourceName string,
+	targetID int64, targetName string, repointed, merged map[string]int64) error {
+	repointedData, err := json.Marshal(repointed)
+	if err != nil {
+		return err
+	}
+	mergedData, err := json.Marshal(merged)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO merge_logs (entity_type, source_id, source_name, target_id, target_name, repointed, merged)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, entity, sourceID, sourceName, targetID, targetName, repointedData, mergedData)
+	return err
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"autotm-admin/internal/helpers"
+	"autotm-admin/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+)
+
+func (r *SliderPsqlRepository) CreatePlatform(ctx context.Context, platform models.Platform) (int64, error) {
+	var id int64
+
+	query := `
+		INSERT INTO platforms (code, name, aspect_width, aspect_height, min_width, min_height, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+	err := r.client.QueryRow(ctx, query, platform.Code, platform.Name, platform.AspectWidth, platform.AspectHeight,
+		platform.MinWidth, platform.MinHeight, platform.IsActive).Scan(&id)
+	if err != nil {
+		r.logger.Errorf("create platform err: %v", err)
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *SliderPsqlRepository) GetPlatforms(ctx context.Context) ([]models.Platform, error) {
+	var platforms []models.Platform
+
+	query := `
+		SELECT
+		    id, code, name, aspect_width, aspect_height, min_width, min_height, is_active
+		FROM platforms
+		ORDER BY code
+	`
+	rows, err := r.client.Query(ctx, query)
+	if err != nil {
+		r.logger.Errorf("get platforms query err : %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var platform models.Platform
+		if err = rows.Scan(&platform.ID, &platform.Code, &platform.Name, &platform.AspectWidth, &platform.AspectHeight,
+			&platform.MinWidth, &platform.MinHeight, &platform.IsActive); err != nil {
+			r.logger.Errorf("get platforms scan err : %v", err)
+			return nil, err
+		}
+		platforms = append(platforms, platform)
+	}
+	return platforms, rows.Err()
+}
+
+// GetPlatformByCode returns the platform with the given code, or an
+// ErrInvalidReference error when there is none.
+func (r *SliderPsqlRepository) GetPlatformByCode(ctx context.Context, code string) (models.Platform, error) {
+	var platform models.Platform
+
+	query := `
+		SELECT
+		    id, code, name, aspect_width, aspect_height, min_width, min_height, is_active
+		FROM platforms
+		WHERE code = $1
+	`
+	err := r.client.QueryRow(ctx, query, code).Scan(&platform.ID, &platform.Code, &platform.Name, &platform.AspectWidth,
+		&platform.AspectHeight, &platform.MinWidth, &platform.MinHeight, &platform.IsActive)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return platform, fmt.Errorf("%w: platform %q not found", helpers.ErrInvalidReference, code)
+		}
+		r.logger.Errorf("get platform by code err: %v", err)
+		return platform, err
+	}
+	return platform, nil
+}
+
+// UpdatePlatform saves a platform. A new code reaches sliders through their
+// foreign key and is applied to the slider stats here, so the stats recorded
+// under the old code stay visible under the new one.
+func (r *SliderPsqlRepository) UpdatePlatform(ctx context.Context, platform models.Platform) (int64, error) {
+	var id int64
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var oldCode string
+	err = tx.QueryRow(ctx, `SELECT code FROM platforms WHERE id = $1 FOR UPDATE`, platform.ID).Scan(&oldCode)
+	if err != nil {
+		r.logger.Errorf("lock platform err: %v", err)
+		return 0, err
+	}
+
+	query := `
+		UPDATE platforms SET
+		    code = $1, name = $2, aspect_width = $3, aspect_height = $4, min_width = $5, min_height = $6,
+		    is_active = $7, updated_at = NOW()
+		WHERE id = $8
+		RETURNING id
+	`
+	err = tx.QueryRow(ctx, query, platform.Code, platform.Name, platform.AspectWidth, platform.AspectHeight,
+		platform.MinWidth, platform.MinHeight, platform.IsActive, platform.ID).Scan(&id)
+	if err != nil {
+		r.logger.Errorf("update platform err: %v", err)
+		return 0, err
+	}
+
+	if oldCode != platform.Code {
+		_, err = tx.Exec(ctx, `
+			WITH moved AS (
+			    DELETE FROM slider_stats WHERE platform = $1 RETURNING *
+			)
+			INSERT INTO slider_stats (slider_id, variant_id, platform, day, impressions, clicks)
+			SELECT slider_id, variant_id, $2, day, impressions, clicks FROM moved
+			ON CONFLICT (slider_id, variant_id, platform, day)
+			DO UPDATE SET impressions = slider_stats.impressions + EXCLUDED.impressions,
+			              clicks = slider_stats.clicks + EXCLUDED.clicks
+		`, oldCode, platform.Code)
+		if err != nil {
+			r.logger.Errorf("rename platform slider stats err: %v", err)
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// DeletePlatform refuses with a ReferencedError while sliders still use the platform.
+func (r *SliderPsqlRepository) DeletePlatform(ctx context.Context, id models.ID) error {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var sliders int64
+	err = tx.QueryRow(ctx, `
+		SELECT COUNT(*) FROM sliders WHERE platform = (SELECT code FROM platforms WHERE id = $1)
+	`, id.ID).Scan(&sliders)
+	if err != nil {
+		r.logger.Errorf("count platform references err: %v", err)
+		return err
+	}
+	if err = referencedError("platform", id.ID, map[string]int64{"sliders": sliders}); err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, `DELETE FROM platforms WHERE id = $1`, id.ID); err != nil {
+		r.logger.Errorf("delete platform err: %v", err)
+		return err
+	}
+	return tx.Commit(ctx)
+}
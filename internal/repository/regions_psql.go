@@ -1,8 +1,12 @@
 package repository
 
 import (
+	"autotm-admin/internal/helpers"
 	"autotm-admin/internal/models"
 	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
 	slog "github.com/salamsites/package-log"
 	spsql "github.com/salamsites/package-psql"
 )
@@ -22,13 +26,28 @@ func NewRegionsPsqlRepository(logger *slog.Logger, client spsql.Client) *Regions
 func (r *RegionsPsqlRepository) CreateRegion(ctx context.Context, region models.Region) (int64, error) {
 	var id int64
 
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
 	query := `INSERT INTO regions (name_tm, name_en, name_ru) VALUES ($1, $2, $3) RETURNING id`
 
-	err := r.client.QueryRow(ctx, query, region.NameTM, region.NameEN, region.NameRU).Scan(&id)
+	err = tx.QueryRow(ctx, query, region.NameTM, region.NameEN, region.NameRU).Scan(&id)
 	if err != nil {
 		r.logger.Errorf("create region err: %v", err)
 		return id, err
 	}
+
+	if err = saveTranslations(ctx, tx, entityRegions, id, fieldName, region.Names); err != nil {
+		r.logger.Errorf("save region translations err: %v", err)
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
 	return id, nil
 }
 
@@ -40,7 +59,8 @@ func (r *RegionsPsqlRepository) GetAllRegions(ctx context.Context, limit, page i
 
 	query := `
 		SELECT 
-		    id, name_tm, name_en, name_ru 
+		    id, name_tm, name_en, name_ru,
+		    ` + translationsColumn(entityRegions, "regions.id", fieldName) + `
 		FROM regions
 		WHERE (name_tm ILIKE '%' || $1 || '%' OR name_ru ILIKE '%' || $1 || '%' OR name_en ILIKE '%' || $1 || '%')
 		ORDER BY created_at DESC
@@ -55,7 +75,7 @@ func (r *RegionsPsqlRepository) GetAllRegions(ctx context.Context, limit, page i
 	defer rows.Close()
 	for rows.Next() {
 		var region models.Region
-		if err := rows.Scan(&region.ID, &region.NameTM, &region.NameEN, &region.NameRU); err != nil {
+		if err := rows.Scan(&region.ID, &region.NameTM, &region.NameEN, &region.NameRU, &region.Names); err != nil {
 			r.logger.Errorf("get all regions scan err : %v", err)
 			return nil, 0, err
 		}
@@ -79,45 +99,129 @@ func (r *RegionsPsqlRepository) GetAllRegions(ctx context.Context, limit, page i
 func (r *RegionsPsqlRepository) UpdateRegion(ctx context.Context, region models.Region) (int64, error) {
 	var id int64
 
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	names, err := mergeTranslations(ctx, tx, entityRegions, region.ID, fieldName, region.Names)
+	if err != nil {
+		r.logger.Errorf("save region translations err: %v", err)
+		return 0, err
+	}
+	region.NameTM, region.NameEN, region.NameRU = names["tm"], names["en"], names["ru"]
+
 	query := `
 		UPDATE regions SET 
 		    name_tm = $1, name_ru = $2, name_en = $3, updated_at = NOW()
 		WHERE id = $4
 		RETURNING id
 	`
-	err := r.client.QueryRow(ctx, query, region.NameTM, region.NameRU, region.NameEN, region.ID).Scan(&id)
+	err = tx.QueryRow(ctx, query, region.NameTM, region.NameRU, region.NameEN, region.ID).Scan(&id)
 	if err != nil {
 		r.logger.Errorf("update region err: %v", err)
 		return id, err
 	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
 	return id, nil
 }
 
-func (r *RegionsPsqlRepository) DeleteRegion(ctx context.Context, id models.ID) error {
+// DeleteRegion deletes a region. When reassignTo is set, its cities and auto
+// stores are moved to that region first; otherwise the delete is refused with a
+// ReferencedError while any of them exist.
+func (r *RegionsPsqlRepository) DeleteRegion(ctx context.Context, id models.ID, reassignTo int64) error {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if reassignTo > 0 {
+		if reassignTo == id.ID {
+			return fmt.Errorf("%w: cannot reassign region %d to itself", helpers.ErrInvalidReference, id.ID)
+		}
+		var exists bool
+		if err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM regions WHERE id = $1)`, reassignTo).Scan(&exists); err != nil {
+			r.logger.Errorf("check reassign region err: %v", err)
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("%w: region %d not found", helpers.ErrInvalidReference, reassignTo)
+		}
+
+		if _, err = tx.Exec(ctx, `UPDATE cities SET region_id = $2, updated_at = NOW() WHERE region_id = $1`, id.ID, reassignTo); err != nil {
+			r.logger.Errorf("reassign region cities err: %v", err)
+			return err
+		}
+		if _, err = tx.Exec(ctx, `UPDATE auto_stores SET region_id = $2, updated_at = NOW() WHERE region_id = $1`, id.ID, reassignTo); err != nil {
+			r.logger.Errorf("reassign region auto stores err: %v", err)
+			return err
+		}
+	} else {
+		var cities, autoStores int64
+		err = tx.QueryRow(ctx, `
+			SELECT
+				(SELECT COUNT(*) FROM cities WHERE region_id = $1),
+				(SELECT COUNT(*) FROM auto_stores WHERE region_id = $1)
+		`, id.ID).Scan(&cities, &autoStores)
+		if err != nil {
+			r.logger.Errorf("count region references err: %v", err)
+			return err
+		}
+		references := map[string]int64{"cities": cities, "auto_stores": autoStores}
+		if err = referencedError("region", id.ID, references); err != nil {
+			return err
+		}
+	}
+
 	query := `DELETE FROM regions WHERE id = $1`
-	_, err := r.client.Exec(ctx, query, id.ID)
+	_, err = tx.Exec(ctx, query, id.ID)
 	if err != nil {
 		r.logger.Errorf("delete region err: %v", err)
 		return err
 	}
-	return nil
+
+	if err = deleteTranslations(ctx, tx, entityRegions, id.ID); err != nil {
+		r.logger.Errorf("delete region translations err: %v", err)
+		return err
+	}
+	return tx.Commit(ctx)
 }
 
 // Cities
 func (r *RegionsPsqlRepository) CreateCity(ctx context.Context, city models.City) (int64, error) {
 	var id int64
 
-	query := `INSERT INTO cities (name_tm, name_en, name_ru, region_id) VALUES ($1, $2, $3, $4) RETURNING id`
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `INSERT INTO cities (name_tm, name_en, name_ru, region_id, latitude, longitude) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
 
-	err := r.client.QueryRow(ctx, query, city.NameTM, city.NameEN, city.NameRU, city.RegionID).Scan(&id)
+	err = tx.QueryRow(ctx, query, city.NameTM, city.NameEN, city.NameRU, city.RegionID, city.Latitude, city.Longitude).Scan(&id)
 	if err != nil {
 		r.logger.Errorf("create city err: %v", err)
 		return id, err
 	}
+
+	if err = saveTranslations(ctx, tx, entityCities, id, fieldName, city.Names); err != nil {
+		r.logger.Errorf("save city translations err: %v", err)
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
 	return id, nil
 }
 
-func (r *RegionsPsqlRepository) GetAllCities(ctx context.Context, limit, page int64, search string) ([]models.City, int64, error) {
+func (r *RegionsPsqlRepository) GetAllCities(ctx context.Context, limit, page int64, regionID int64, search string) ([]models.City, int64, error) {
 	var (
 		cities []models.City
 		count  int64
@@ -126,16 +230,18 @@ func (r *RegionsPsqlRepository) GetAllCities(ctx context.Context, limit, page in
 	query := `
 		SELECT 
 		    c.id, c.name_tm, c.name_en, c.name_ru, c.region_id,
-		    r.name_tm, r.name_en, r.name_ru
+		    r.name_tm, r.name_en, r.name_ru, c.latitude, c.longitude,
+		    ` + translationsColumn(entityCities, "c.id", fieldName) + `
 		FROM cities c
 			LEFT JOIN regions r on r.id = c.region_id
 		WHERE (c.name_tm ILIKE '%' || $1 || '%' OR c.name_ru ILIKE '%' || $1 || '%' OR c.name_en ILIKE '%' || $1 || '%' 
 		    OR r.name_tm ILIKE '%' || $1 || '%' OR r.name_ru ILIKE '%' || $1 || '%' OR r.name_en ILIKE '%' || $1 || '%')
+			AND ($4 = 0 OR c.region_id = $4)
 		ORDER BY c.created_at DESC
 		LIMIT $2 OFFSET $3;
 	`
 
-	rows, err := r.client.Query(ctx, query, search, limit, page)
+	rows, err := r.client.Query(ctx, query, search, limit, page, regionID)
 	if err != nil {
 		r.logger.Errorf("get all cities query err : %v", err)
 		return nil, 0, err
@@ -144,7 +250,7 @@ func (r *RegionsPsqlRepository) GetAllCities(ctx context.Context, limit, page in
 	for rows.Next() {
 		var city models.City
 		err = rows.Scan(&city.ID, &city.NameTM, &city.NameEN, &city.NameRU, &city.RegionID,
-			&city.RegionNameTM, &city.RegionNameEN, &city.RegionNameRU,
+			&city.RegionNameTM, &city.RegionNameEN, &city.RegionNameRU, &city.Latitude, &city.Longitude, &city.Names,
 		)
 		if err != nil {
 			r.logger.Errorf("get all cities scan err : %v", err)
@@ -160,8 +266,9 @@ func (r *RegionsPsqlRepository) GetAllCities(ctx context.Context, limit, page in
 			LEFT JOIN regions r on r.id = c.region_id
 		WHERE (c.name_tm ILIKE '%' || $1 || '%' OR c.name_ru ILIKE '%' || $1 || '%' OR c.name_en ILIKE '%' || $1 || '%' 
 		    OR r.name_tm ILIKE '%' || $1 || '%' OR r.name_ru ILIKE '%' || $1 || '%' OR r.name_en ILIKE '%' || $1 || '%')
+			AND ($2 = 0 OR c.region_id = $2)
 		`
-	errCount := r.client.QueryRow(ctx, queryCount, search).Scan(&count)
+	errCount := r.client.QueryRow(ctx, queryCount, search, regionID).Scan(&count)
 	if errCount != nil {
 		r.logger.Errorf("get all cities count err : %v", err)
 		return nil, 0, err
@@ -169,29 +276,386 @@ func (r *RegionsPsqlRepository) GetAllCities(ctx context.Context, limit, page in
 	return cities, count, nil
 }
 
+// GetRegionTree returns every region with its cities nested.
+func (r *RegionsPsqlRepository) GetRegionTree(ctx context.Context) ([]models.Region, error) {
+	var regions []models.Region
+
+	query := `
+		SELECT
+		    id, name_tm, name_en, name_ru,
+		    ` + translationsColumn(entityRegions, "regions.id", fieldName) + `
+		FROM regions
+	`
+	rows, err := r.client.Query(ctx, query)
+	if err != nil {
+		r.logger.Errorf("get region tree regions query err : %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+	positions := make(map[int64]int)
+	for rows.Next() {
+		var region models.Region
+		if err = rows.Scan(&region.ID, &region.NameTM, &region.NameEN, &region.NameRU, &region.Names); err != nil {
+			r.logger.Errorf("get region tree regions scan err : %v", err)
+			return nil, err
+		}
+		positions[region.ID] = len(regions)
+		regions = append(regions, region)
+	}
+	rows.Close()
+
+	queryCities := `
+		SELECT
+		    id, name_tm, name_en, name_ru, region_id,
+		    ` + translationsColumn(entityCities, "cities.id", fieldName) + `
+		FROM cities
+		WHERE region_id IS NOT NULL
+	`
+	cityRows, err := r.client.Query(ctx, queryCities)
+	if err != nil {
+		r.logger.Errorf("get region tree cities query err : %v", err)
+		return nil, err
+	}
+	defer cityRows.Close()
+	for cityRows.Next() {
+		var city models.City
+		if err = cityRows.Scan(&city.ID, &city.NameTM, &city.NameEN, &city.NameRU, &city.RegionID, &city.Names); err != nil {
+			r.logger.Errorf("get region tree cities scan err : %v", err)
+			return nil, err
+		}
+		if i, ok := positions[city.RegionID]; ok {
+			regions[i].Cities = append(regions[i].Cities, city)
+		}
+	}
+	cityRows.Close()
+
+	queryDistricts := `
+		SELECT
+		    d.id, d.name_tm, d.name_en, d.name_ru, d.city_id, c.region_id,
+		    ` + translationsColumn(entityDistricts, "d.id", fieldName) + `
+		FROM districts d
+			JOIN cities c ON c.id = d.city_id
+		WHERE c.region_id IS NOT NULL
+	`
+	districtRows, err := r.client.Query(ctx, queryDistricts)
+	if err != nil {
+		r.logger.Errorf("get region tree districts query err : %v", err)
+		return nil, err
+	}
+	defer districtRows.Close()
+	for districtRows.Next() {
+		var (
+			district models.District
+			regionID int64
+		)
+		if err = districtRows.Scan(&district.ID, &district.NameTM, &district.NameEN, &district.NameRU, &district.CityID,
+			&regionID, &district.Names); err != nil {
+			r.logger.Errorf("get region tree districts scan err : %v", err)
+			return nil, err
+		}
+		i, ok := positions[regionID]
+		if !ok {
+			continue
+		}
+		for j := range regions[i].Cities {
+			if regions[i].Cities[j].ID == district.CityID {
+				regions[i].Cities[j].Districts = append(regions[i].Cities[j].Districts, district)
+				break
+			}
+		}
+	}
+	return regions, nil
+}
+
 func (r *RegionsPsqlRepository) UpdateCity(ctx context.Context, city models.City) (int64, error) {
 	var id int64
 
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	names, err := mergeTranslations(ctx, tx, entityCities, city.ID, fieldName, city.Names)
+	if err != nil {
+		r.logger.Errorf("save city translations err: %v", err)
+		return 0, err
+	}
+	city.NameTM, city.NameEN, city.NameRU = names["tm"], names["en"], names["ru"]
+
 	query := `
 		UPDATE cities SET 
-		    name_tm = $1, name_ru = $2, name_en = $3, region_id = $4, updated_at = NOW()
-		WHERE id = $5
+		    name_tm = $1, name_ru = $2, name_en = $3, region_id = $4, latitude = $5, longitude = $6, updated_at = NOW()
+		WHERE id = $7
 		RETURNING id
 	`
-	err := r.client.QueryRow(ctx, query, city.NameTM, city.NameRU, city.NameEN, city.RegionID, city.ID).Scan(&id)
+	err = tx.QueryRow(ctx, query, city.NameTM, city.NameRU, city.NameEN, city.RegionID, city.Latitude, city.Longitude, city.ID).Scan(&id)
 	if err != nil {
 		r.logger.Errorf("update city err: %v", err)
 		return id, err
 	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
 	return id, nil
 }
 
-func (r *RegionsPsqlRepository) DeleteCity(ctx context.Context, id models.ID) error {
+// DeleteCity deletes a city. When reassignTo is set, its districts and auto
+// stores are moved to that city (and its region) first; otherwise the delete is
+// refused with a ReferencedError while any of them exist.
+func (r *RegionsPsqlRepository) DeleteCity(ctx context.Context, id models.ID, reassignTo int64) error {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if reassignTo > 0 {
+		if reassignTo == id.ID {
+			return fmt.Errorf("%w: cannot reassign city %d to itself", helpers.ErrInvalidReference, id.ID)
+		}
+		var regionID *int64
+		err = tx.QueryRow(ctx, `SELECT region_id FROM cities WHERE id = $1`, reassignTo).Scan(&regionID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("%w: city %d not found", helpers.ErrInvalidReference, reassignTo)
+			}
+			r.logger.Errorf("check reassign city err: %v", err)
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE auto_stores SET city_id = $2, region_id = COALESCE($3, region_id), updated_at = NOW()
+			WHERE city_id = $1
+		`, id.ID, reassignTo, regionID)
+		if err != nil {
+			r.logger.Errorf("reassign city auto stores err: %v", err)
+			return err
+		}
+		if _, err = tx.Exec(ctx, `UPDATE districts SET city_id = $2, updated_at = NOW() WHERE city_id = $1`, id.ID, reassignTo); err != nil {
+			r.logger.Errorf("reassign city districts err: %v", err)
+			return err
+		}
+	} else {
+		var districts, autoStores int64
+		err = tx.QueryRow(ctx, `
+			SELECT
+				(SELECT COUNT(*) FROM districts WHERE city_id = $1),
+				(SELECT COUNT(*) FROM auto_stores WHERE city_id = $1)
+		`, id.ID).Scan(&districts, &autoStores)
+		if err != nil {
+			r.logger.Errorf("count city references err: %v", err)
+			return err
+		}
+		references := map[string]int64{"districts": districts, "auto_stores": autoStores}
+		if err = referencedError("city", id.ID, references); err != nil {
+			return err
+		}
+	}
+
 	query := `DELETE FROM cities WHERE id = $1`
-	_, err := r.client.Exec(ctx, query, id.ID)
+	_, err = tx.Exec(ctx, query, id.ID)
 	if err != nil {
 		r.logger.Errorf("delete cities err: %v", err)
 		return err
 	}
-	return nil
+
+	if err = deleteTranslations(ctx, tx, entityCities, id.ID); err != nil {
+		r.logger.Errorf("delete city translations err: %v", err)
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// Districts
+func (r *RegionsPsqlRepository) CreateDistrict(ctx context.Context, district models.District) (int64, error) {
+	var id int64
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `INSERT INTO districts (name_tm, name_en, name_ru, city_id) VALUES ($1, $2, $3, $4) RETURNING id`
+
+	err = tx.QueryRow(ctx, query, district.NameTM, district.NameEN, district.NameRU, district.CityID).Scan(&id)
+	if err != nil {
+		r.logger.Errorf("create district err: %v", err)
+		return id, err
+	}
+
+	if err = saveTranslations(ctx, tx, entityDistricts, id, fieldName, district.Names); err != nil {
+		r.logger.Errorf("save district translations err: %v", err)
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *RegionsPsqlRepository) GetAllDistricts(ctx context.Context, limit, page int64, cityID int64, search string) ([]models.District, int64, error) {
+	var (
+		districts []models.District
+		count     int64
+	)
+
+	query := `
+		SELECT 
+		    d.id, d.name_tm, d.name_en, d.name_ru, d.city_id,
+		    c.name_tm, c.name_en, c.name_ru,
+		    ` + translationsColumn(entityDistricts, "d.id", fieldName) + `
+		FROM districts d
+			LEFT JOIN cities c on c.id = d.city_id
+		WHERE (d.name_tm ILIKE '%' || $1 || '%' OR d.name_ru ILIKE '%' || $1 || '%' OR d.name_en ILIKE '%' || $1 || '%')
+			AND ($4 = 0 OR d.city_id = $4)
+		ORDER BY d.created_at DESC
+		LIMIT $2 OFFSET $3;
+	`
+
+	rows, err := r.client.Query(ctx, query, search, limit, page, cityID)
+	if err != nil {
+		r.logger.Errorf("get all districts query err : %v", err)
+		return nil, 0, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var district models.District
+		err = rows.Scan(&district.ID, &district.NameTM, &district.NameEN, &district.NameRU, &district.CityID,
+			&district.CityNameTM, &district.CityNameEN, &district.CityNameRU, &district.Names,
+		)
+		if err != nil {
+			r.logger.Errorf("get all districts scan err : %v", err)
+			return nil, 0, err
+		}
+		districts = append(districts, district)
+	}
+
+	queryCount := `
+		SELECT 
+		    COUNT(d.id) 
+		FROM districts d
+		WHERE (d.name_tm ILIKE '%' || $1 || '%' OR d.name_ru ILIKE '%' || $1 || '%' OR d.name_en ILIKE '%' || $1 || '%')
+			AND ($2 = 0 OR d.city_id = $2)
+	`
+	err = r.client.QueryRow(ctx, queryCount, search, cityID).Scan(&count)
+	if err != nil {
+		r.logger.Errorf("get all districts count err : %v", err)
+		return nil, 0, err
+	}
+	return districts, count, nil
+}
+
+func (r *RegionsPsqlRepository) UpdateDistrict(ctx context.Context, district models.District) (int64, error) {
+	var id int64
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	names, err := mergeTranslations(ctx, tx, entityDistricts, district.ID, fieldName, district.Names)
+	if err != nil {
+		r.logger.Errorf("save district translations err: %v", err)
+		return 0, err
+	}
+	district.NameTM, district.NameEN, district.NameRU = names["tm"], names["en"], names["ru"]
+
+	query := `
+		UPDATE districts SET 
+		    name_tm = $1, name_ru = $2, name_en = $3, city_id = $4, updated_at = NOW()
+		WHERE id = $5
+		RETURNING id
+	`
+	err = tx.QueryRow(ctx, query, district.NameTM, district.NameRU, district.NameEN, district.CityID, district.ID).Scan(&id)
+	if err != nil {
+		r.logger.Errorf("update district err: %v", err)
+		return id, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// DeleteDistrict deletes a district. When reassignTo is set, its auto stores are
+// moved to that district (and its city and region) first; otherwise the delete
+// is refused with a ReferencedError while any of them exist.
+func (r *RegionsPsqlRepository) DeleteDistrict(ctx context.Context, id models.ID, reassignTo int64) error {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if reassignTo > 0 {
+		if reassignTo == id.ID {
+			return fmt.Errorf("%w: cannot reassign district %d to itself", helpers.ErrInvalidReference, id.ID)
+		}
+		var (
+			cityID   int64
+			regionID *int64
+		)
+		err = tx.QueryRow(ctx, `
+			SELECT d.city_id, c.region_id FROM districts d JOIN cities c ON c.id = d.city_id WHERE d.id = $1
+		`, reassignTo).Scan(&cityID, &regionID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("%w: district %d not found", helpers.ErrInvalidReference, reassignTo)
+			}
+			r.logger.Errorf("check reassign district err: %v", err)
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE auto_stores SET district_id = $2, city_id = $3, region_id = COALESCE($4, region_id), updated_at = NOW()
+			WHERE district_id = $1
+		`, id.ID, reassignTo, cityID, regionID)
+		if err != nil {
+			r.logger.Errorf("reassign district auto stores err: %v", err)
+			return err
+		}
+	} else {
+		var autoStores int64
+		err = tx.QueryRow(ctx, `SELECT COUNT(*) FROM auto_stores WHERE district_id = $1`, id.ID).Scan(&autoStores)
+		if err != nil {
+			r.logger.Errorf("count district references err: %v", err)
+			return err
+		}
+		references := map[string]int64{"auto_stores": autoStores}
+		if err = referencedError("district", id.ID, references); err != nil {
+			return err
+		}
+	}
+
+	query := `DELETE FROM districts WHERE id = $1`
+	_, err = tx.Exec(ctx, query, id.ID)
+	if err != nil {
+		r.logger.Errorf("delete district err: %v", err)
+		return err
+	}
+
+	if err = deleteTranslations(ctx, tx, entityDistricts, id.ID); err != nil {
+		r.logger.Errorf("delete district translations err: %v", err)
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// referencedError returns a ReferencedError listing the non-zero counts, or nil when there are none.
+func referencedError(entity string, id int64, references map[string]int64) error {
+	for table, count := range references {
+		if count == 0 {
+			delete(references, table)
+		}
+	}
+	if len(references) == 0 {
+		return nil
+	}
+	return &helpers.ReferencedError{Entity: entity, ID: id, References: references}
 }
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"autotm-admin/internal/models"
+	"context"
+	"fmt"
+	slog "github.com/salamsites/package-log"
+	spsql "github.com/salamsites/package-psql"
+)
+
+// localizedTables lists the tables that carry name_tm/name_en/name_ru columns.
+// Entity names coming from the request are only ever used as keys of this map.
+var localizedTables = map[string]string{
+	"body_types": "body_types",
+	"categories": "categories",
+	"regions":    "regions",
+	"cities":     "cities",
+	"districts":  "districts",
+}
+
+type ReportPsqlRepository struct {
+	logger *slog.Logger
+	client spsql.Client
+}
+
+func NewReportPsqlRepository(logger *slog.Logger, client spsql.Client) *ReportPsqlRepository {
+	return &ReportPsqlRepository{
+		logger: logger,
+		client: client,
+	}
+}
+
+func (r *ReportPsqlRepository) GetLocalizedNames(ctx context.Context, entity string) ([]models.LocalizedName, error) {
+	table, ok := localizedTables[entity]
+	if !ok {
+		return nil, fmt.Errorf("unknown localized entity: %q", entity)
+	}
+
+	var names []models.LocalizedName
+
+	query := fmt.Sprintf(`
+		SELECT
+		    id, COALESCE(name_tm, ''), COALESCE(name_en, ''), COALESCE(name_ru, '')
+		FROM %s
+		ORDER BY id
+	`, table)
+
+	rows, err := r.client.Query(ctx, query)
+	if err != nil {
+		r.logger.Errorf("get localized names query err : %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name models.LocalizedName
+		if err = rows.Scan(&name.ID, &name.NameTM, &name.NameEN, &name.NameRU); err != nil {
+			r.logger.Errorf("get localized names scan err : %v", err)
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	if err = rows.Err(); err != nil {
+		r.logger.Errorf("get localized names rows err : %v", err)
+		return nil, err
+	}
+	return names, nil
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"autotm-admin/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	slog "github.com/salamsites/package-log"
+	spsql "github.com/salamsites/package-psql"
+)
+
+type SeedPsqlRepository struct {
+	logger *slog.Logger
+	client spsql.Client
+}
+
+func NewSeedPsqlRepository(logger *slog.Logger, client spsql.Client) *SeedPsqlRepository {
+	return &SeedPsqlRepository{
+		logger: logger,
+		client: client,
+	}
+}
+
+// SeedCatalog inserts the catalog in a single transaction. Rows are matched by
+// their natural key (slug or case-insensitive name), so existing rows are reused
+// and re-running the seed never creates duplicates. It returns the number of
+// rows created per table.
+func (r *SeedPsqlRepository) SeedCatalog(ctx context.Context, catalog models.SeedCatalog) (map[string]int64, error) {
+	created := map[string]int64{
+		"categories":       0,
+		"brands":           0,
+		"brand_categories": 0,
+		"models":           0,
+		"body_types":       0,
+		"regions":          0,
+		"cities":           0,
+	}
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	categoryIDs := make(map[string]int64, len(catalog.Categories))
+	for _, c := range catalog.Categories {
+		categoryID, isNew, err := findOrCreate(ctx, tx,
+			`SELECT id FROM categories WHERE slug = $1`, []interface{}{c.Category.Slug},
+			`INSERT INTO categories (slug, name_tm, name_en, name_ru) VALUES ($1, $2, $3, $4) RETURNING id`,
+			[]interface{}{c.Category.Slug, c.Category.NameTM, c.Category.NameEN, c.Category.NameRU},
+		)
+		if err != nil {
+			r.logger.Errorf("seed category %q err: %v", c.Category.Slug, err)
+			return nil, err
+		}
+		if isNew {
+			created["categories"]++
+			if err = saveTranslations(ctx, tx, entityCategories, categoryID, fieldName, c.Category.Names); err != nil {
+				r.logger.Errorf("seed category translations err: %v", err)
+				return nil, err
+			}
+		}
+		categoryIDs[c.Category.Slug] = categoryID
+
+		for _, b := range c.Brands {
+			brandID, isNew, err := findOrCreate(ctx, tx,
+				`SELECT id FROM brands WHERE lower(name) = lower($1) ORDER BY id LIMIT 1`, []interface{}{b.Name},
+				`INSERT INTO brands (name) VALUES ($1) RETURNING id`, []interface{}{b.Name},
+			)
+			if err != nil {
+				r.logger.Errorf("seed brand %q err: %v", b.Name, err)
+				return nil, err
+			}
+			if isNew {
+				created["brands"]++
+			}
+
+			tag, err := tx.Exec(ctx,
+				`INSERT INTO brand_categories (brand_id, category_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+				brandID, categoryID,
+			)
+			if err != nil {
+				r.logger.Errorf("seed brand category err: %v", err)
+				return nil, err
+			}
+			created["brand_categories"] += tag.RowsAffected()
+
+			for _, m := range b.Models {
+				_, isNew, err = findOrCreate(ctx, tx,
+					`SELECT id FROM models WHERE brand_id = $1 AND category_id = $2 AND lower(name) = lower($3) ORDER BY id LIMIT 1`,
+					[]interface{}{brandID, categoryID, m},
+					`INSERT INTO models (name, brand_id, category_id) VALUES ($1, $2, $3) RETURNING id`,
+					[]interface{}{m, brandID, categoryID},
+				)
+				if err != nil {
+					r.logger.Errorf("seed model %q err: %v", m, err)
+					return nil, err
+				}
+				if isNew {
+					created["models"]++
+				}
+			}
+		}
+	}
+
+	for _, bt := range catalog.BodyTypes {
+		categoryID, ok := categoryIDs[bt.Category]
+		if !ok {
+			err = tx.QueryRow(ctx, `SELECT id FROM categories WHERE slug = $1`, bt.Category).Scan(&categoryID)
+			if err != nil {
+				r.logger.Errorf("seed body type category %q err: %v", bt.Category, err)
+				return nil, fmt.Errorf("body type %q: unknown category %q: %w", bt.NameEN, bt.Category, err)
+			}
+			categoryIDs[bt.Category] = categoryID
+		}
+
+		bodyTypeID, isNew, err := findOrCreate(ctx, tx,
+			`SELECT id FROM body_types WHERE category_id = $1 AND lower(name_en) = lower($2) ORDER BY id LIMIT 1`,
+			[]interface{}{categoryID, bt.NameEN},
+			`INSERT INTO body_types (name_tm, name_en, name_ru, category_id) VALUES ($1, $2, $3, $4) RETURNING id`,
+			[]interface{}{bt.NameTM, bt.NameEN, bt.NameRU, categoryID},
+		)
+		if err != nil {
+			r.logger.Errorf("seed body type %q err: %v", bt.NameEN, err)
+			return nil, err
+		}
+		if isNew {
+			created["body_types"]++
+			if err = saveTranslations(ctx, tx, entityBodyTypes, bodyTypeID, fieldName, bt.Names); err != nil {
+				r.logger.Errorf("seed body type translations err: %v", err)
+				return nil, err
+			}
+		}
+	}
+
+	for _, rg := range catalog.Regions {
+		regionID, isNew, err := findOrCreate(ctx, tx,
+			`SELECT id FROM regions WHERE lower(name_en) = lower($1) ORDER BY id LIMIT 1`, []interface{}{rg.Region.NameEN},
+			`INSERT INTO regions (name_tm, name_en, name_ru) VALUES ($1, $2, $3) RETURNING id`,
+			[]interface{}{rg.Region.NameTM, rg.Region.NameEN, rg.Region.NameRU},
+		)
+		if err != nil {
+			r.logger.Errorf("seed region %q err: %v", rg.Region.NameEN, err)
+			return nil, err
+		}
+		if isNew {
+			created["regions"]++
+			if err = saveTranslations(ctx, tx, entityRegions, regionID, fieldName, rg.Region.Names); err != nil {
+				r.logger.Errorf("seed region translations err: %v", err)
+				return nil, err
+			}
+		}
+
+		for _, city := range rg.Cities {
+			cityID, isNew, err := findOrCreate(ctx, tx,
+				`SELECT id FROM cities WHERE region_id = $1 AND lower(name_en) = lower($2) ORDER BY id LIMIT 1`,
+				[]interface{}{regionID, city.NameEN},
+				`INSERT INTO cities (name_tm, name_en, name_ru, region_id) VALUES ($1, $2, $3, $4) RETURNING id`,
+				[]interface{}{city.NameTM, city.NameEN, city.NameRU, regionID},
+			)
+			if err != nil {
+				r.logger.Errorf("seed city %q err: %v", city.NameEN, err)
+				return nil, err
+			}
+			if isNew {
+				created["cities"]++
+				if err = saveTranslations(ctx, tx, entityCities, cityID, fieldName, city.Names); err != nil {
+					r.logger.Errorf("seed city translations err: %v", err)
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// findOrCreate returns the id selected by selectQuery, inserting the row with
+// insertQuery when nothing matches. The bool reports whether a row was created.
+func findOrCreate(ctx context.Context, tx pgx.Tx, selectQuery string, selectArgs []interface{},
+	insertQuery string, insertArgs []interface{}) (int64, bool, error) {
+	var id int64
+
+	err := tx.QueryRow(ctx, selectQuery, selectArgs...).Scan(&id)
+	if err == nil {
+		return id, false, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, err
+	}
+
+	if err = tx.QueryRow(ctx, insertQuery, insertArgs...).Scan(&id); err != nil {
+		return 0, false, err
+	}
+	return id, true, nil
+}
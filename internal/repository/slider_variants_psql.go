@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"autotm-admin/internal/helpers"
+	"autotm-admin/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+)
+
+func (r *SliderPsqlRepository) CreateSliderVariant(ctx context.Context, variant models.SliderVariant) (int64, error) {
+	var id int64
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO slider_variants (slider_id, name, weight, image_path_tm, image_path_en, image_path_ru)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+	err = tx.QueryRow(ctx, query, variant.SliderID, variant.Name, variant.Weight,
+		variant.ImagePathTM, variant.ImagePathEN, variant.ImagePathRU).Scan(&id)
+	if err != nil {
+		r.logger.Errorf("create slider variant err: %v", err)
+		return 0, err
+	}
+
+	if err = saveTranslations(ctx, tx, entitySliderVariants, id, fieldImagePath, variant.ImagePaths); err != nil {
+		r.logger.Errorf("save slider variant translations err: %v", err)
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// GetSliderVariants returns the variants of the given sliders ordered by slider and ID.
+func (r *SliderPsqlRepository) GetSliderVariants(ctx context.Context, sliderIDs []int64) ([]models.SliderVariant, error) {
+	var variants []models.SliderVariant
+
+	query := `
+		SELECT
+		    id, slider_id, name, weight, image_path_tm, image_path_en, image_path_ru,
+		    ` + translationsColumn(entitySliderVariants, "slider_variants.id", fieldImagePath) + `
+		FROM slider_variants
+		WHERE slider_id = ANY($1::bigint[])
+		ORDER BY slider_id, id
+	`
+	rows, err := r.client.Query(ctx, query, sliderIDs)
+	if err != nil {
+		r.logger.Errorf("get slider variants query err : %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var variant models.SliderVariant
+		if err = rows.Scan(&variant.ID, &variant.SliderID, &variant.Name, &variant.Weight,
+			&variant.ImagePathTM, &variant.ImagePathEN, &variant.ImagePathRU, &variant.ImagePaths); err != nil {
+			r.logger.Errorf("get slider variants scan err : %v", err)
+			return nil, err
+		}
+		variants = append(variants, variant)
+	}
+	return variants, rows.Err()
+}
+
+func (r *SliderPsqlRepository) GetSliderVariantByID(ctx context.Context, id int64) (models.SliderVariant, error) {
+	var variant models.SliderVariant
+
+	query := `
+		SELECT
+		    id, slider_id, name, weight, image_path_tm, image_path_en, image_path_ru,
+		    ` + translationsColumn(entitySliderVariants, "slider_variants.id", fieldImagePath) + `
+		FROM slider_variants
+		WHERE id = $1
+	`
+	err := r.client.QueryRow(ctx, query, id).Scan(&variant.ID, &variant.SliderID, &variant.Name, &variant.Weight,
+		&variant.ImagePathTM, &variant.ImagePathEN, &variant.ImagePathRU, &variant.ImagePaths)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return variant, fmt.Errorf("%w: slider variant %d not found", helpers.ErrInvalidReference, id)
+		}
+		r.logger.Errorf("get slider variant by id err: %v", err)
+		return variant, err
+	}
+	return variant, nil
+}
+
+func (r *SliderPsqlRepository) UpdateSliderVariant(ctx context.Context, variant models.SliderVariant) (int64, error) {
+	var id int64
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	imagePaths, err := mergeTranslations(ctx, tx, entitySliderVariants, variant.ID, fieldImagePath, variant.ImagePaths)
+	if err != nil {
+		r.logger.Errorf("save slider variant translations err: %v", err)
+		return 0, err
+	}
+	variant.ImagePathTM, variant.ImagePathEN, variant.ImagePathRU = imagePaths["tm"], imagePaths["en"], imagePaths["ru"]
+
+	query := `
+		UPDATE slider_variants SET
+		    name = $1, weight = $2, image_path_tm = $3, image_path_en = $4, image_path_ru = $5, updated_at = NOW()
+		WHERE id = $6
+		RETURNING id
+	`
+	err = tx.QueryRow(ctx, query, variant.Name, variant.Weight,
+		variant.ImagePathTM, variant.ImagePathEN, variant.ImagePathRU, variant.ID).Scan(&id)
+	if err != nil {
+		r.logger.Errorf("update slider variant err: %v", err)
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *SliderPsqlRepository) DeleteSliderVariant(ctx context.Context, id models.ID) error {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = tx.Exec(ctx, `DELETE FROM slider_variants WHERE id = $1`, id.ID); err != nil {
+		r.logger.Errorf("delete slider variant err: %v", err)
+		return err
+	}
+
+	if err = deleteTranslations(ctx, tx, entitySliderVariants, id.ID); err != nil {
+		r.logger.Errorf("delete slider variant translations err: %v", err)
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// PromoteSliderVariant makes the variant's images the slider's own creative and
+// removes every variant of the slider, ending the experiment.
+func (r *SliderPsqlRepository) PromoteSliderVariant(ctx context.Context, id int64) error {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		sliderID   int64
+		imagePaths map[string]string
+	)
+	query := `
+		UPDATE sliders s SET
+		    image_path_tm = v.image_path_tm, image_path_en = v.image_path_en, image_path_ru = v.image_path_ru,
+		    updated_at = NOW()
+		FROM slider_variants v
+		WHERE v.id = $1 AND s.id = v.slider_id
+		RETURNING s.id, ` + translationsColumn(entitySliderVariants, "v.id", fieldImagePath)
+	err = tx.QueryRow(ctx, query, id).Scan(&sliderID, &imagePaths)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: slider variant %d not found", helpers.ErrInvalidReference, id)
+		}
+		r.logger.Errorf("promote slider variant err: %v", err)
+		return err
+	}
+
+	if err = saveTranslations(ctx, tx, entitySliders, sliderID, fieldImagePath, imagePaths); err != nil {
+		r.logger.Errorf("save promoted slider translations err: %v", err)
+		return err
+	}
+
+	if err = deleteSliderVariants(ctx, tx, sliderID); err != nil {
+		r.logger.Errorf("delete promoted slider variants err: %v", err)
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// deleteSliderVariants removes the variants of a slider with their translations.
+func deleteSliderVariants(ctx context.Context, db execer, sliderID int64) error {
+	_, err := db.Exec(ctx, `
+		DELETE FROM translations
+		WHERE entity_type = $1 AND entity_id IN (SELECT id FROM slider_variants WHERE slider_id = $2)
+	`, entitySliderVariants, sliderID)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(ctx, `DELETE FROM slider_variants WHERE slider_id = $1`, sliderID)
+	return err
+}
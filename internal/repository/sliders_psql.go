@@ -1,12 +1,38 @@
 package repository
 
 import (
+	"autotm-admin/internal/helpers"
 	"autotm-admin/internal/models"
 	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
 	slog "github.com/salamsites/package-log"
 	spsql "github.com/salamsites/package-psql"
+	"time"
 )
 
+// sliderColumns selects a slider together with its resolved click target. It is
+// used with the sliders table aliased as s and sliderTargetJoins.
+var sliderColumns = `
+		    s.id, s.image_path_tm, s.image_path_en, s.image_path_ru, s.platform,
+		    s.starts_at, s.ends_at, s.is_active, s.position,
+		    s.target_type, s.target_id, COALESCE(s.target_url, ''), COALESCE(s.target_screen, ''),
+		    COALESCE(tb.name, tm.name, ta.store_name, ''),
+		    ` + translationsColumn(entitySliders, "s.id", fieldImagePath) + `,
+		    ` + translationsColumn(entitySliders, "s.id", fieldTargetURL)
+
+const sliderTargetJoins = `
+		    LEFT JOIN brands tb ON s.target_type = 'brand' AND tb.id = s.target_id
+		    LEFT JOIN models tm ON s.target_type = 'model' AND tm.id = s.target_id
+		    LEFT JOIN auto_stores ta ON s.target_type = 'auto_store' AND ta.id = s.target_id`
+
+// sliderTargetTables maps the target types that reference a row to their table.
+var sliderTargetTables = map[string]string{
+	models.SliderTargetBrand:     "brands",
+	models.SliderTargetModel:     "models",
+	models.SliderTargetAutoStore: "auto_stores",
+}
+
 type SliderPsqlRepository struct {
 	logger *slog.Logger
 	client spsql.Client
@@ -22,13 +48,43 @@ func NewSliderPsqlRepository(logger *slog.Logger, client spsql.Client) *SliderPs
 func (r *SliderPsqlRepository) CreateSlider(ctx context.Context, slider models.Slider) (int64, error) {
 	var id int64
 
-	query := `INSERT INTO sliders (image_path_tm, image_path_en, image_path_ru, platform) VALUES ($1, $2, $3, $4) RETURNING id`
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO sliders (image_path_tm, image_path_en, image_path_ru, platform, starts_at, ends_at, is_active, position,
+		                     target_type, target_id, target_url, target_screen)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULLIF($11, ''), NULLIF($12, ''))
+		RETURNING id
+	`
+
+	if err = checkSliderTarget(ctx, tx, slider.Target); err != nil {
+		return 0, err
+	}
 
-	err := r.client.QueryRow(ctx, query, slider.ImagePathTM, slider.ImagePathEN, slider.ImagePathRU, slider.Platform).Scan(&id)
+	err = tx.QueryRow(ctx, query, slider.ImagePathTM, slider.ImagePathEN, slider.ImagePathRU, slider.Platform,
+		slider.StartsAt, slider.EndsAt, slider.IsActive, slider.Position,
+		slider.Target.Type, slider.Target.ID, slider.Target.URL, slider.Target.Screen).Scan(&id)
 	if err != nil {
 		r.logger.Errorf("create err: %v", err)
 		return id, err
 	}
+
+	if err = saveTranslations(ctx, tx, entitySliders, id, fieldImagePath, slider.ImagePaths); err != nil {
+		r.logger.Errorf("save slider translations err: %v", err)
+		return 0, err
+	}
+	if err = saveTranslations(ctx, tx, entitySliders, id, fieldTargetURL, slider.Target.URLs); err != nil {
+		r.logger.Errorf("save slider target url translations err: %v", err)
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
 	return id, nil
 }
 
@@ -39,11 +95,10 @@ func (r *SliderPsqlRepository) GetAllSliders(ctx context.Context, limit, page in
 	)
 
 	query := `
-		SELECT 
-		    id, image_path_tm, image_path_en, image_path_ru, platform
-		FROM sliders
-		WHERE platform = $1
-		ORDER BY created_at DESC
+		SELECT ` + sliderColumns + `
+		FROM sliders s ` + sliderTargetJoins + `
+		WHERE s.platform = $1
+		ORDER BY s.position, s.id
 		LIMIT $2 OFFSET $3;
 	`
 
@@ -54,8 +109,8 @@ func (r *SliderPsqlRepository) GetAllSliders(ctx context.Context, limit, page in
 	}
 	defer rows.Close()
 	for rows.Next() {
-		var slider models.Slider
-		if err = rows.Scan(&slider.ID, &slider.ImagePathTM, &slider.ImagePathEN, &slider.ImagePathRU, &slider.Platform); err != nil {
+		slider, err := scanSlider(rows)
+		if err != nil {
 			r.logger.Errorf("get sliders scan err : %v", err)
 			return nil, 0, err
 		}
@@ -79,43 +134,302 @@ func (r *SliderPsqlRepository) GetAllSliders(ctx context.Context, limit, page in
 func (r *SliderPsqlRepository) UpdateSlider(ctx context.Context, slider models.Slider) (int64, error) {
 	var id int64
 
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	imagePaths, err := mergeTranslations(ctx, tx, entitySliders, slider.ID, fieldImagePath, slider.ImagePaths)
+	if err != nil {
+		r.logger.Errorf("save slider translations err: %v", err)
+		return 0, err
+	}
+	slider.ImagePathTM, slider.ImagePathEN, slider.ImagePathRU = imagePaths["tm"], imagePaths["en"], imagePaths["ru"]
+
 	query := `
 		UPDATE sliders SET 
-		    image_path_tm = $1, image_path_en = $2, image_path_ru = $3, platform = $4, updated_at = NOW()
-		WHERE id = $5
+		    image_path_tm = $1, image_path_en = $2, image_path_ru = $3, platform = $4,
+		    starts_at = $5, ends_at = $6, is_active = $7, position = $8,
+		    target_type = $9, target_id = $10, target_url = NULLIF($11, ''), target_screen = NULLIF($12, ''),
+		    updated_at = NOW()
+		WHERE id = $13
 		RETURNING id;
 	`
-	err := r.client.QueryRow(ctx, query, slider.ImagePathTM, slider.ImagePathEN, slider.ImagePathRU, slider.Platform, slider.ID).Scan(&id)
+
+	if err = checkSliderTarget(ctx, tx, slider.Target); err != nil {
+		return 0, err
+	}
+
+	err = tx.QueryRow(ctx, query, slider.ImagePathTM, slider.ImagePathEN, slider.ImagePathRU, slider.Platform,
+		slider.StartsAt, slider.EndsAt, slider.IsActive, slider.Position,
+		slider.Target.Type, slider.Target.ID, slider.Target.URL, slider.Target.Screen, slider.ID).Scan(&id)
 	if err != nil {
 		r.logger.Errorf("update slider err: %v", err)
 		return id, err
 	}
+
+	if err = saveTranslations(ctx, tx, entitySliders, id, fieldTargetURL, slider.Target.URLs); err != nil {
+		r.logger.Errorf("save slider target url translations err: %v", err)
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
 	return id, nil
 }
 
 func (r *SliderPsqlRepository) DeleteSlider(ctx context.Context, id models.ID) error {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = deleteSliderVariants(ctx, tx, id.ID); err != nil {
+		r.logger.Errorf("delete slider variants err: %v", err)
+		return err
+	}
+
 	query := `DELETE FROM sliders WHERE id = $1`
-	_, err := r.client.Exec(ctx, query, id.ID)
+	_, err = tx.Exec(ctx, query, id.ID)
 	if err != nil {
 		r.logger.Errorf("delete slider err: %v", err)
 		return err
 	}
-	return nil
+
+	if err = deleteTranslations(ctx, tx, entitySliders, id.ID); err != nil {
+		r.logger.Errorf("delete slider translations err: %v", err)
+		return err
+	}
+	return tx.Commit(ctx)
 }
 
 func (r *SliderPsqlRepository) GetSliderByID(ctx context.Context, id int64) (models.Slider, error) {
-	var slider models.Slider
-
 	query := `
-		SELECT
-			id, image_path_tm, image_path_en, image_path_ru, platform
-		FROM sliders
-		WHERE id = $1
+		SELECT ` + sliderColumns + `
+		FROM sliders s ` + sliderTargetJoins + `
+		WHERE s.id = $1
 	`
-	err := r.client.QueryRow(ctx, query, id).Scan(&slider.ID, &slider.ImagePathTM, &slider.ImagePathEN, &slider.ImagePathRU, &slider.Platform)
+	slider, err := scanSlider(r.client.QueryRow(ctx, query, id))
 	if err != nil {
 		r.logger.Errorf("get slider by id query err : %v", err)
 		return slider, err
 	}
 	return slider, nil
 }
+
+// GetActiveSliders returns the sliders of a platform that are switched on and
+// whose schedule window contains the current time, in display order.
+func (r *SliderPsqlRepository) GetActiveSliders(ctx context.Context, platform string) ([]models.Slider, error) {
+	var sliders []models.Slider
+
+	query := `
+		SELECT ` + sliderColumns + `
+		FROM sliders s ` + sliderTargetJoins + `
+		WHERE s.platform = $1
+		  AND s.is_active
+		  AND (s.starts_at IS NULL OR s.starts_at <= NOW())
+		  AND (s.ends_at IS NULL OR s.ends_at > NOW())
+		ORDER BY s.position, s.id
+	`
+
+	rows, err := r.client.Query(ctx, query, platform)
+	if err != nil {
+		r.logger.Errorf("get active sliders query err : %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		slider, err := scanSlider(rows)
+		if err != nil {
+			r.logger.Errorf("get active sliders scan err : %v", err)
+			return nil, err
+		}
+		sliders = append(sliders, slider)
+	}
+	return sliders, rows.Err()
+}
+
+func (r *SliderPsqlRepository) GetNextSliderPosition(ctx context.Context, platform string) (int64, error) {
+	var position int64
+
+	query := `SELECT COALESCE(MAX(position) + 1, 0) FROM sliders WHERE platform = $1`
+	err := r.client.QueryRow(ctx, query, platform).Scan(&position)
+	if err != nil {
+		r.logger.Errorf("get next slider position err: %v", err)
+		return 0, err
+	}
+	return position, nil
+}
+
+// ReorderSliders puts the given sliders first, in the given order, and moves
+// the remaining sliders of the platform behind them keeping their relative order.
+func (r *SliderPsqlRepository) ReorderSliders(ctx context.Context, platform string, ids []int64) error {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE sliders s SET
+		    position = o.ord - 1, updated_at = NOW()
+		FROM unnest($1::bigint[]) WITH ORDINALITY AS o(id, ord)
+		WHERE s.id = o.id AND s.platform = $2
+	`
+	tag, err := tx.Exec(ctx, query, ids, platform)
+	if err != nil {
+		r.logger.Errorf("reorder sliders err: %v", err)
+		return err
+	}
+	if tag.RowsAffected() != int64(len(ids)) {
+		return fmt.Errorf("%w: all slider ids must exist and belong to platform %s", helpers.ErrInvalidReference, platform)
+	}
+
+	queryRest := `
+		UPDATE sliders s SET
+		    position = $3 + o.rn - 1, updated_at = NOW()
+		FROM (
+		    SELECT id, ROW_NUMBER() OVER (ORDER BY position, id) AS rn
+		    FROM sliders
+		    WHERE platform = $1 AND id <> ALL($2::bigint[])
+		) o
+		WHERE s.id = o.id
+	`
+	if _, err = tx.Exec(ctx, queryRest, platform, ids, len(ids)); err != nil {
+		r.logger.Errorf("reorder remaining sliders err: %v", err)
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func scanSlider(row pgx.Row) (models.Slider, error) {
+	var slider models.Slider
+	err := row.Scan(&slider.ID, &slider.ImagePathTM, &slider.ImagePathEN, &slider.ImagePathRU, &slider.Platform,
+		&slider.StartsAt, &slider.EndsAt, &slider.IsActive, &slider.Position,
+		&slider.Target.Type, &slider.Target.ID, &slider.Target.URL, &slider.Target.Screen, &slider.Target.Name,
+		&slider.ImagePaths, &slider.Target.URLs)
+	return slider, err
+}
+
+// checkSliderTarget makes sure the row referenced by a brand, model or auto store
+// target exists.
+func checkSliderTarget(ctx context.Context, tx pgx.Tx, target models.SliderTarget) error {
+	table, ok := sliderTargetTables[target.Type]
+	if !ok || target.ID == nil {
+		return nil
+	}
+
+	var exists bool
+	query := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE id = $1)`, table)
+	if err := tx.QueryRow(ctx, query, *target.ID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: %s %d not found", helpers.ErrInvalidReference, target.Type, *target.ID)
+	}
+	return nil
+}
+
+// clearSliderTargets resets the click target of sliders pointing at a row that
+// is being deleted.
+func clearSliderTargets(ctx context.Context, db execer, targetType string, id int64) error {
+	_, err := db.Exec(ctx, `
+		UPDATE sliders SET target_type = 'none', target_id = NULL, updated_at = NOW()
+		WHERE target_type = $1 AND target_id = $2
+	`, targetType, id)
+	return err
+}
+
+// repointSliderTargets moves the click target of sliders from one row to another.
+func repointSliderTargets(ctx context.Context, db execer, targetType string, sourceID, targetID int64) (int64, error) {
+	tag, err := db.Exec(ctx, `
+		UPDATE sliders SET target_id = $3, updated_at = NOW()
+		WHERE target_type = $1 AND target_id = $2
+	`, targetType, sourceID, targetID)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// RecordSliderImpressions adds one impression for each existing slider of ids
+// shown on platform to today's counters and returns how many sliders were
+// counted. Sliders of another platform are skipped. variantIDs holds the served
+// variant per slider; variants that do not belong to the slider are counted as
+// its own creative.
+func (r *SliderPsqlRepository) RecordSliderImpressions(ctx context.Context, ids, variantIDs []int64, platform string) (int64, error) {
+	query := `
+		INSERT INTO slider_stats (slider_id, variant_id, platform, day, impressions)
+		SELECT DISTINCT s.id, COALESCE(v.id, 0), s.platform, CURRENT_DATE, 1
+		FROM unnest($1::bigint[], $2::bigint[]) AS e(slider_id, variant_id)
+		    JOIN sliders s ON s.id = e.slider_id AND s.platform = $3
+		    LEFT JOIN slider_variants v ON v.id = e.variant_id AND v.slider_id = s.id
+		ON CONFLICT (slider_id, variant_id, platform, day)
+		DO UPDATE SET impressions = slider_stats.impressions + 1
+	`
+	tag, err := r.client.Exec(ctx, query, ids, variantIDs, platform)
+	if err != nil {
+		r.logger.Errorf("record slider impressions err: %v", err)
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// RecordSliderClick adds one click to today's counters of a slider shown on
+// platform. A slider of another platform is an invalid reference.
+func (r *SliderPsqlRepository) RecordSliderClick(ctx context.Context, id, variantID int64, platform string) error {
+	query := `
+		INSERT INTO slider_stats (slider_id, variant_id, platform, day, clicks)
+		SELECT s.id, $2, s.platform, CURRENT_DATE, 1
+		FROM sliders s
+		WHERE s.id = $1 AND s.platform = $3
+		  AND ($2 = 0 OR EXISTS (SELECT 1 FROM slider_variants v WHERE v.id = $2 AND v.slider_id = s.id))
+		ON CONFLICT (slider_id, variant_id, platform, day)
+		DO UPDATE SET clicks = slider_stats.clicks + 1
+	`
+	tag, err := r.client.Exec(ctx, query, id, variantID, platform)
+	if err != nil {
+		r.logger.Errorf("record slider click err: %v", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: slider %d with variant %d not found on platform %s", helpers.ErrInvalidReference, id, variantID, platform)
+	}
+	return nil
+}
+
+// GetSliderStats returns the daily counters per slider variant between from and
+// to inclusive, summed over platforms unless platform is set.
+func (r *SliderPsqlRepository) GetSliderStats(ctx context.Context, from, to time.Time, platform string, sliderID int64) ([]models.SliderStat, error) {
+	var stats []models.SliderStat
+
+	query := `
+		SELECT
+		    st.slider_id, st.variant_id, COALESCE(v.name, ''), st.day, SUM(st.impressions), SUM(st.clicks)
+		FROM slider_stats st
+		    LEFT JOIN slider_variants v ON v.id = st.variant_id
+		WHERE st.day BETWEEN $1 AND $2
+		  AND ($3 = '' OR st.platform = $3)
+		  AND ($4 = 0 OR st.slider_id = $4)
+		GROUP BY st.slider_id, st.variant_id, v.name, st.day
+		ORDER BY st.slider_id, st.variant_id, st.day
+	`
+	rows, err := r.client.Query(ctx, query, from, to, platform, sliderID)
+	if err != nil {
+		r.logger.Errorf("get slider stats query err : %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var stat models.SliderStat
+		if err = rows.Scan(&stat.SliderID, &stat.VariantID, &stat.VariantName, &stat.Day, &stat.Impressions, &stat.Clicks); err != nil {
+			r.logger.Errorf("get slider stats scan err : %v", err)
+			return nil, err
+		}
+		stats = append(stats, stat)
+	}
+	return stats, rows.Err()
+}
@@ -3,11 +3,21 @@ package storage
 import (
 	"autotm-admin/internal/models"
 	"context"
+	"time"
 )
 
 type AutoStoreRepository interface {
-	CreateAutoStore(ctx context.Context, autoStore models.AutoStore) (int64, error)
-	GetAutoStores(ctx context.Context, limit, page int64, search string) ([]models.AutoStore, int64, error)
-	UpdateAutoStore(ctx context.Context, autoStore models.AutoStore) (int64, error)
+	CreateAutoStore(ctx context.Context, autoStore models.AutoStore, maxStores int) (int64, error)
+	GetAutoStores(ctx context.Context, filter models.AutoStoreFilter) ([]models.AutoStore, int64, error)
+	GetNearestAutoStores(ctx context.Context, lat, lng, radiusKm float64, limit int64) ([]models.AutoStore, error)
+	UpdateAutoStore(ctx context.Context, autoStore models.AutoStore, maxStores int) (int64, error)
 	DeleteAutoStore(ctx context.Context, id models.ID) error
+	SetAutoStoreSchedule(ctx context.Context, schedule models.AutoStoreSchedule) error
+	GetAutoStoreSchedules(ctx context.Context, ids []int64, from time.Time) (map[int64]models.AutoStoreSchedule, error)
+	ChangeAutoStoreStatus(ctx context.Context, id int64, from []string, change models.AutoStoreStatusChange) error
+	GetAutoStoreStatusHistory(ctx context.Context, id int64) ([]models.AutoStoreStatusChange, error)
+	GetAutoStoreContacts(ctx context.Context, ids []int64) (map[int64][]models.AutoStoreContact, error)
+	SetAutoStoreBrands(ctx context.Context, autoStoreID int64, brands []models.AutoStoreBrand) error
+	GetAutoStoreBrands(ctx context.Context, ids []int64) (map[int64][]models.AutoStoreBrand, error)
+	GetAutoStoreGalleryLimit(ctx context.Context, id int64) (int64, *int64, error)
 }
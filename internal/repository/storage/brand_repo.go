@@ -24,5 +24,20 @@ type BrandRepository interface {
 	CreateModel(ctx context.Context, model models.Model) (int64, error)
 	GetModels(ctx context.Context, limit, page int64, category, search string) ([]models.Model, int64, error)
 	UpdateModel(ctx context.Context, model models.Model) (int64, error)
+	GetModelByID(ctx context.Context, id int64) (models.Model, error)
 	DeleteModel(ctx context.Context, id models.ID) error
+
+	// WMI Code
+	CreateWMICode(ctx context.Context, code models.WMICode) (int64, error)
+	GetWMICodes(ctx context.Context, limit, page int64, brandID int64, search string) ([]models.WMICode, int64, error)
+	UpdateWMICode(ctx context.Context, code models.WMICode) (int64, error)
+	DeleteWMICode(ctx context.Context, id models.ID) error
+	GetWMICodeByVIN(ctx context.Context, vin string) (models.WMICode, error)
+
+	// Merge
+	MergeBrands(ctx context.Context, sourceID, targetID int64) (map[string]int64, map[string]int64, error)
+	MergeModels(ctx context.Context, sourceID, targetID int64) (map[string]int64, map[string]int64, error)
+
+	// Category
+	GetCategoryIDBySlug(ctx context.Context, slug string) (int64, bool, error)
 }
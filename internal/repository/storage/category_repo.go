@@ -0,0 +1,14 @@
+package storage
+
+import (
+	"autotm-admin/internal/models"
+	"context"
+)
+
+type CategoryRepository interface {
+	CreateCategory(ctx context.Context, category models.Category) (int64, error)
+	GetCategories(ctx context.Context, limit, page int64, search string, activeOnly bool) ([]models.Category, int64, error)
+	GetCategoryByID(ctx context.Context, id int64) (models.Category, error)
+	UpdateCategory(ctx context.Context, category models.Category) (int64, error)
+	DeleteCategory(ctx context.Context, id models.ID) error
+}
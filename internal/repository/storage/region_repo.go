@@ -9,11 +9,18 @@ type RegionsRepository interface {
 	CreateRegion(ctx context.Context, model models.Region) (int64, error)
 	GetAllRegions(ctx context.Context, limit, page int64, search string) ([]models.Region, int64, error)
 	UpdateRegion(ctx context.Context, region models.Region) (int64, error)
-	DeleteRegion(ctx context.Context, id models.ID) error
+	DeleteRegion(ctx context.Context, id models.ID, reassignTo int64) error
 
 	//Cities
 	CreateCity(ctx context.Context, model models.City) (int64, error)
-	GetAllCities(ctx context.Context, limit, page int64, search string) ([]models.City, int64, error)
+	GetAllCities(ctx context.Context, limit, page int64, regionID int64, search string) ([]models.City, int64, error)
+	GetRegionTree(ctx context.Context) ([]models.Region, error)
 	UpdateCity(ctx context.Context, region models.City) (int64, error)
-	DeleteCity(ctx context.Context, id models.ID) error
+	DeleteCity(ctx context.Context, id models.ID, reassignTo int64) error
+
+	// Districts
+	CreateDistrict(ctx context.Context, district models.District) (int64, error)
+	GetAllDistricts(ctx context.Context, limit, page int64, cityID int64, search string) ([]models.District, int64, error)
+	UpdateDistrict(ctx context.Context, district models.District) (int64, error)
+	DeleteDistrict(ctx context.Context, id models.ID, reassignTo int64) error
 }
@@ -0,0 +1,10 @@
+package storage
+
+import (
+	"autotm-admin/internal/models"
+	"context"
+)
+
+type ReportRepository interface {
+	GetLocalizedNames(ctx context.Context, entity string) ([]models.LocalizedName, error)
+}
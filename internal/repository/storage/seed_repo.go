@@ -0,0 +1,10 @@
+package storage
+
+import (
+	"autotm-admin/internal/models"
+	"context"
+)
+
+type SeedRepository interface {
+	SeedCatalog(ctx context.Context, catalog models.SeedCatalog) (map[string]int64, error)
+}
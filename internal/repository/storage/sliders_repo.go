@@ -3,6 +3,7 @@ package storage
 import (
 	"autotm-admin/internal/models"
 	"context"
+	"time"
 )
 
 type SlidersRepository interface {
@@ -11,4 +12,21 @@ type SlidersRepository interface {
 	UpdateSlider(ctx context.Context, slider models.Slider) (int64, error)
 	GetSliderByID(ctx context.Context, id int64) (models.Slider, error)
 	DeleteSlider(ctx context.Context, id models.ID) error
+	GetActiveSliders(ctx context.Context, platform string) ([]models.Slider, error)
+	GetNextSliderPosition(ctx context.Context, platform string) (int64, error)
+	ReorderSliders(ctx context.Context, platform string, ids []int64) error
+	RecordSliderImpressions(ctx context.Context, ids, variantIDs []int64, platform string) (int64, error)
+	RecordSliderClick(ctx context.Context, id, variantID int64, platform string) error
+	GetSliderStats(ctx context.Context, from, to time.Time, platform string, sliderID int64) ([]models.SliderStat, error)
+	CreatePlatform(ctx context.Context, platform models.Platform) (int64, error)
+	GetPlatforms(ctx context.Context) ([]models.Platform, error)
+	GetPlatformByCode(ctx context.Context, code string) (models.Platform, error)
+	UpdatePlatform(ctx context.Context, platform models.Platform) (int64, error)
+	DeletePlatform(ctx context.Context, id models.ID) error
+	CreateSliderVariant(ctx context.Context, variant models.SliderVariant) (int64, error)
+	GetSliderVariants(ctx context.Context, sliderIDs []int64) ([]models.SliderVariant, error)
+	GetSliderVariantByID(ctx context.Context, id int64) (models.SliderVariant, error)
+	UpdateSliderVariant(ctx context.Context, variant models.SliderVariant) (int64, error)
+	DeleteSliderVariant(ctx context.Context, id models.ID) error
+	PromoteSliderVariant(ctx context.Context, id int64) error
 }
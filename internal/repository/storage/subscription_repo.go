@@ -0,0 +1,19 @@
+package storage
+
+import (
+	"autotm-admin/internal/models"
+	"context"
+	"time"
+)
+
+type SubscriptionRepository interface {
+	CreateSubscriptionPlan(ctx context.Context, plan models.SubscriptionPlan) (int64, error)
+	GetSubscriptionPlans(ctx context.Context, activeOnly bool) ([]models.SubscriptionPlan, error)
+	UpdateSubscriptionPlan(ctx context.Context, plan models.SubscriptionPlan) (int64, error)
+	DeleteSubscriptionPlan(ctx context.Context, id models.ID) error
+	AssignSubscription(ctx context.Context, autoStoreID, planID int64, startsAt *time.Time) (int64, error)
+	ExtendSubscription(ctx context.Context, id, days int64) error
+	CancelSubscription(ctx context.Context, id int64) error
+	GetStoreSubscriptions(ctx context.Context, autoStoreID int64) ([]models.StoreSubscription, error)
+	RefreshExpiredAutoStores(ctx context.Context) (int64, error)
+}
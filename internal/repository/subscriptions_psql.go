@@ -0,0 +1,375 @@
+package repository
+
+import (
+	"autotm-admin/internal/helpers"
+	"autotm-admin/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	slog "github.com/salamsites/package-log"
+	spsql "github.com/salamsites/package-psql"
+	"time"
+)
+
+type SubscriptionPsqlRepository struct {
+	logger *slog.Logger
+	client spsql.Client
+}
+
+func NewSubscriptionPsqlRepository(logger *slog.Logger, client spsql.Client) *SubscriptionPsqlRepository {
+	return &SubscriptionPsqlRepository{
+		logger: logger,
+		client: client,
+	}
+}
+
+func (r *SubscriptionPsqlRepository) CreateSubscriptionPlan(ctx context.Context, plan models.SubscriptionPlan) (int64, error) {
+	var id int64
+
+	query := `
+		INSERT INTO subscription_plans (name, price, currency, duration_days, max_gallery_images, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+	err := r.client.QueryRow(ctx, query, plan.Name, plan.Price, plan.Currency, plan.DurationDays,
+		plan.MaxGalleryImages, plan.IsActive).Scan(&id)
+	if err != nil {
+		r.logger.Errorf("create subscription plan err: %v", err)
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *SubscriptionPsqlRepository) GetSubscriptionPlans(ctx context.Context, activeOnly bool) ([]models.SubscriptionPlan, error) {
+	var plans []models.SubscriptionPlan
+
+	query := `
+		SELECT
+		    id, name, price, currency, duration_days, max_gallery_images, is_active
+		FROM subscription_plans
+		WHERE (NOT $1 OR is_active)
+		ORDER BY price, name
+	`
+	rows, err := r.client.Query(ctx, query, activeOnly)
+	if err != nil {
+		r.logger.Errorf("get subscription plans query err : %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var plan models.SubscriptionPlan
+		if err = rows.Scan(&plan.ID, &plan.Name, &plan.Price, &plan.Currency, &plan.DurationDays,
+			&plan.MaxGalleryImages, &plan.IsActive); err != nil {
+			r.logger.Errorf("get subscription plans scan err : %v", err)
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+	return plans, rows.Err()
+}
+
+func (r *SubscriptionPsqlRepository) UpdateSubscriptionPlan(ctx context.Context, plan models.SubscriptionPlan) (int64, error) {
+	var id int64
+
+	query := `
+		UPDATE subscription_plans SET
+		    name = $1, price = $2, currency = $3, duration_days = $4, max_gallery_images = $5,
+		    is_active = $6, updated_at = NOW()
+		WHERE id = $7
+		RETURNING id
+	`
+	err := r.client.QueryRow(ctx, query, plan.Name, plan.Price, plan.Currency, plan.DurationDays,
+		plan.MaxGalleryImages, plan.IsActive, plan.ID).Scan(&id)
+	if err != nil {
+		r.logger.Errorf("update subscription plan err: %v", err)
+		return 0, err
+	}
+	return id, nil
+}
+
+// DeleteSubscriptionPlan refuses with a ReferencedError while any subscription,
+// including ended and cancelled ones, uses the plan.
+func (r *SubscriptionPsqlRepository) DeleteSubscriptionPlan(ctx context.Context, id models.ID) error {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var subscriptions int64
+	err = tx.QueryRow(ctx, `SELECT COUNT(*) FROM store_subscriptions WHERE plan_id = $1`, id.ID).Scan(&subscriptions)
+	if err != nil {
+		r.logger.Errorf("count subscription plan references err: %v", err)
+		return err
+	}
+	if err = referencedError("subscription plan", id.ID, map[string]int64{"store_subscriptions": subscriptions}); err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, `DELETE FROM subscription_plans WHERE id = $1`, id.ID); err != nil {
+		r.logger.Errorf("delete subscription plan err: %v", err)
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// AssignSubscription subscribes a store to an active plan for the plan's
+// duration. A nil startsAt starts the subscription now, or when the store's
+// latest subscription ends if that is later. Periods of active subscriptions
+// of a store may not overlap; an overlap is an ErrInvalidDateRange error.
+func (r *SubscriptionPsqlRepository) AssignSubscription(ctx context.Context, autoStoreID, planID int64, startsAt *time.Time) (int64, error) {
+	var id int64
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = lockAutoStore(ctx, tx, autoStoreID); err != nil {
+		if !errors.Is(err, helpers.ErrInvalidReference) {
+			r.logger.Errorf("lock auto store err: %v", err)
+		}
+		return 0, err
+	}
+
+	var durationDays int64
+	err = tx.QueryRow(ctx, `SELECT duration_days FROM subscription_plans WHERE id = $1 AND is_active`, planID).Scan(&durationDays)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("%w: subscription plan %d not found or inactive", helpers.ErrInvalidReference, planID)
+	}
+	if err != nil {
+		r.logger.Errorf("get subscription plan err: %v", err)
+		return 0, err
+	}
+
+	var start time.Time
+	if startsAt != nil {
+		start = *startsAt
+	} else {
+		err = tx.QueryRow(ctx, `
+			SELECT GREATEST(NOW(), MAX(ends_at)) FROM store_subscriptions WHERE auto_store_id = $1 AND status = $2
+		`, autoStoreID, models.SubscriptionStatusActive).Scan(&start)
+		if err != nil {
+			r.logger.Errorf("get subscription start err: %v", err)
+			return 0, err
+		}
+	}
+	end := start.AddDate(0, 0, int(durationDays))
+
+	if err = checkSubscriptionOverlap(ctx, tx, autoStoreID, 0, start, end); err != nil {
+		return 0, err
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO store_subscriptions (auto_store_id, plan_id, starts_at, ends_at, status)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`, autoStoreID, planID, start, end, models.SubscriptionStatusActive).Scan(&id)
+	if err != nil {
+		r.logger.Errorf("insert store subscription err: %v", err)
+		return 0, err
+	}
+
+	if _, err = refreshAutoStoreExpiry(ctx, tx, autoStoreID); err != nil {
+		r.logger.Errorf("refresh auto store expiry err: %v", err)
+		return 0, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// ExtendSubscription moves the end of an active subscription by days days.
+// Cancelled subscriptions cannot be extended.
+func (r *SubscriptionPsqlRepository) ExtendSubscription(ctx context.Context, id, days int64) error {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	subscription, err := lockStoreSubscription(ctx, tx, id)
+	if err != nil {
+		if !errors.Is(err, helpers.ErrInvalidReference) {
+			r.logger.Errorf("lock store subscription err: %v", err)
+		}
+		return err
+	}
+	if subscription.Status != models.SubscriptionStatusActive {
+		return fmt.Errorf("%w: subscription %d is %s and cannot be extended", helpers.ErrInvalidTransition, id, subscription.Status)
+	}
+
+	end := subscription.EndsAt.AddDate(0, 0, int(days))
+	if err = checkSubscriptionOverlap(ctx, tx, subscription.AutoStoreID, id, subscription.StartsAt, end); err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, `UPDATE store_subscriptions SET ends_at = $2 WHERE id = $1`, id, end); err != nil {
+		r.logger.Errorf("extend store subscription err: %v", err)
+		return err
+	}
+	if _, err = refreshAutoStoreExpiry(ctx, tx, subscription.AutoStoreID); err != nil {
+		r.logger.Errorf("refresh auto store expiry err: %v", err)
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// CancelSubscription ends an active subscription immediately. The store is
+// hidden unless another subscription covers the current time.
+func (r *SubscriptionPsqlRepository) CancelSubscription(ctx context.Context, id int64) error {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	subscription, err := lockStoreSubscription(ctx, tx, id)
+	if err != nil {
+		if !errors.Is(err, helpers.ErrInvalidReference) {
+			r.logger.Errorf("lock store subscription err: %v", err)
+		}
+		return err
+	}
+	if subscription.Status != models.SubscriptionStatusActive {
+		return fmt.Errorf("%w: subscription %d is already %s", helpers.ErrInvalidTransition, id, subscription.Status)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE store_subscriptions SET status = $2, cancelled_at = NOW() WHERE id = $1
+	`, id, models.SubscriptionStatusCancelled)
+	if err != nil {
+		r.logger.Errorf("cancel store subscription err: %v", err)
+		return err
+	}
+	if _, err = refreshAutoStoreExpiry(ctx, tx, subscription.AutoStoreID); err != nil {
+		r.logger.Errorf("refresh auto store expiry err: %v", err)
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// GetStoreSubscriptions returns the subscriptions of a store, latest first.
+func (r *SubscriptionPsqlRepository) GetStoreSubscriptions(ctx context.Context, autoStoreID int64) ([]models.StoreSubscription, error) {
+	var subscriptions []models.StoreSubscription
+
+	rows, err := r.client.Query(ctx, `
+		SELECT ss.id, ss.auto_store_id, ss.plan_id, p.name, ss.starts_at, ss.ends_at, ss.status, ss.cancelled_at, ss.created_at
+		FROM store_subscriptions ss
+		JOIN subscription_plans p ON p.id = ss.plan_id
+		WHERE ss.auto_store_id = $1
+		ORDER BY ss.starts_at DESC, ss.id DESC
+	`, autoStoreID)
+	if err != nil {
+		r.logger.Errorf("get store subscriptions err: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var subscription models.StoreSubscription
+		if err = rows.Scan(&subscription.ID, &subscription.AutoStoreID, &subscription.PlanID, &subscription.PlanName,
+			&subscription.StartsAt, &subscription.EndsAt, &subscription.Status, &subscription.CancelledAt,
+			&subscription.CreatedAt); err != nil {
+			r.logger.Errorf("scan store subscription err: %v", err)
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, rows.Err()
+}
+
+// RefreshExpiredAutoStores marks the stores whose subscriptions have all lapsed
+// as expired and clears the mark of stores covered again. It returns the
+// number of stores that changed.
+func (r *SubscriptionPsqlRepository) RefreshExpiredAutoStores(ctx context.Context) (int64, error) {
+	changed, err := refreshAutoStoreExpiry(ctx, r.client, 0)
+	if err != nil {
+		r.logger.Errorf("refresh expired auto stores err: %v", err)
+		return 0, err
+	}
+	return changed, nil
+}
+
+// autoStoreExpiredSQL is true for the store aliased ast when it has had a
+// subscription but no active one covers the current time; stores never
+// subscribed stay listed. The public queries evaluate it directly, so a store
+// drops out the moment its subscription ends rather than at the next run of
+// the expiry job.
+const autoStoreExpiredSQL = `(
+	EXISTS (SELECT 1 FROM store_subscriptions ss WHERE ss.auto_store_id = ast.id)
+	AND NOT EXISTS (
+	    SELECT 1 FROM store_subscriptions ss
+	    WHERE ss.auto_store_id = ast.id AND ss.status = '` + models.SubscriptionStatusActive + `'
+	        AND ss.starts_at <= NOW() AND ss.ends_at > NOW()
+	))`
+
+// refreshAutoStoreExpiry recomputes is_expired of one store, or of every store
+// when autoStoreID is 0, from autoStoreExpiredSQL.
+func refreshAutoStoreExpiry(ctx context.Context, db execer, autoStoreID int64) (int64, error) {
+	tag, err := db.Exec(ctx, `
+		UPDATE auto_stores s SET is_expired = e.expired
+		FROM (
+		    SELECT ast.id, `+autoStoreExpiredSQL+` AS expired
+		    FROM auto_stores ast
+		    WHERE $1 = 0 OR ast.id = $1
+		) e
+		WHERE s.id = e.id AND s.is_expired IS DISTINCT FROM e.expired
+	`, autoStoreID)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// lockAutoStore locks a store row so that its subscriptions change one at a time.
+func lockAutoStore(ctx context.Context, tx pgx.Tx, autoStoreID int64) error {
+	var id int64
+	err := tx.QueryRow(ctx, `SELECT id FROM auto_stores WHERE id = $1 FOR UPDATE`, autoStoreID).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: auto store %d not found", helpers.ErrInvalidReference, autoStoreID)
+	}
+	return err
+}
+
+// lockStoreSubscription locks a subscription and the store it belongs to.
+func lockStoreSubscription(ctx context.Context, tx pgx.Tx, id int64) (models.StoreSubscription, error) {
+	var subscription models.StoreSubscription
+
+	err := tx.QueryRow(ctx, `SELECT auto_store_id FROM store_subscriptions WHERE id = $1`, id).Scan(&subscription.AutoStoreID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return subscription, fmt.Errorf("%w: subscription %d not found", helpers.ErrInvalidReference, id)
+	}
+	if err != nil {
+		return subscription, err
+	}
+	if err = lockAutoStore(ctx, tx, subscription.AutoStoreID); err != nil {
+		return subscription, err
+	}
+
+	err = tx.QueryRow(ctx, `
+		SELECT id, auto_store_id, plan_id, starts_at, ends_at, status FROM store_subscriptions WHERE id = $1 FOR UPDATE
+	`, id).Scan(&subscription.ID, &subscription.AutoStoreID, &subscription.PlanID, &subscription.StartsAt,
+		&subscription.EndsAt, &subscription.Status)
+	return subscription, err
+}
+
+// checkSubscriptionOverlap returns ErrInvalidDateRange when [start, end)
+// overlaps an active subscription of the store other than exceptID.
+func checkSubscriptionOverlap(ctx context.Context, tx pgx.Tx, autoStoreID, exceptID int64, start, end time.Time) error {
+	var overlapping int64
+	err := tx.QueryRow(ctx, `
+		SELECT COALESCE(MIN(id), 0) FROM store_subscriptions
+		WHERE auto_store_id = $1 AND id <> $2 AND status = $3 AND starts_at < $5 AND ends_at > $4
+	`, autoStoreID, exceptID, models.SubscriptionStatusActive, start, end).Scan(&overlapping)
+	if err != nil {
+		return err
+	}
+	if overlapping != 0 {
+		return fmt.Errorf("%w: the period overlaps subscription %d", helpers.ErrInvalidDateRange, overlapping)
+	}
+	return nil
+}
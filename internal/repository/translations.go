@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"autotm-admin/internal/helpers"
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Translation entity types and fields stored in the translations table.
+const (
+	entityCategories     = "categories"
+	entityBodyTypes      = "body_types"
+	entityRegions        = "regions"
+	entityCities         = "cities"
+	entityDistricts      = "districts"
+	entitySliders        = "sliders"
+	entitySliderVariants = "slider_variants"
+
+	fieldName      = "name"
+	fieldImagePath = "image_path"
+	fieldTargetURL = "target_url"
+)
+
+// execer is satisfied by both spsql.Client and pgx.Tx.
+type execer interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+// translationsColumn returns a select expression aggregating the locale values of
+// one entity field into a JSON object. entity, idColumn and field must be constants.
+func translationsColumn(entity, idColumn, field string) string {
+	return fmt.Sprintf(`COALESCE((
+		SELECT jsonb_object_agg(t.locale, t.value) FROM translations t
+		WHERE t.entity_type = '%s' AND t.entity_id = %s AND t.field = '%s'
+	), '{}'::jsonb)`, entity, idColumn, field)
+}
+
+// saveTranslations replaces the locale values of one entity field.
+func saveTranslations(ctx context.Context, db execer, entity string, id int64, field string, values map[string]string) error {
+	_, err := db.Exec(ctx,
+		`DELETE FROM translations WHERE entity_type = $1 AND entity_id = $2 AND field = $3`,
+		entity, id, field,
+	)
+	if err != nil {
+		return err
+	}
+
+	for locale, value := range values {
+		if value == "" {
+			continue
+		}
+		_, err = db.Exec(ctx,
+			`INSERT INTO translations (entity_type, entity_id, field, locale, value) VALUES ($1, $2, $3, $4, $5)`,
+			entity, id, field, locale, value,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeTranslations applies the locale values of an update to the stored ones
+// of one entity field (see helpers.MergeLocalizedValues), saves and returns
+// them. Updates write their legacy tm/en/ru columns from the returned values so
+// both stay equal when a request carries only some locales.
+func mergeTranslations(ctx context.Context, tx pgx.Tx, entity string, id int64, field string, values map[string]string) (map[string]string, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT locale, value FROM translations WHERE entity_type = $1 AND entity_id = $2 AND field = $3`,
+		entity, id, field,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stored := make(map[string]string)
+	for rows.Next() {
+		var locale, value string
+		if err = rows.Scan(&locale, &value); err != nil {
+			return nil, err
+		}
+		stored[locale] = value
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	merged := helpers.MergeLocalizedValues(stored, values)
+	if err = saveTranslations(ctx, tx, entity, id, field, merged); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// deleteTranslations removes every translation of an entity.
+func deleteTranslations(ctx context.Context, db execer, entity string, id int64) error {
+	_, err := db.Exec(ctx, `DELETE FROM translations WHERE entity_type = $1 AND entity_id = $2`, entity, id)
+	return err
+}
@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"autotm-admin/internal/models"
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	slog "github.com/salamsites/package-log"
+	spsql "github.com/salamsites/package-psql"
+	"maps"
+	"strings"
+	"testing"
+)
+
+// fakeTranslationsTx keeps the translations table in memory and records the
+// arguments of the UPDATE that writes the legacy columns.
+type fakeTranslationsTx struct {
+	pgx.Tx
+	translations map[string]map[string]string
+	updateArgs   []interface{}
+}
+
+func translationKey(entity string, id int64, field string) string {
+	return fmt.Sprintf("%s/%d/%s", entity, id, field)
+}
+
+func (tx *fakeTranslationsTx) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	sql = strings.TrimSpace(sql)
+	switch {
+	case strings.HasPrefix(sql, "DELETE FROM translations"):
+		delete(tx.translations, translationKey(args[0].(string), args[1].(int64), args[2].(string)))
+	case strings.HasPrefix(sql, "INSERT INTO translations"):
+		key := translationKey(args[0].(string), args[1].(int64), args[2].(string))
+		if tx.translations[key] == nil {
+			tx.translations[key] = make(map[string]string)
+		}
+		tx.translations[key][args[3].(string)] = args[4].(string)
+	default:
+		return pgconn.CommandTag{}, fmt.Errorf("unexpected exec: %s", sql)
+	}
+	return pgconn.CommandTag{}, nil
+}
+
+func (tx *fakeTranslationsTx) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	if !strings.HasPrefix(strings.TrimSpace(sql), "SELECT locale, value FROM translations") {
+		return nil, fmt.Errorf("unexpected query: %s", sql)
+	}
+	rows := &fakeTranslationRows{}
+	for locale, value := range tx.translations[translationKey(args[0].(string), args[1].(int64), args[2].(string))] {
+		rows.values = append(rows.values, [2]string{locale, value})
+	}
+	return rows, nil
+}
+
+func (tx *fakeTranslationsTx) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	tx.updateArgs = args
+	return fakeIDRow{id: args[len(args)-1].(int64)}
+}
+
+func (tx *fakeTranslationsTx) Commit(ctx context.Context) error   { return nil }
+func (tx *fakeTranslationsTx) Rollback(ctx context.Context) error { return nil }
+
+type fakeTranslationRows struct {
+	pgx.Rows
+	values [][2]string
+	next   int
+}
+
+func (r *fakeTranslationRows) Next() bool {
+	r.next++
+	return r.next <= len(r.values)
+}
+
+func (r *fakeTranslationRows) Scan(dest ...interface{}) error {
+	*dest[0].(*string), *dest[1].(*string) = r.values[r.next-1][0], r.values[r.next-1][1]
+	return nil
+}
+
+func (r *fakeTranslationRows) Err() error { return nil }
+func (r *fakeTranslationRows) Close()     {}
+
+type fakeIDRow struct {
+	id int64
+}
+
+func (r fakeIDRow) Scan(dest ...interface{}) error {
+	*dest[0].(*int64) = r.id
+	return nil
+}
+
+type fakeTxClient struct {
+	spsql.Client
+	tx pgx.Tx
+}
+
+func (c fakeTxClient) Begin(ctx context.Context) (pgx.Tx, error) {
+	return c.tx, nil
+}
+
+func TestUpdateKeepsLegacyColumnsAndTranslationsEqual(t *testing.T) {
+	logger := slog.GetLogger(t.TempDir(), "test.log")
+	stored := map[string]string{"tm": "Aşgabat", "en": "Ashgabat", "ru": "Ашхабад", "tr": "Aşkabat"}
+
+	// update runs one repository update with values as the request's locale
+	// map and no flat values, the way the services pass a partial update.
+	tests := []struct {
+		name   string
+		entity string
+		field  string
+		update func(client spsql.Client, values map[string]string) error
+		// positions of the tm, en and ru columns in the UPDATE arguments
+		columns [3]int
+	}{
+		{
+			name: "region", entity: entityRegions, field: fieldName, columns: [3]int{0, 2, 1},
+			update: func(client spsql.Client, values map[string]string) error {
+				_, err := NewRegionsPsqlRepository(logger, client).UpdateRegion(context.Background(), models.Region{ID: 1, Names: values})
+				return err
+			},
+		},
+		{
+			name: "city", entity: entityCities, field: fieldName, columns: [3]int{0, 2, 1},
+			update: func(client spsql.Client, values map[string]string) error {
+				_, err := NewRegionsPsqlRepository(logger, client).UpdateCity(context.Background(), models.City{ID: 1, Names: values})
+				return err
+			},
+		},
+		{
+			name: "district", entity: entityDistricts, field: fieldName, columns: [3]int{0, 2, 1},
+			update: func(client spsql.Client, values map[string]string) error {
+				_, err := NewRegionsPsqlRepository(logger, client).UpdateDistrict(context.Background(), models.District{ID: 1, Names: values})
+				return err
+			},
+		},
+		{
+			name: "category", entity: entityCategories, field: fieldName, columns: [3]int{1, 2, 3},
+			update: func(client spsql.Client, values map[string]string) error {
+				_, err := NewCategoryPsqlRepository(logger, client).UpdateCategory(context.Background(), models.Category{ID: 1, Names: values})
+				return err
+			},
+		},
+		{
+			name: "body type", entity: entityBodyTypes, field: fieldName, columns: [3]int{0, 1, 2},
+			update: func(client spsql.Client, values map[string]string) error {
+				_, err := NewBrandPsqlRepository(logger, client).UpdateBodyType(context.Background(), models.BodyType{ID: 1, Names: values})
+				return err
+			},
+		},
+		{
+			name: "slider", entity: entitySliders, field: fieldImagePath, columns: [3]int{0, 1, 2},
+			update: func(client spsql.Client, values map[string]string) error {
+				_, err := NewSliderPsqlRepository(logger, client).UpdateSlider(context.Background(), models.Slider{ID: 1, ImagePaths: values})
+				return err
+			},
+		},
+		{
+			name: "slider variant", entity: entitySliderVariants, field: fieldImagePath, columns: [3]int{2, 3, 4},
+			update: func(client spsql.Client, values map[string]string) error {
+				_, err := NewSliderPsqlRepository(logger, client).UpdateSliderVariant(context.Background(), models.SliderVariant{ID: 1, ImagePaths: values})
+				return err
+			},
+		},
+	}
+
+	updates := []struct {
+		name   string
+		values map[string]string
+		want   map[string]string
+	}{
+		{
+			name:   "new locale only",
+			values: map[string]string{"de": "Aschgabat"},
+			want:   map[string]string{"tm": "Aşgabat", "en": "Ashgabat", "ru": "Ашхабад", "tr": "Aşkabat", "de": "Aschgabat"},
+		},
+		{
+			name:   "one legacy locale",
+			values: map[string]string{"en": "Ashgabat city"},
+			want:   map[string]string{"tm": "Aşgabat", "en": "Ashgabat city", "ru": "Ашхабад", "tr": "Aşkabat"},
+		},
+		{
+			name:   "legacy locale removed",
+			values: map[string]string{"ru": ""},
+			want:   map[string]string{"tm": "Aşgabat", "en": "Ashgabat", "tr": "Aşkabat"},
+		},
+	}
+
+	for _, tt := range tests {
+		for _, u := range updates {
+			t.Run(tt.name+"/"+u.name, func(t *testing.T) {
+				key := translationKey(tt.entity, 1, tt.field)
+				tx := &fakeTranslationsTx{translations: map[string]map[string]string{key: maps.Clone(stored)}}
+
+				if err := tt.update(fakeTxClient{tx: tx}, u.values); err != nil {
+					t.Fatalf("update err = %v", err)
+				}
+
+				if got := tx.translations[key]; !maps.Equal(got, u.want) {
+					t.Errorf("translations = %v, want %v", got, u.want)
+				}
+				for i, locale := range []string{"tm", "en", "ru"} {
+					if got := tx.updateArgs[tt.columns[i]]; got != u.want[locale] {
+						t.Errorf("%s column = %v, want %q", locale, got, u.want[locale])
+					}
+				}
+			})
+		}
+	}
+}
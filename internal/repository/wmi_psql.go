@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"autotm-admin/internal/models"
+	"context"
+)
+
+func (r *BrandPsqlRepository) CreateWMICode(ctx context.Context, code models.WMICode) (int64, error) {
+	var id int64
+
+	query := `
+		INSERT INTO wmi_codes (code, brand_id, manufacturer, country)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+	err := r.client.QueryRow(ctx, query, code.Code, code.BrandID, code.Manufacturer, code.Country).Scan(&id)
+	if err != nil {
+		r.logger.Errorf("create wmi code err: %v", err)
+		return id, err
+	}
+	return id, nil
+}
+
+func (r *BrandPsqlRepository) GetWMICodes(ctx context.Context, limit, page int64, brandID int64, search string) ([]models.WMICode, int64, error) {
+	var (
+		codes []models.WMICode
+		count int64
+	)
+
+	query := `
+		SELECT
+			w.id, w.code, w.brand_id, b.name, w.manufacturer, COALESCE(w.country, '')
+		FROM wmi_codes w
+			JOIN brands b ON b.id = w.brand_id
+		WHERE ($1 = 0 OR w.brand_id = $1) AND
+			(w.code ILIKE '%' || $2 || '%' OR w.manufacturer ILIKE '%' || $2 || '%' OR b.name ILIKE '%' || $2 || '%')
+		ORDER BY w.code
+		LIMIT $3 OFFSET $4;
+	`
+
+	rows, err := r.client.Query(ctx, query, brandID, search, limit, page)
+	if err != nil {
+		r.logger.Errorf("get wmi codes query err : %v", err)
+		return nil, 0, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var code models.WMICode
+		if err = rows.Scan(&code.ID, &code.Code, &code.BrandID, &code.BrandName, &code.Manufacturer, &code.Country); err != nil {
+			r.logger.Errorf("get wmi codes scan err : %v", err)
+			return nil, 0, err
+		}
+		codes = append(codes, code)
+	}
+
+	queryCount := `
+		SELECT
+			COUNT(w.id)
+		FROM wmi_codes w
+			JOIN brands b ON b.id = w.brand_id
+		WHERE ($1 = 0 OR w.brand_id = $1) AND
+			(w.code ILIKE '%' || $2 || '%' OR w.manufacturer ILIKE '%' || $2 || '%' OR b.name ILIKE '%' || $2 || '%')
+	`
+	err = r.client.QueryRow(ctx, queryCount, brandID, search).Scan(&count)
+	if err != nil {
+		r.logger.Errorf("get wmi codes count err : %v", err)
+		return nil, 0, err
+	}
+	return codes, count, nil
+}
+
+func (r *BrandPsqlRepository) UpdateWMICode(ctx context.Context, code models.WMICode) (int64, error) {
+	var id int64
+
+	query := `
+		UPDATE wmi_codes SET
+			code = $1, brand_id = $2, manufacturer = $3, country = $4, updated_at = NOW()
+		WHERE id = $5
+		RETURNING id
+	`
+	err := r.client.QueryRow(ctx, query, code.Code, code.BrandID, code.Manufacturer, code.Country, code.ID).Scan(&id)
+	if err != nil {
+		r.logger.Errorf("update wmi code err: %v", err)
+		return id, err
+	}
+	return id, nil
+}
+
+func (r *BrandPsqlRepository) DeleteWMICode(ctx context.Context, id models.ID) error {
+	query := `DELETE FROM wmi_codes WHERE id = $1`
+	_, err := r.client.Exec(ctx, query, id.ID)
+	if err != nil {
+		r.logger.Errorf("delete wmi code err: %v", err)
+		return err
+	}
+	return nil
+}
+
+// GetWMICodeByVIN returns the longest WMI prefix matching the VIN, so a
+// three-character code wins over a two-character one.
+func (r *BrandPsqlRepository) GetWMICodeByVIN(ctx context.Context, vin string) (models.WMICode, error) {
+	var code models.WMICode
+
+	query := `
+		SELECT
+			w.id, w.code, w.brand_id, b.name, w.manufacturer, COALESCE(w.country, '')
+		FROM wmi_codes w
+			JOIN brands b ON b.id = w.brand_id
+		WHERE $1 LIKE w.code || '%'
+		ORDER BY length(w.code) DESC
+		LIMIT 1
+	`
+	err := r.client.QueryRow(ctx, query, vin).Scan(&code.ID, &code.Code, &code.BrandID, &code.BrandName,
+		&code.Manufacturer, &code.Country)
+	if err != nil {
+		return code, err
+	}
+	return code, nil
+}
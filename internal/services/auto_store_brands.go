@@ -0,0 +1,63 @@
+package services
+
+import (
+	"autotm-admin/internal/dtos"
+	"autotm-admin/internal/helpers"
+	"autotm-admin/internal/models"
+	"context"
+	"strings"
+)
+
+// SetAutoStoreBrands replaces the brands a store works with. Repeated links are
+// saved once.
+func (s *AutoStoreService) SetAutoStoreBrands(ctx context.Context, req dtos.SetAutoStoreBrandsReq) error {
+	validate := helpers.GetValidator()
+	if err := validate.Struct(req); err != nil {
+		s.logger.Errorf("validate err: %v", err)
+		return err
+	}
+
+	brands := make([]models.AutoStoreBrand, 0, len(req.Brands))
+	seen := make(map[models.AutoStoreBrand]struct{}, len(req.Brands))
+	for _, b := range req.Brands {
+		brand := models.AutoStoreBrand{
+			BrandID:  b.BrandID,
+			Category: strings.ToLower(strings.TrimSpace(b.Category)),
+			Role:     b.Role,
+		}
+		if _, ok := seen[brand]; ok {
+			continue
+		}
+		seen[brand] = struct{}{}
+		brands = append(brands, brand)
+	}
+
+	if err := s.repo.SetAutoStoreBrands(ctx, req.AutoStoreID, brands); err != nil {
+		s.logger.Errorf("set auto store brands err: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (s *AutoStoreService) GetAutoStoreBrands(ctx context.Context, autoStoreID int64) ([]dtos.AutoStoreBrand, error) {
+	brands, err := s.repo.GetAutoStoreBrands(ctx, []int64{autoStoreID})
+	if err != nil {
+		s.logger.Errorf("get auto store brands err: %v", err)
+		return nil, err
+	}
+	return toAutoStoreBrandDTOs(brands[autoStoreID]), nil
+}
+
+func toAutoStoreBrandDTOs(brands []models.AutoStoreBrand) []dtos.AutoStoreBrand {
+	result := make([]dtos.AutoStoreBrand, 0, len(brands))
+	for _, brand := range brands {
+		result = append(result, dtos.AutoStoreBrand{
+			BrandID:   brand.BrandID,
+			BrandName: brand.BrandName,
+			LogoPath:  brand.BrandLogoPath,
+			Category:  brand.Category,
+			Role:      brand.Role,
+		})
+	}
+	return result
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"autotm-admin/internal/dtos"
+	"autotm-admin/internal/helpers"
+	"autotm-admin/internal/models"
+	"fmt"
+	"slices"
+)
+
+// storeContacts normalizes the contact list of a create or update request.
+// Requests without contacts fall back to the single phone number and email
+// fields. The returned phone number and email are the first contacts of those
+// types, kept on the store for clients that still read them.
+func storeContacts(req []dtos.AutoStoreContact, phoneNumber, email string) ([]models.AutoStoreContact, string, string, error) {
+	if len(req) == 0 {
+		if phoneNumber != "" {
+			req = append(req, dtos.AutoStoreContact{Type: models.ContactPhone, Value: phoneNumber})
+		}
+		if email != "" {
+			req = append(req, dtos.AutoStoreContact{Type: models.ContactEmail, Value: email})
+		}
+	}
+
+	contacts := make([]models.AutoStoreContact, 0, len(req))
+	seen := make(map[models.AutoStoreContact]struct{}, len(req))
+	phoneNumber, email = "", ""
+	for _, c := range req {
+		value, err := helpers.NormalizeContact(c.Type, c.Value)
+		if err != nil {
+			return nil, "", "", err
+		}
+		contact := models.AutoStoreContact{Type: c.Type, Value: value}
+		if _, ok := seen[contact]; ok {
+			return nil, "", "", fmt.Errorf("%w: duplicate %s contact %q", helpers.ErrInvalidContact, c.Type, value)
+		}
+		seen[contact] = struct{}{}
+		contacts = append(contacts, contact)
+
+		if c.Type == models.ContactPhone && phoneNumber == "" {
+			phoneNumber = value
+		}
+		if c.Type == models.ContactEmail && email == "" {
+			email = value
+		}
+	}
+	return contacts, phoneNumber, email, nil
+}
+
+// legacyContacts applies the phone number and email of an update without a
+// contact list to the stored contacts: each replaces the first contact of its
+// type or is added when there is none, and an empty value removes that
+// contact. The other contacts keep their values and order.
+func legacyContacts(stored []models.AutoStoreContact, phoneNumber, email string) []dtos.AutoStoreContact {
+	contacts := toAutoStoreContactDTOs(stored)
+	for _, legacy := range []dtos.AutoStoreContact{
+		{Type: models.ContactPhone, Value: phoneNumber},
+		{Type: models.ContactEmail, Value: email},
+	} {
+		i := slices.IndexFunc(contacts, func(c dtos.AutoStoreContact) bool { return c.Type == legacy.Type })
+		switch {
+		case i < 0 && legacy.Value != "":
+			contacts = append(contacts, legacy)
+		case i >= 0 && legacy.Value == "":
+			contacts = slices.Delete(contacts, i, i+1)
+		case i >= 0:
+			contacts[i].Value = legacy.Value
+		}
+	}
+	return contacts
+}
+
+func toAutoStoreContactDTOs(contacts []models.AutoStoreContact) []dtos.AutoStoreContact {
+	result := make([]dtos.AutoStoreContact, 0, len(contacts))
+	for _, contact := range contacts {
+		result = append(result, dtos.AutoStoreContact{
+			Type:  contact.Type,
+			Value: contact.Value,
+		})
+	}
+	return result
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"autotm-admin/internal/dtos"
+	"autotm-admin/internal/helpers"
+	"autotm-admin/internal/models"
+	"slices"
+	"testing"
+)
+
+func TestLegacyContacts(t *testing.T) {
+	stored := []models.AutoStoreContact{
+		{Type: models.ContactWhatsApp, Value: "+99365000001"},
+		{Type: models.ContactPhone, Value: "+99365000002"},
+		{Type: models.ContactTelegram, Value: "autotm_store"},
+		{Type: models.ContactPhone, Value: "+99365000003"},
+		{Type: models.ContactEmail, Value: "info@autotm.com"},
+	}
+
+	tests := []struct {
+		name        string
+		stored      []models.AutoStoreContact
+		phoneNumber string
+		email       string
+		want        []dtos.AutoStoreContact
+	}{
+		{
+			name:        "unchanged values keep every contact",
+			stored:      stored,
+			phoneNumber: "+99365000002",
+			email:       "info@autotm.com",
+			want: []dtos.AutoStoreContact{
+				{Type: models.ContactWhatsApp, Value: "+99365000001"},
+				{Type: models.ContactPhone, Value: "+99365000002"},
+				{Type: models.ContactTelegram, Value: "autotm_store"},
+				{Type: models.ContactPhone, Value: "+99365000003"},
+				{Type: models.ContactEmail, Value: "info@autotm.com"},
+			},
+		},
+		{
+			name:        "new values replace the first phone and email",
+			stored:      stored,
+			phoneNumber: "+99361111111",
+			email:       "sales@autotm.com",
+			want: []dtos.AutoStoreContact{
+				{Type: models.ContactWhatsApp, Value: "+99365000001"},
+				{Type: models.ContactPhone, Value: "+99361111111"},
+				{Type: models.ContactTelegram, Value: "autotm_store"},
+				{Type: models.ContactPhone, Value: "+99365000003"},
+				{Type: models.ContactEmail, Value: "sales@autotm.com"},
+			},
+		},
+		{
+			name:   "empty values remove the first phone and email",
+			stored: stored,
+			want: []dtos.AutoStoreContact{
+				{Type: models.ContactWhatsApp, Value: "+99365000001"},
+				{Type: models.ContactTelegram, Value: "autotm_store"},
+				{Type: models.ContactPhone, Value: "+99365000003"},
+			},
+		},
+		{
+			name:        "missing types are added",
+			stored:      []models.AutoStoreContact{{Type: models.ContactInstagram, Value: "autotm"}},
+			phoneNumber: "+99365000002",
+			email:       "info@autotm.com",
+			want: []dtos.AutoStoreContact{
+				{Type: models.ContactInstagram, Value: "autotm"},
+				{Type: models.ContactPhone, Value: "+99365000002"},
+				{Type: models.ContactEmail, Value: "info@autotm.com"},
+			},
+		},
+		{
+			name:        "no stored contacts",
+			phoneNumber: "+99365000002",
+			want:        []dtos.AutoStoreContact{{Type: models.ContactPhone, Value: "+99365000002"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := legacyContacts(tt.stored, tt.phoneNumber, tt.email)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("legacyContacts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if stored[1].Value != "+99365000002" || len(stored) != 5 {
+		t.Errorf("legacyContacts modified the stored contacts: %v", stored)
+	}
+}
+
+func TestUpdateAutoStoreReqContactsValidation(t *testing.T) {
+	validate := helpers.GetValidator()
+
+	if err := validate.Struct(dtos.UpdateAutoStoreReq{StoreName: "AutoTM"}); err != nil {
+		t.Errorf("request without contacts err = %v", err)
+	}
+
+	invalid := []dtos.AutoStoreContact{{Type: "fax", Value: "+99312000000"}}
+	if err := validate.Struct(dtos.UpdateAutoStoreReq{StoreName: "AutoTM", Contacts: &invalid}); err == nil {
+		t.Errorf("request with an invalid contact type was accepted")
+	}
+}
@@ -0,0 +1,21 @@
+package services
+
+import (
+	"autotm-admin/internal/helpers"
+	"context"
+	"fmt"
+)
+
+// checkGalleryLimit rejects more gallery images than the plan of the store's
+// running subscription allows. A store already over the limit, for example
+// after moving to a smaller plan, may keep its images but not add more.
+func (s *AutoStoreService) checkGalleryLimit(ctx context.Context, autoStoreID int64, images int) error {
+	current, limit, err := s.repo.GetAutoStoreGalleryLimit(ctx, autoStoreID)
+	if err != nil {
+		return err
+	}
+	if limit == nil || int64(images) <= *limit || int64(images) <= current {
+		return nil
+	}
+	return fmt.Errorf("%w: the plan of auto store %d allows %d gallery images", helpers.ErrPlanLimitExceeded, autoStoreID, *limit)
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"autotm-admin/internal/dtos"
+	"autotm-admin/internal/helpers"
+	"autotm-admin/internal/models"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	// embed the time zone database so the configured zone loads on hosts without one
+	_ "time/tzdata"
+)
+
+// scheduleLookaheadDays bounds how far ahead the next opening time is searched.
+const scheduleLookaheadDays = 14
+
+func (s *AutoStoreService) SetAutoStoreSchedule(ctx context.Context, schedule dtos.AutoStoreSchedule) error {
+	validate := helpers.GetValidator()
+	if err := validate.Struct(schedule); err != nil {
+		s.logger.Errorf("validate err: %v", err)
+		return err
+	}
+
+	newSchedule := models.AutoStoreSchedule{AutoStoreID: schedule.AutoStoreID}
+	for _, hours := range schedule.Hours {
+		opensAt, err := parseClock(hours.OpensAt)
+		if err != nil {
+			return err
+		}
+		closesAt, err := parseClock(hours.ClosesAt)
+		if err != nil {
+			return err
+		}
+		if closesAt <= opensAt {
+			return fmt.Errorf("%w: %s-%s closes before it opens", helpers.ErrInvalidSchedule, hours.OpensAt, hours.ClosesAt)
+		}
+		newSchedule.Hours = append(newSchedule.Hours, models.OpeningInterval{
+			Weekday:  hours.Weekday,
+			OpensAt:  opensAt,
+			ClosesAt: closesAt,
+		})
+	}
+
+	sort.Slice(newSchedule.Hours, func(i, j int) bool {
+		a, b := newSchedule.Hours[i], newSchedule.Hours[j]
+		if a.Weekday != b.Weekday {
+			return a.Weekday < b.Weekday
+		}
+		return a.OpensAt < b.OpensAt
+	})
+	for i := 1; i < len(newSchedule.Hours); i++ {
+		prev, cur := newSchedule.Hours[i-1], newSchedule.Hours[i]
+		if prev.Weekday == cur.Weekday && cur.OpensAt < prev.ClosesAt {
+			return fmt.Errorf("%w: intervals overlap on weekday %d", helpers.ErrInvalidSchedule, cur.Weekday)
+		}
+	}
+
+	seen := make(map[string]bool, len(schedule.Closures))
+	for _, closure := range schedule.Closures {
+		if seen[closure.Date] {
+			return fmt.Errorf("%w: closure %s is listed twice", helpers.ErrInvalidSchedule, closure.Date)
+		}
+		seen[closure.Date] = true
+
+		date, err := time.Parse(time.DateOnly, closure.Date)
+		if err != nil {
+			return fmt.Errorf("%w: %v", helpers.ErrInvalidSchedule, err)
+		}
+		newSchedule.Closures = append(newSchedule.Closures, models.StoreClosure{Date: date, Reason: closure.Reason})
+	}
+
+	if err := s.repo.SetAutoStoreSchedule(ctx, newSchedule); err != nil {
+		s.logger.Errorf("set auto store schedule err: %v", err)
+		return err
+	}
+	return nil
+}
+
+// GetAutoStoreSchedule returns the weekly hours of a store and its closures from today on.
+func (s *AutoStoreService) GetAutoStoreSchedule(ctx context.Context, autoStoreID int64) (dtos.AutoStoreSchedule, error) {
+	now := time.Now().In(s.location)
+	schedules, err := s.repo.GetAutoStoreSchedules(ctx, []int64{autoStoreID}, startOfDay(now))
+	if err != nil {
+		s.logger.Errorf("get auto store schedule err: %v", err)
+		return dtos.AutoStoreSchedule{}, err
+	}
+	schedule := schedules[autoStoreID]
+
+	result := dtos.AutoStoreSchedule{
+		AutoStoreID: autoStoreID,
+		TimeZone:    s.location.String(),
+		Hours:       []dtos.OpeningHours{},
+		Closures:    []dtos.StoreClosure{},
+	}
+	for _, interval := range schedule.Hours {
+		result.Hours = append(result.Hours, dtos.OpeningHours{
+			Weekday:  interval.Weekday,
+			OpensAt:  formatClock(interval.OpensAt),
+			ClosesAt: formatClock(interval.ClosesAt),
+		})
+	}
+	for _, closure := range schedule.Closures {
+		result.Closures = append(result.Closures, dtos.StoreClosure{
+			Date:   closure.Date.Format(time.DateOnly),
+			Reason: closure.Reason,
+		})
+	}
+	return result, nil
+}
+
+// openStatus reports whether a store is open at now and, when it is not, the
+// next time it opens within scheduleLookaheadDays. now must be in the store's
+// time zone.
+func openStatus(schedule models.AutoStoreSchedule, now time.Time) (bool, *time.Time) {
+	if len(schedule.Hours) == 0 {
+		return false, nil
+	}
+
+	closed := make(map[string]bool, len(schedule.Closures))
+	for _, closure := range schedule.Closures {
+		closed[closure.Date.Format(time.DateOnly)] = true
+	}
+
+	today := startOfDay(now)
+	minute := now.Hour()*60 + now.Minute()
+	if !closed[today.Format(time.DateOnly)] {
+		for _, interval := range schedule.Hours {
+			if interval.Weekday == isoWeekday(today) && interval.OpensAt <= minute && minute < interval.ClosesAt {
+				return true, nil
+			}
+		}
+	}
+
+	for i := 0; i <= scheduleLookaheadDays; i++ {
+		day := today.AddDate(0, 0, i)
+		if closed[day.Format(time.DateOnly)] {
+			continue
+		}
+		for _, interval := range schedule.Hours {
+			if interval.Weekday != isoWeekday(day) {
+				continue
+			}
+			opening := day.Add(time.Duration(interval.OpensAt) * time.Minute)
+			if opening.After(now) {
+				return false, &opening
+			}
+		}
+	}
+	return false, nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// isoWeekday returns 1 for Monday through 7 for Sunday.
+func isoWeekday(t time.Time) int {
+	if t.Weekday() == time.Sunday {
+		return 7
+	}
+	return int(t.Weekday())
+}
+
+// parseClock parses HH:MM into minutes since midnight; 24:00 is accepted as the end of the day.
+func parseClock(value string) (int, error) {
+	hours, minutes, ok := strings.Cut(value, ":")
+	if ok && len(hours) == 2 && len(minutes) == 2 {
+		h, errH := strconv.Atoi(hours)
+		m, errM := strconv.Atoi(minutes)
+		if errH == nil && errM == nil && h >= 0 && m >= 0 && m < 60 && (h < 24 || h == 24 && m == 0) {
+			return h*60 + m, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q is not a HH:MM time", helpers.ErrInvalidSchedule, value)
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"autotm-admin/internal/helpers"
+	"autotm-admin/internal/models"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{value: "00:00", want: 0},
+		{value: "09:30", want: 570},
+		{value: "23:59", want: 1439},
+		{value: "24:00", want: 1440},
+		{value: "24:01", wantErr: true},
+		{value: "25:00", wantErr: true},
+		{value: "12:60", wantErr: true},
+		{value: "9:30", wantErr: true},
+		{value: "09:3", wantErr: true},
+		{value: "0930", wantErr: true},
+		{value: "-1:00", wantErr: true},
+		{value: "ab:cd", wantErr: true},
+		{value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseClock(tt.value)
+			if tt.wantErr {
+				if !errors.Is(err, helpers.ErrInvalidSchedule) {
+					t.Fatalf("parseClock(%q) err = %v, want ErrInvalidSchedule", tt.value, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseClock(%q) err = %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseClock(%q) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenStatus(t *testing.T) {
+	// 2026-10-19 is a Monday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, time.October, day, hour, minute, 0, 0, time.UTC)
+	}
+	closure := func(day int) models.StoreClosure {
+		return models.StoreClosure{Date: time.Date(2026, time.October, day, 0, 0, 0, 0, time.UTC)}
+	}
+	mondays := []models.OpeningInterval{{Weekday: 1, OpensAt: 9 * 60, ClosesAt: 18 * 60}}
+
+	tests := []struct {
+		name     string
+		schedule models.AutoStoreSchedule
+		now      time.Time
+		wantOpen bool
+		wantNext *time.Time
+	}{
+		{
+			name:     "no hours",
+			schedule: models.AutoStoreSchedule{},
+			now:      at(19, 10, 0),
+		},
+		{
+			name:     "open",
+			schedule: models.AutoStoreSchedule{Hours: mondays},
+			now:      at(19, 10, 0),
+			wantOpen: true,
+		},
+		{
+			name:     "before opening",
+			schedule: models.AutoStoreSchedule{Hours: mondays},
+			now:      at(19, 8, 0),
+			wantNext: timePtr(at(19, 9, 0)),
+		},
+		{
+			name:     "closing time is exclusive",
+			schedule: models.AutoStoreSchedule{Hours: mondays},
+			now:      at(19, 18, 0),
+			wantNext: timePtr(at(26, 9, 0)),
+		},
+		{
+			name: "between intervals",
+			schedule: models.AutoStoreSchedule{Hours: []models.OpeningInterval{
+				{Weekday: 1, OpensAt: 9 * 60, ClosesAt: 12 * 60},
+				{Weekday: 1, OpensAt: 14 * 60, ClosesAt: 18 * 60},
+			}},
+			now:      at(19, 13, 0),
+			wantNext: timePtr(at(19, 14, 0)),
+		},
+		{
+			name:     "open until 24:00",
+			schedule: models.AutoStoreSchedule{Hours: []models.OpeningInterval{{Weekday: 1, OpensAt: 0, ClosesAt: 24 * 60}}},
+			now:      at(19, 23, 59),
+			wantOpen: true,
+		},
+		{
+			name:     "closure today",
+			schedule: models.AutoStoreSchedule{Hours: mondays, Closures: []models.StoreClosure{closure(19)}},
+			now:      at(19, 10, 0),
+			wantNext: timePtr(at(26, 9, 0)),
+		},
+		{
+			name:     "closures skip to the next open day",
+			schedule: models.AutoStoreSchedule{Hours: mondays, Closures: []models.StoreClosure{closure(19), closure(26)}},
+			now:      at(19, 10, 0),
+			wantNext: timePtr(time.Date(2026, time.November, 2, 9, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "closed for the whole lookahead",
+			schedule: models.AutoStoreSchedule{Hours: mondays, Closures: []models.StoreClosure{
+				closure(19), closure(26), {Date: time.Date(2026, time.November, 2, 0, 0, 0, 0, time.UTC)},
+			}},
+			now: at(19, 10, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			open, next := openStatus(tt.schedule, tt.now)
+			if open != tt.wantOpen {
+				t.Errorf("open = %v, want %v", open, tt.wantOpen)
+			}
+			switch {
+			case next == nil && tt.wantNext == nil:
+			case next == nil || tt.wantNext == nil:
+				t.Errorf("next = %v, want %v", next, tt.wantNext)
+			case !next.Equal(*tt.wantNext):
+				t.Errorf("next = %v, want %v", *next, *tt.wantNext)
+			}
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"autotm-admin/internal/dtos"
+	"autotm-admin/internal/helpers"
+	"context"
+	"fmt"
+)
+
+// checkAutoStoreOwner makes sure userID is a user known to the user service.
+// MaxStoresPerUser is enforced by the repository in the transaction that saves
+// the store.
+func (s *AutoStoreService) checkAutoStoreOwner(ctx context.Context, userID int64) error {
+	if userID <= 0 {
+		return fmt.Errorf("%w: user_id is required", helpers.ErrInvalidOwner)
+	}
+
+	users, err := s.userService.GetUserByIds(ctx, dtos.GetUserByIDsReq{Ids: []int64{userID}})
+	if err != nil {
+		s.logger.Errorf("get user by ids err: %v", err)
+		return err
+	}
+	found := false
+	for _, user := range users {
+		if user.Id == userID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("%w: user %d does not exist", helpers.ErrInvalidOwner, userID)
+	}
+	return nil
+}
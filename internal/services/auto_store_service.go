@@ -1,26 +1,43 @@
 package services
 
 import (
+	"autotm-admin/internal/configs"
 	"autotm-admin/internal/dtos"
 	"autotm-admin/internal/helpers"
 	"autotm-admin/internal/models"
 	"autotm-admin/internal/repository/storage"
 	"autotm-admin/internal/services/repository"
 	"context"
+	"fmt"
 	slog "github.com/salamsites/package-log"
+	"math"
+	"strings"
+	"time"
 )
 
 type AutoStoreService struct {
 	logger      *slog.Logger
 	repo        storage.AutoStoreRepository
 	userService repository.UserService
+	location    *time.Location
+
+	maxStoresPerUser int
 }
 
-func NewAutoStoreService(logger *slog.Logger, repo storage.AutoStoreRepository, userService repository.UserService) *AutoStoreService {
+func NewAutoStoreService(cfg *configs.Config, logger *slog.Logger, repo storage.AutoStoreRepository, userService repository.UserService) *AutoStoreService {
+	location, err := time.LoadLocation(cfg.TimeZone)
+	if err != nil {
+		logger.Errorf("load time zone %q err: %v, using UTC", cfg.TimeZone, err)
+		location = time.UTC
+	}
+
 	return &AutoStoreService{
 		logger:      logger,
 		repo:        repo,
 		userService: userService,
+		location:    location,
+
+		maxStoresPerUser: cfg.MaxStoresPerUser,
 	}
 }
 
@@ -31,19 +48,33 @@ func (s *AutoStoreService) CreateAutoStore(ctx context.Context, autoStore dtos.C
 		return 0, err
 	}
 
+	if err := s.checkAutoStoreOwner(ctx, autoStore.UserID); err != nil {
+		return 0, err
+	}
+
+	contacts, phoneNumber, email, err := storeContacts(autoStore.Contacts, autoStore.PhoneNumber, autoStore.Email)
+	if err != nil {
+		return 0, err
+	}
+
 	newAutoStore := models.AutoStore{
 		UserID:      autoStore.UserID,
-		PhoneNumber: autoStore.PhoneNumber,
-		Email:       autoStore.Email,
+		PhoneNumber: phoneNumber,
+		Email:       email,
 		StoreName:   autoStore.StoreName,
+		Slug:        helpers.Slugify(autoStore.StoreName),
 		Images:      autoStore.Images,
 		LogoPath:    autoStore.LogoPath,
 		Address:     autoStore.Address,
 		RegionID:    autoStore.RegionID,
 		CityID:      autoStore.CityID,
+		DistrictID:  autoStore.DistrictID,
+		Latitude:    autoStore.Latitude,
+		Longitude:   autoStore.Longitude,
+		Contacts:    contacts,
 	}
 
-	autoStoreID, err := s.repo.CreateAutoStore(ctx, newAutoStore)
+	autoStoreID, err := s.repo.CreateAutoStore(ctx, newAutoStore, s.maxStoresPerUser)
 	if err != nil {
 		s.logger.Errorf("create err: %v", err)
 		return autoStoreID, err
@@ -72,19 +103,144 @@ func (s *AutoStoreService) GetUsersFromUserService(ctx context.Context, limit, p
 	return result, nil
 }
 
-func (s *AutoStoreService) GetAutoStores(ctx context.Context, limit, page int64, search string) (dtos.AutoStoresResult, error) {
-	offset := (page - 1) * limit
-	if page <= 0 {
-		page = 1
+// GetAutoStores returns a filtered page of auto stores. Without a sort order,
+// dates sort newest first and names alphabetically.
+func (s *AutoStoreService) GetAutoStores(ctx context.Context, req dtos.AutoStoresFilter) (dtos.AutoStoresResult, error) {
+	validate := helpers.GetValidator()
+	if err := validate.Struct(req); err != nil {
+		s.logger.Errorf("validate err: %v", err)
+		return dtos.AutoStoresResult{}, err
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	if req.Page <= 0 {
+		req.Page = 1
 		offset = 0
 	}
 
-	autoStores, count, err := s.repo.GetAutoStores(ctx, limit, offset, search)
+	if !validAutoStoreStatus(req.Status) {
+		return dtos.AutoStoresResult{}, fmt.Errorf("%w: unknown status %q", helpers.ErrInvalidStatus, req.Status)
+	}
+	if req.CreatedFrom != nil && req.CreatedTo != nil && req.CreatedTo.Before(*req.CreatedFrom) {
+		return dtos.AutoStoresResult{}, fmt.Errorf("%w: created_to is before created_from", helpers.ErrInvalidDateRange)
+	}
+
+	filter := models.AutoStoreFilter{
+		Search:      req.Search,
+		Status:      req.Status,
+		RegionID:    req.RegionID,
+		CityID:      req.CityID,
+		UserID:      req.UserID,
+		BrandID:     req.BrandID,
+		Category:    req.Category,
+		BrandRole:   req.BrandRole,
+		Expired:     req.Expired,
+		CreatedFrom: req.CreatedFrom,
+		SortBy:      req.SortBy,
+		Limit:       req.Limit,
+		Offset:      offset,
+	}
+	if req.CreatedTo != nil {
+		createdTo := req.CreatedTo.AddDate(0, 0, 1)
+		filter.CreatedTo = &createdTo
+	}
+	if filter.SortBy == "" {
+		filter.SortBy = models.AutoStoreSortCreatedAt
+	}
+	switch req.Order {
+	case "asc":
+		filter.Desc = false
+	case "desc":
+		filter.Desc = true
+	default:
+		filter.Desc = filter.SortBy != models.AutoStoreSortName
+	}
+
+	autoStores, count, err := s.repo.GetAutoStores(ctx, filter)
 	if err != nil {
 		s.logger.Errorf("get autoStores err: %v", err)
 		return dtos.AutoStoresResult{}, err
 	}
 
+	dtoAutoStores, err := s.toAutoStoreDTOs(ctx, autoStores)
+	if err != nil {
+		return dtos.AutoStoresResult{}, err
+	}
+
+	result := dtos.AutoStoresResult{
+		AutoStores: dtoAutoStores,
+		Count:      count,
+	}
+
+	return result, nil
+}
+
+func (s *AutoStoreService) GetNearestAutoStores(ctx context.Context, req dtos.NearestAutoStoresReq) (dtos.AutoStoresResult, error) {
+	validate := helpers.GetValidator()
+	if err := validate.Struct(req); err != nil {
+		s.logger.Errorf("validate err: %v", err)
+		return dtos.AutoStoresResult{}, err
+	}
+
+	autoStores, err := s.repo.GetNearestAutoStores(ctx, req.Latitude, req.Longitude, req.RadiusKm, req.Limit)
+	if err != nil {
+		s.logger.Errorf("get nearest autoStores err: %v", err)
+		return dtos.AutoStoresResult{}, err
+	}
+
+	dtoAutoStores, err := s.toAutoStoreDTOs(ctx, autoStores)
+	if err != nil {
+		return dtos.AutoStoresResult{}, err
+	}
+	for i := range dtoAutoStores {
+		distance := math.Round(autoStores[i].DistanceKm*100) / 100
+		dtoAutoStores[i].DistanceKm = &distance
+	}
+
+	result := dtos.AutoStoresResult{
+		AutoStores: dtoAutoStores,
+		Count:      int64(len(dtoAutoStores)),
+	}
+
+	return result, nil
+}
+
+// GetAutoStoreBySlug returns the approved, unexpired store with the given
+// current or former slug. Former slugs are reported as redirects to the current one.
+func (s *AutoStoreService) GetAutoStoreBySlug(ctx context.Context, slug string) (dtos.AutoStoreBySlugResult, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	expired := false
+
+	filter := models.AutoStoreFilter{
+		Slug:    slug,
+		Status:  models.AutoStoreStatusApproved,
+		Expired: &expired,
+		SortBy:  models.AutoStoreSortCreatedAt,
+		Limit:   1,
+	}
+	autoStores, _, err := s.repo.GetAutoStores(ctx, filter)
+	if err != nil {
+		s.logger.Errorf("get auto store by slug err: %v", err)
+		return dtos.AutoStoreBySlugResult{}, err
+	}
+	if len(autoStores) == 0 {
+		return dtos.AutoStoreBySlugResult{}, fmt.Errorf("%w: auto store %q", helpers.ErrNotFound, slug)
+	}
+
+	dtoAutoStores, err := s.toAutoStoreDTOs(ctx, autoStores)
+	if err != nil {
+		return dtos.AutoStoreBySlugResult{}, err
+	}
+
+	result := dtos.AutoStoreBySlugResult{
+		AutoStore: dtoAutoStores[0],
+		Redirect:  dtoAutoStores[0].Slug != slug,
+	}
+	return result, nil
+}
+
+// toAutoStoreDTOs maps auto stores to DTOs, resolving owner names from the user service.
+func (s *AutoStoreService) toAutoStoreDTOs(ctx context.Context, autoStores []models.AutoStore) ([]dtos.AutoStore, error) {
 	userIDMap := make(map[int64]struct{})
 	var id dtos.GetUserByIDsReq
 	for _, a := range autoStores {
@@ -94,10 +250,12 @@ func (s *AutoStoreService) GetAutoStores(ctx context.Context, limit, page int64,
 		}
 	}
 
+	// Owner names are decoration: when the user service fails the stores are
+	// still listed, with the names marked unavailable.
 	users, err := s.userService.GetUserByIds(ctx, id)
+	userNameUnavailable := err != nil
 	if err != nil {
 		s.logger.Errorf("get user by ids err: %v", err)
-		return dtos.AutoStoresResult{}, err
 	}
 
 	userMap := make(map[int64]dtos.GetUsers)
@@ -105,36 +263,70 @@ func (s *AutoStoreService) GetAutoStores(ctx context.Context, limit, page int64,
 		userMap[user.Id] = user
 	}
 
+	now := time.Now().In(s.location)
+	storeIDs := make([]int64, 0, len(autoStores))
+	for _, a := range autoStores {
+		storeIDs = append(storeIDs, a.ID)
+	}
+	schedules, err := s.repo.GetAutoStoreSchedules(ctx, storeIDs, startOfDay(now))
+	if err != nil {
+		s.logger.Errorf("get auto store schedules err: %v", err)
+		return nil, err
+	}
+	contacts, err := s.repo.GetAutoStoreContacts(ctx, storeIDs)
+	if err != nil {
+		s.logger.Errorf("get auto store contacts err: %v", err)
+		return nil, err
+	}
+	brands, err := s.repo.GetAutoStoreBrands(ctx, storeIDs)
+	if err != nil {
+		s.logger.Errorf("get auto store brands err: %v", err)
+		return nil, err
+	}
+
 	var dtoAutoStores []dtos.AutoStore
 	for _, autoStore := range autoStores {
 		user := userMap[autoStore.UserID]
+		openNow, nextOpeningAt := openStatus(schedules[autoStore.ID], now)
 		dtoAutoStores = append(dtoAutoStores, dtos.AutoStore{
-			ID:           autoStore.ID,
-			PhoneNumber:  autoStore.PhoneNumber,
-			Email:        autoStore.Email,
-			StoreName:    autoStore.StoreName,
-			Images:       autoStore.Images,
-			LogoPath:     autoStore.LogoPath,
-			Address:      autoStore.Address,
-			CityID:       autoStore.CityID,
-			CityNameTM:   autoStore.CityNameTM,
-			CityNameEN:   autoStore.CityNameEN,
-			CityNameRU:   autoStore.CityNameRU,
-			RegionID:     autoStore.RegionID,
-			RegionNameTM: autoStore.RegionNameTM,
-			RegionNameEN: autoStore.RegionNameEN,
-			RegionNameRU: autoStore.RegionNameRU,
-			UserID:       autoStore.UserID,
-			UserName:     user.FullName,
+			ID:                  autoStore.ID,
+			PhoneNumber:         autoStore.PhoneNumber,
+			Email:               autoStore.Email,
+			StoreName:           autoStore.StoreName,
+			Slug:                autoStore.Slug,
+			Images:              autoStore.Images,
+			LogoPath:            autoStore.LogoPath,
+			Address:             autoStore.Address,
+			CityID:              autoStore.CityID,
+			CityNameTM:          autoStore.CityNameTM,
+			CityNameEN:          autoStore.CityNameEN,
+			CityNameRU:          autoStore.CityNameRU,
+			RegionID:            autoStore.RegionID,
+			RegionNameTM:        autoStore.RegionNameTM,
+			RegionNameEN:        autoStore.RegionNameEN,
+			RegionNameRU:        autoStore.RegionNameRU,
+			DistrictID:          autoStore.DistrictID,
+			DistrictNameTM:      autoStore.DistrictNameTM,
+			DistrictNameEN:      autoStore.DistrictNameEN,
+			DistrictNameRU:      autoStore.DistrictNameRU,
+			UserID:              autoStore.UserID,
+			UserName:            user.FullName,
+			UserNameUnavailable: userNameUnavailable,
+			Latitude:            autoStore.Latitude,
+			Longitude:           autoStore.Longitude,
+			OpenNow:             openNow,
+			NextOpeningAt:       nextOpeningAt,
+			Status:              autoStore.Status,
+			RejectionReason:     autoStore.RejectionReason,
+			ReviewedBy:          autoStore.ReviewedBy,
+			ReviewedAt:          autoStore.ReviewedAt,
+			IsExpired:           autoStore.IsExpired,
+			Contacts:            toAutoStoreContactDTOs(contacts[autoStore.ID]),
+			Brands:              toAutoStoreBrandDTOs(brands[autoStore.ID]),
 		})
 	}
 
-	result := dtos.AutoStoresResult{
-		AutoStores: dtoAutoStores,
-		Count:      count,
-	}
-
-	return result, nil
+	return dtoAutoStores, nil
 }
 
 func (s *AutoStoreService) UpdateAutoStore(ctx context.Context, autoStore dtos.UpdateAutoStoreReq) (dtos.ID, error) {
@@ -145,19 +337,48 @@ func (s *AutoStoreService) UpdateAutoStore(ctx context.Context, autoStore dtos.U
 		return id, err
 	}
 
+	if err := s.checkAutoStoreOwner(ctx, autoStore.UserID); err != nil {
+		return id, err
+	}
+	if err := s.checkGalleryLimit(ctx, autoStore.ID, len(autoStore.Images)); err != nil {
+		return id, err
+	}
+
+	var reqContacts []dtos.AutoStoreContact
+	if autoStore.Contacts != nil {
+		reqContacts = *autoStore.Contacts
+	} else {
+		stored, err := s.repo.GetAutoStoreContacts(ctx, []int64{autoStore.ID})
+		if err != nil {
+			s.logger.Errorf("get auto store contacts err: %v", err)
+			return id, err
+		}
+		reqContacts = legacyContacts(stored[autoStore.ID], autoStore.PhoneNumber, autoStore.Email)
+	}
+	contacts, phoneNumber, email, err := storeContacts(reqContacts, autoStore.PhoneNumber, autoStore.Email)
+	if err != nil {
+		return id, err
+	}
+
 	newAutoStore := models.AutoStore{
 		ID:          autoStore.ID,
-		PhoneNumber: autoStore.PhoneNumber,
-		Email:       autoStore.Email,
+		UserID:      autoStore.UserID,
+		PhoneNumber: phoneNumber,
+		Email:       email,
 		StoreName:   autoStore.StoreName,
+		Slug:        helpers.Slugify(autoStore.StoreName),
 		Images:      autoStore.Images,
 		LogoPath:    autoStore.LogoPath,
 		RegionID:    autoStore.RegionID,
 		CityID:      autoStore.CityID,
+		DistrictID:  autoStore.DistrictID,
 		Address:     autoStore.Address,
+		Latitude:    autoStore.Latitude,
+		Longitude:   autoStore.Longitude,
+		Contacts:    contacts,
 	}
 
-	autoStoreID, err := s.repo.UpdateAutoStore(ctx, newAutoStore)
+	autoStoreID, err := s.repo.UpdateAutoStore(ctx, newAutoStore, s.maxStoresPerUser)
 	if err != nil {
 		s.logger.Errorf("update auto store err: %v", err)
 		return id, err
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"autotm-admin/internal/dtos"
+	"autotm-admin/internal/helpers"
+	"autotm-admin/internal/models"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// autoStoreTransitions lists, for every target status, the statuses a store
+// may be moved from.
+var autoStoreTransitions = map[string][]string{
+	models.AutoStoreStatusApproved:  {models.AutoStoreStatusPending, models.AutoStoreStatusRejected, models.AutoStoreStatusSuspended},
+	models.AutoStoreStatusRejected:  {models.AutoStoreStatusPending},
+	models.AutoStoreStatusSuspended: {models.AutoStoreStatusApproved},
+}
+
+func (s *AutoStoreService) ApproveAutoStore(ctx context.Context, req dtos.ReviewAutoStoreReq) error {
+	return s.changeAutoStoreStatus(ctx, req, models.AutoStoreStatusApproved)
+}
+
+func (s *AutoStoreService) RejectAutoStore(ctx context.Context, req dtos.ReviewAutoStoreReq) error {
+	return s.changeAutoStoreStatus(ctx, req, models.AutoStoreStatusRejected)
+}
+
+func (s *AutoStoreService) SuspendAutoStore(ctx context.Context, req dtos.ReviewAutoStoreReq) error {
+	return s.changeAutoStoreStatus(ctx, req, models.AutoStoreStatusSuspended)
+}
+
+// changeAutoStoreStatus validates a review request and moves the store to status.
+// Rejections and suspensions must give a reason; approvals clear it.
+func (s *AutoStoreService) changeAutoStoreStatus(ctx context.Context, req dtos.ReviewAutoStoreReq, status string) error {
+	validate := helpers.GetValidator()
+	if err := validate.Struct(req); err != nil {
+		s.logger.Errorf("validate err: %v", err)
+		return err
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if status == models.AutoStoreStatusApproved {
+		reason = ""
+	} else if reason == "" {
+		return fmt.Errorf("%w: a reason is required to mark a store %s", helpers.ErrInvalidStatus, status)
+	}
+
+	reviewerID := req.ReviewerID
+	change := models.AutoStoreStatusChange{
+		ToStatus:  status,
+		Reason:    reason,
+		ChangedBy: &reviewerID,
+	}
+	if err := s.repo.ChangeAutoStoreStatus(ctx, req.AutoStoreID, autoStoreTransitions[status], change); err != nil {
+		s.logger.Errorf("change auto store status err: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (s *AutoStoreService) GetAutoStoreStatusHistory(ctx context.Context, autoStoreID int64) ([]dtos.AutoStoreStatusChange, error) {
+	history, err := s.repo.GetAutoStoreStatusHistory(ctx, autoStoreID)
+	if err != nil {
+		s.logger.Errorf("get auto store status history err: %v", err)
+		return nil, err
+	}
+
+	result := make([]dtos.AutoStoreStatusChange, 0, len(history))
+	for _, change := range history {
+		result = append(result, dtos.AutoStoreStatusChange{
+			ID:         change.ID,
+			FromStatus: change.FromStatus,
+			ToStatus:   change.ToStatus,
+			Reason:     change.Reason,
+			ChangedBy:  change.ChangedBy,
+			CreatedAt:  change.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// validAutoStoreStatus reports whether status is empty or a known review status.
+func validAutoStoreStatus(status string) bool {
+	switch status {
+	case "", models.AutoStoreStatusPending, models.AutoStoreStatusApproved,
+		models.AutoStoreStatusRejected, models.AutoStoreStatusSuspended:
+		return true
+	}
+	return false
+}
@@ -6,7 +6,12 @@ import (
 	"autotm-admin/internal/models"
 	"autotm-admin/internal/repository/storage"
 	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
 	slog "github.com/salamsites/package-log"
+	"slices"
+	"strings"
 )
 
 type BrandService struct {
@@ -29,12 +34,24 @@ func (s *BrandService) CreateBodyType(ctx context.Context, bodyType dtos.CreateB
 		return id, err
 	}
 
+	names := helpers.LocalizedValues(bodyType.Names, bodyType.NameTM, bodyType.NameEN, bodyType.NameRU)
+	if err := helpers.ValidateLocales(names); err != nil {
+		s.logger.Errorf("validate locales err: %v", err)
+		return id, err
+	}
+
+	categoryID, err := s.categoryID(ctx, bodyType.Category)
+	if err != nil {
+		return id, err
+	}
+
 	newBodyType := models.BodyType{
-		NameTM:    bodyType.NameTM,
-		NameEN:    bodyType.NameEN,
-		NameRU:    bodyType.NameRU,
-		ImagePath: bodyType.ImagePath,
-		Category:  bodyType.Category,
+		NameTM:     names["tm"],
+		NameEN:     names["en"],
+		NameRU:     names["ru"],
+		Names:      names,
+		ImagePath:  bodyType.ImagePath,
+		CategoryID: categoryID,
 	}
 
 	bodyTypeID, err := s.repo.CreateBodyType(ctx, newBodyType)
@@ -66,6 +83,7 @@ func (s *BrandService) GetBodyType(ctx context.Context, limit, page int64, categ
 			NameTM:    b.NameTM,
 			NameEN:    b.NameEN,
 			NameRU:    b.NameRU,
+			Names:     helpers.LocalizedValues(b.Names, b.NameTM, b.NameEN, b.NameRU),
 			ImagePath: b.ImagePath,
 			Category:  b.Category,
 		})
@@ -98,13 +116,25 @@ func (s *BrandService) UpdateBodyType(ctx context.Context, bodyType dtos.UpdateB
 		}
 	}
 
+	names := helpers.LocalizedValues(bodyType.Names, bodyType.NameTM, bodyType.NameEN, bodyType.NameRU)
+	if err = helpers.ValidateLocales(names); err != nil {
+		s.logger.Errorf("validate locales err: %v", err)
+		return id, err
+	}
+
+	categoryID, err := s.categoryID(ctx, bodyType.Category, oldBodyType.CategoryID)
+	if err != nil {
+		return id, err
+	}
+
 	newBodyType := models.BodyType{
-		ID:        bodyType.ID,
-		NameTM:    bodyType.NameTM,
-		NameEN:    bodyType.NameEN,
-		NameRU:    bodyType.NameRU,
-		ImagePath: bodyType.ImagePath,
-		Category:  bodyType.Category,
+		ID:         bodyType.ID,
+		NameTM:     names["tm"],
+		NameEN:     names["en"],
+		NameRU:     names["ru"],
+		Names:      names,
+		ImagePath:  bodyType.ImagePath,
+		CategoryID: categoryID,
 	}
 
 	bodyTypeID, err := s.repo.UpdateBodyType(ctx, newBodyType)
@@ -150,10 +180,15 @@ func (s *BrandService) CreateBrand(ctx context.Context, brand dtos.CreateBrandRe
 		return id, err
 	}
 
+	categoryIDs, err := s.categoryIDs(ctx, brand.Categories)
+	if err != nil {
+		return id, err
+	}
+
 	newBrand := models.Brand{
-		Name:       brand.Name,
-		LogoPath:   brand.LogoPath,
-		Categories: brand.Categories,
+		Name:        brand.Name,
+		LogoPath:    brand.LogoPath,
+		CategoryIDs: categoryIDs,
 	}
 
 	brandID, err := s.repo.CreateBrand(ctx, newBrand)
@@ -215,11 +250,16 @@ func (s *BrandService) UpdateBrand(ctx context.Context, brand dtos.UpdateBrandRe
 		}
 	}
 
+	categoryIDs, err := s.categoryIDs(ctx, brand.Categories, oldBrand.CategoryIDs...)
+	if err != nil {
+		return id, err
+	}
+
 	newBrand := models.Brand{
-		ID:         brand.ID,
-		Name:       brand.Name,
-		LogoPath:   brand.LogoPath,
-		Categories: brand.Categories,
+		ID:          brand.ID,
+		Name:        brand.Name,
+		LogoPath:    brand.LogoPath,
+		CategoryIDs: categoryIDs,
 	}
 
 	brandID, err := s.repo.UpdateBrand(ctx, newBrand)
@@ -266,10 +306,15 @@ func (s *BrandService) CreateModel(ctx context.Context, model dtos.CreateModelRe
 		return id, err
 	}
 
+	categoryID, err := s.categoryID(ctx, model.Category)
+	if err != nil {
+		return id, err
+	}
+
 	newModel := models.Model{
-		Name:     model.Name,
-		BrandID:  model.BrandID,
-		Category: model.Category,
+		Name:       model.Name,
+		BrandID:    model.BrandID,
+		CategoryID: categoryID,
 	}
 
 	modelID, err := s.repo.CreateModel(ctx, newModel)
@@ -321,11 +366,22 @@ func (s *BrandService) UpdateModel(ctx context.Context, model dtos.UpdateModelRe
 		return id, err
 	}
 
+	oldModel, err := s.repo.GetModelByID(ctx, model.ID)
+	if err != nil {
+		s.logger.Errorf("get old model err: %v", err)
+		return id, err
+	}
+
+	categoryID, err := s.categoryID(ctx, model.Category, oldModel.CategoryID)
+	if err != nil {
+		return id, err
+	}
+
 	newModel := models.Model{
-		ID:       model.ID,
-		Name:     model.Name,
-		BrandID:  model.BrandID,
-		Category: model.Category,
+		ID:         model.ID,
+		Name:       model.Name,
+		BrandID:    model.BrandID,
+		CategoryID: categoryID,
 	}
 
 	modelID, err := s.repo.UpdateModel(ctx, newModel)
@@ -349,3 +405,204 @@ func (s *BrandService) DeleteModel(ctx context.Context, id int64) error {
 	}
 	return nil
 }
+
+// categoryID resolves a category slug (auto, moto, truck, ...) to its id.
+// Unknown and inactive categories are ErrInvalidReference errors; an inactive
+// category listed in current, the categories the row already has, is accepted
+// so rows of a deactivated category stay editable.
+func (s *BrandService) categoryID(ctx context.Context, slug string, current ...int64) (int64, error) {
+	id, isActive, err := s.repo.GetCategoryIDBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("%w: unknown category: %q", helpers.ErrInvalidReference, slug)
+		}
+		s.logger.Errorf("get category id err: %v", err)
+		return 0, err
+	}
+	if !isActive && !slices.Contains(current, id) {
+		return 0, fmt.Errorf("%w: category %q is inactive", helpers.ErrInvalidReference, slug)
+	}
+	return id, nil
+}
+
+func (s *BrandService) categoryIDs(ctx context.Context, slugs []string, current ...int64) ([]int64, error) {
+	ids := make([]int64, 0, len(slugs))
+	for _, slug := range slugs {
+		id, err := s.categoryID(ctx, slug, current...)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (s *BrandService) CreateWMICode(ctx context.Context, code dtos.CreateWMICodeReq) (dtos.ID, error) {
+	var id dtos.ID
+	validate := helpers.GetValidator()
+	if err := validate.Struct(code); err != nil {
+		s.logger.Errorf("validate err: %v", err)
+		return id, err
+	}
+
+	newCode := models.WMICode{
+		Code:         strings.ToUpper(code.Code),
+		BrandID:      code.BrandID,
+		Manufacturer: code.Manufacturer,
+		Country:      code.Country,
+	}
+
+	codeID, err := s.repo.CreateWMICode(ctx, newCode)
+	if err != nil {
+		s.logger.Errorf("create wmi code err: %v", err)
+		return id, err
+	}
+	id.ID = codeID
+	return id, nil
+}
+
+func (s *BrandService) GetWMICodes(ctx context.Context, limit, page int64, brandID int64, search string) (dtos.WMICodeResult, error) {
+	offset := (page - 1) * limit
+	if page <= 0 {
+		page = 1
+		offset = 0
+	}
+
+	codes, count, err := s.repo.GetWMICodes(ctx, limit, offset, brandID, search)
+	if err != nil {
+		s.logger.Errorf("get wmi codes err: %v", err)
+		return dtos.WMICodeResult{}, err
+	}
+	var dtoCodes []dtos.WMICode
+	for _, c := range codes {
+		dtoCodes = append(dtoCodes, dtos.WMICode{
+			ID:           c.ID,
+			Code:         c.Code,
+			BrandID:      c.BrandID,
+			BrandName:    c.BrandName,
+			Manufacturer: c.Manufacturer,
+			Country:      c.Country,
+		})
+	}
+
+	result := dtos.WMICodeResult{
+		WMICodes: dtoCodes,
+		Count:    count,
+	}
+	return result, nil
+}
+
+func (s *BrandService) UpdateWMICode(ctx context.Context, code dtos.UpdateWMICodeReq) (dtos.ID, error) {
+	var id dtos.ID
+	validate := helpers.GetValidator()
+	if err := validate.Struct(code); err != nil {
+		s.logger.Errorf("validate err: %v", err)
+		return id, err
+	}
+
+	newCode := models.WMICode{
+		ID:           code.ID,
+		Code:         strings.ToUpper(code.Code),
+		BrandID:      code.BrandID,
+		Manufacturer: code.Manufacturer,
+		Country:      code.Country,
+	}
+
+	codeID, err := s.repo.UpdateWMICode(ctx, newCode)
+	if err != nil {
+		s.logger.Errorf("update wmi code err: %v", err)
+		return id, err
+	}
+	id.ID = codeID
+	return id, nil
+}
+
+func (s *BrandService) DeleteWMICode(ctx context.Context, id int64) error {
+	deleteID := models.ID{
+		ID: id,
+	}
+
+	err := s.repo.DeleteWMICode(ctx, deleteID)
+	if err != nil {
+		s.logger.Errorf("delete wmi code err: %v", err)
+		return err
+	}
+	return nil
+}
+
+// DecodeVIN validates a VIN and resolves its manufacturer and brand through the
+// WMI table. An unknown WMI is not an error; the brand fields stay empty.
+func (s *BrandService) DecodeVIN(ctx context.Context, vin string) (dtos.VINDecodeResult, error) {
+	decoded, err := helpers.DecodeVIN(vin)
+	if err != nil {
+		return dtos.VINDecodeResult{}, err
+	}
+
+	result := dtos.VINDecodeResult{
+		VIN:             decoded.VIN,
+		WMI:             decoded.WMI,
+		CheckDigit:      decoded.CheckDigit,
+		CheckDigitValid: decoded.CheckDigitValid,
+		ModelYearCode:   decoded.ModelYearCode,
+		ModelYears:      decoded.ModelYears,
+		Region:          decoded.Region,
+	}
+
+	code, err := s.repo.GetWMICodeByVIN(ctx, decoded.VIN)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return result, nil
+		}
+		s.logger.Errorf("get wmi code by vin err: %v", err)
+		return dtos.VINDecodeResult{}, err
+	}
+
+	result.Manufacturer = code.Manufacturer
+	result.Country = code.Country
+	result.BrandID = code.BrandID
+	result.BrandName = code.BrandName
+	return result, nil
+}
+
+func (s *BrandService) MergeBrands(ctx context.Context, req dtos.MergeReq) (dtos.MergeResult, error) {
+	validate := helpers.GetValidator()
+	if err := validate.Struct(req); err != nil {
+		s.logger.Errorf("validate err: %v", err)
+		return dtos.MergeResult{}, err
+	}
+
+	repointed, merged, err := s.repo.MergeBrands(ctx, req.SourceID, req.TargetID)
+	if err != nil {
+		s.logger.Errorf("merge brands err: %v", err)
+		return dtos.MergeResult{}, err
+	}
+	return mergeResult(req, repointed, merged), nil
+}
+
+func (s *BrandService) MergeModels(ctx context.Context, req dtos.MergeReq) (dtos.MergeResult, error) {
+	validate := helpers.GetValidator()
+	if err := validate.Struct(req); err != nil {
+		s.logger.Errorf("validate err: %v", err)
+		return dtos.MergeResult{}, err
+	}
+
+	repointed, merged, err := s.repo.MergeModels(ctx, req.SourceID, req.TargetID)
+	if err != nil {
+		s.logger.Errorf("merge models err: %v", err)
+		return dtos.MergeResult{}, err
+	}
+	return mergeResult(req, repointed, merged), nil
+}
+
+func mergeResult(req dtos.MergeReq, repointed, merged map[string]int64) dtos.MergeResult {
+	result := dtos.MergeResult{
+		SourceID:  req.SourceID,
+		TargetID:  req.TargetID,
+		Repointed: repointed,
+		Merged:    merged,
+	}
+	for _, count := range repointed {
+		result.Total += count
+	}
+	return result
+}
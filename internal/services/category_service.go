@@ -0,0 +1,174 @@
+package services
+
+import (
+	"autotm-admin/internal/dtos"
+	"autotm-admin/internal/helpers"
+	"autotm-admin/internal/models"
+	"autotm-admin/internal/repository/storage"
+	"context"
+	slog "github.com/salamsites/package-log"
+	"strings"
+)
+
+type CategoryService struct {
+	logger *slog.Logger
+	repo   storage.CategoryRepository
+}
+
+func NewCategoryService(logger *slog.Logger, repo storage.CategoryRepository) *CategoryService {
+	return &CategoryService{
+		logger: logger,
+		repo:   repo,
+	}
+}
+
+func (s *CategoryService) CreateCategory(ctx context.Context, category dtos.CreateCategoryReq) (dtos.ID, error) {
+	var id dtos.ID
+	names := helpers.LocalizedValues(category.Names, category.NameTM, category.NameEN, category.NameRU)
+	category.NameTM, category.NameEN, category.NameRU = names["tm"], names["en"], names["ru"]
+
+	validate := helpers.GetValidator()
+	if err := validate.Struct(category); err != nil {
+		s.logger.Errorf("validate err: %v", err)
+		return id, err
+	}
+	if err := helpers.ValidateLocales(names); err != nil {
+		s.logger.Errorf("validate locales err: %v", err)
+		return id, err
+	}
+
+	isActive := true
+	if category.IsActive != nil {
+		isActive = *category.IsActive
+	}
+
+	newCategory := models.Category{
+		Slug:     strings.ToLower(strings.TrimSpace(category.Slug)),
+		NameTM:   category.NameTM,
+		NameEN:   category.NameEN,
+		NameRU:   category.NameRU,
+		Names:    names,
+		IconPath: category.IconPath,
+		IsActive: isActive,
+	}
+
+	categoryID, err := s.repo.CreateCategory(ctx, newCategory)
+	if err != nil {
+		s.logger.Errorf("create category err: %v", err)
+		return id, err
+	}
+
+	id.ID = categoryID
+	return id, nil
+}
+
+func (s *CategoryService) GetCategories(ctx context.Context, limit, page int64, search string, activeOnly bool) (dtos.CategoryResult, error) {
+	offset := (page - 1) * limit
+	if page <= 0 {
+		page = 1
+		offset = 0
+	}
+
+	categories, count, err := s.repo.GetCategories(ctx, limit, offset, search, activeOnly)
+	if err != nil {
+		s.logger.Errorf("get categories err: %v", err)
+		return dtos.CategoryResult{}, err
+	}
+	var dtoCategories []dtos.Category
+	for _, c := range categories {
+		dtoCategories = append(dtoCategories, dtos.Category{
+			ID:       c.ID,
+			Slug:     c.Slug,
+			NameTM:   c.NameTM,
+			NameEN:   c.NameEN,
+			NameRU:   c.NameRU,
+			Names:    helpers.LocalizedValues(c.Names, c.NameTM, c.NameEN, c.NameRU),
+			IconPath: c.IconPath,
+			IsActive: c.IsActive,
+		})
+	}
+
+	result := dtos.CategoryResult{
+		Categories: dtoCategories,
+		Count:      count,
+	}
+	return result, nil
+}
+
+func (s *CategoryService) UpdateCategory(ctx context.Context, category dtos.UpdateCategoryReq) (dtos.ID, error) {
+	var id dtos.ID
+	names := helpers.LocalizedValues(category.Names, category.NameTM, category.NameEN, category.NameRU)
+	category.NameTM, category.NameEN, category.NameRU = names["tm"], names["en"], names["ru"]
+
+	validate := helpers.GetValidator()
+	if err := validate.Struct(category); err != nil {
+		s.logger.Errorf("validate err: %v", err)
+		return id, err
+	}
+	if err := helpers.ValidateLocales(names); err != nil {
+		s.logger.Errorf("validate locales err: %v", err)
+		return id, err
+	}
+
+	oldCategory, err := s.repo.GetCategoryByID(ctx, category.ID)
+	if err != nil {
+		s.logger.Errorf("get old category err: %v", err)
+		return id, err
+	}
+
+	if oldCategory.IconPath != category.IconPath && oldCategory.IconPath != "" {
+		if errPath := helpers.DeleteImage(oldCategory.IconPath); errPath != nil {
+			s.logger.Errorf("delete old icon path err: %v", errPath)
+		}
+	}
+
+	isActive := oldCategory.IsActive
+	if category.IsActive != nil {
+		isActive = *category.IsActive
+	}
+
+	newCategory := models.Category{
+		ID:       category.ID,
+		Slug:     strings.ToLower(strings.TrimSpace(category.Slug)),
+		NameTM:   category.NameTM,
+		NameEN:   category.NameEN,
+		NameRU:   category.NameRU,
+		Names:    names,
+		IconPath: category.IconPath,
+		IsActive: isActive,
+	}
+
+	categoryID, err := s.repo.UpdateCategory(ctx, newCategory)
+	if err != nil {
+		s.logger.Errorf("update category err: %v", err)
+		return id, err
+	}
+
+	id.ID = categoryID
+	return id, nil
+}
+
+func (s *CategoryService) DeleteCategory(ctx context.Context, id int64) error {
+	oldCategory, err := s.repo.GetCategoryByID(ctx, id)
+	if err != nil {
+		s.logger.Errorf("get old category err: %v", err)
+		return err
+	}
+
+	deleteID := models.ID{
+		ID: id,
+	}
+
+	err = s.repo.DeleteCategory(ctx, deleteID)
+	if err != nil {
+		s.logger.Errorf("delete category err: %v", err)
+		return err
+	}
+
+	if oldCategory.IconPath != "" {
+		if err = helpers.DeleteImage(oldCategory.IconPath); err != nil {
+			s.logger.Errorf("delete old icon path err: %v", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"autotm-admin/internal/dtos"
+	"autotm-admin/internal/helpers"
+	"autotm-admin/internal/models"
+	"context"
+)
+
+func (s *SlidersService) CreatePlatform(ctx context.Context, platform dtos.CreatePlatformReq) (int64, error) {
+	platform.Code = normalizePlatform(platform.Code)
+
+	validate := helpers.GetValidator()
+	if err := validate.Struct(platform); err != nil {
+		s.logger.Errorf("validate err: %v", err)
+		return 0, err
+	}
+
+	isActive := true
+	if platform.IsActive != nil {
+		isActive = *platform.IsActive
+	}
+
+	id, err := s.repo.CreatePlatform(ctx, models.Platform{
+		Code:         platform.Code,
+		Name:         platform.Name,
+		AspectWidth:  platform.AspectWidth,
+		AspectHeight: platform.AspectHeight,
+		MinWidth:     platform.MinWidth,
+		MinHeight:    platform.MinHeight,
+		IsActive:     isActive,
+	})
+	if err != nil {
+		s.logger.Errorf("create platform err: %v", err)
+		return 0, err
+	}
+	return id, nil
+}
+
+func (s *SlidersService) GetPlatforms(ctx context.Context) ([]dtos.Platform, error) {
+	platforms, err := s.repo.GetPlatforms(ctx)
+	if err != nil {
+		s.logger.Errorf("get platforms err: %v", err)
+		return nil, err
+	}
+
+	result := make([]dtos.Platform, 0, len(platforms))
+	for _, p := range platforms {
+		result = append(result, dtos.Platform{
+			ID:           p.ID,
+			Code:         p.Code,
+			Name:         p.Name,
+			AspectWidth:  p.AspectWidth,
+			AspectHeight: p.AspectHeight,
+			MinWidth:     p.MinWidth,
+			MinHeight:    p.MinHeight,
+			IsActive:     p.IsActive,
+		})
+	}
+	return result, nil
+}
+
+func (s *SlidersService) UpdatePlatform(ctx context.Context, platform dtos.UpdatePlatformReq) (int64, error) {
+	platform.Code = normalizePlatform(platform.Code)
+
+	validate := helpers.GetValidator()
+	if err := validate.Struct(platform); err != nil {
+		s.logger.Errorf("validate err: %v", err)
+		return 0, err
+	}
+
+	id, err := s.repo.UpdatePlatform(ctx, models.Platform{
+		ID:           platform.ID,
+		Code:         platform.Code,
+		Name:         platform.Name,
+		AspectWidth:  platform.AspectWidth,
+		AspectHeight: platform.AspectHeight,
+		MinWidth:     platform.MinWidth,
+		MinHeight:    platform.MinHeight,
+		IsActive:     *platform.IsActive,
+	})
+	if err != nil {
+		s.logger.Errorf("update platform err: %v", err)
+		return 0, err
+	}
+	return id, nil
+}
+
+func (s *SlidersService) DeletePlatform(ctx context.Context, id int64) error {
+	if err := s.repo.DeletePlatform(ctx, models.ID{ID: id}); err != nil {
+		s.logger.Errorf("delete platform err: %v", err)
+		return err
+	}
+	return nil
+}
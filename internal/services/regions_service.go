@@ -7,6 +7,7 @@ import (
 	"autotm-admin/internal/repository/storage"
 	"context"
 	slog "github.com/salamsites/package-log"
+	"sort"
 )
 
 type RegionsService struct {
@@ -28,10 +29,17 @@ func (s *RegionsService) CreateRegion(ctx context.Context, region dtos.CreateReg
 		return 0, err
 	}
 
+	names := helpers.LocalizedValues(region.Names, region.NameTM, region.NameEN, region.NameRu)
+	if err := helpers.ValidateLocales(names); err != nil {
+		s.logger.Errorf("validate locales err: %v", err)
+		return 0, err
+	}
+
 	newRegion := models.Region{
-		NameTM: region.NameTM,
-		NameEN: region.NameEN,
-		NameRU: region.NameRu,
+		NameTM: names["tm"],
+		NameEN: names["en"],
+		NameRU: names["ru"],
+		Names:  names,
 	}
 
 	regionID, err := s.repo.CreateRegion(ctx, newRegion)
@@ -61,6 +69,7 @@ func (s *RegionsService) GetAllRegions(ctx context.Context, limit, page int64, s
 			NameTM: b.NameTM,
 			NameEN: b.NameEN,
 			NameRu: b.NameRU,
+			Names:  helpers.LocalizedValues(b.Names, b.NameTM, b.NameEN, b.NameRU),
 		})
 	}
 
@@ -78,11 +87,18 @@ func (s *RegionsService) UpdateRegion(ctx context.Context, region dtos.UpdateReg
 		return 0, err
 	}
 
+	names := helpers.LocalizedValues(region.Names, region.NameTM, region.NameEN, region.NameRu)
+	if err := helpers.ValidateLocales(names); err != nil {
+		s.logger.Errorf("validate locales err: %v", err)
+		return 0, err
+	}
+
 	newRegion := models.Region{
 		ID:     region.ID,
-		NameTM: region.NameTM,
-		NameEN: region.NameEN,
-		NameRU: region.NameRu,
+		NameTM: names["tm"],
+		NameEN: names["en"],
+		NameRU: names["ru"],
+		Names:  names,
 	}
 
 	regionID, err := s.repo.UpdateRegion(ctx, newRegion)
@@ -93,12 +109,12 @@ func (s *RegionsService) UpdateRegion(ctx context.Context, region dtos.UpdateReg
 	return regionID, nil
 }
 
-func (s *RegionsService) DeleteRegion(ctx context.Context, id int64) error {
+func (s *RegionsService) DeleteRegion(ctx context.Context, id, reassignTo int64) error {
 	deleteID := models.ID{
 		ID: id,
 	}
 
-	err := s.repo.DeleteRegion(ctx, deleteID)
+	err := s.repo.DeleteRegion(ctx, deleteID, reassignTo)
 	if err != nil {
 		s.logger.Errorf("delete region err: %v", err)
 		return err
@@ -114,11 +130,20 @@ func (s *RegionsService) CreateCity(ctx context.Context, city dtos.CreateCityReq
 		return 0, err
 	}
 
+	names := helpers.LocalizedValues(city.Names, city.NameTM, city.NameEN, city.NameRu)
+	if err := helpers.ValidateLocales(names); err != nil {
+		s.logger.Errorf("validate locales err: %v", err)
+		return 0, err
+	}
+
 	newCity := models.City{
-		NameTM:   city.NameTM,
-		NameEN:   city.NameEN,
-		NameRU:   city.NameRu,
-		RegionID: city.RegionID,
+		NameTM:    names["tm"],
+		NameEN:    names["en"],
+		NameRU:    names["ru"],
+		Names:     names,
+		RegionID:  city.RegionID,
+		Latitude:  city.Latitude,
+		Longitude: city.Longitude,
 	}
 
 	cityID, err := s.repo.CreateCity(ctx, newCity)
@@ -129,14 +154,14 @@ func (s *RegionsService) CreateCity(ctx context.Context, city dtos.CreateCityReq
 	return cityID, nil
 }
 
-func (s *RegionsService) GetAllCities(ctx context.Context, limit, page int64, search string) (dtos.CityResult, error) {
+func (s *RegionsService) GetAllCities(ctx context.Context, limit, page int64, regionID int64, search string) (dtos.CityResult, error) {
 	offset := (page - 1) * limit
 	if page <= 0 {
 		page = 1
 		offset = 0
 	}
 
-	cities, count, err := s.repo.GetAllCities(ctx, limit, offset, search)
+	cities, count, err := s.repo.GetAllCities(ctx, limit, offset, regionID, search)
 	if err != nil {
 		s.logger.Errorf("get all cities err: %v", err)
 		return dtos.CityResult{}, err
@@ -152,6 +177,9 @@ func (s *RegionsService) GetAllCities(ctx context.Context, limit, page int64, se
 			RegionNameTM: b.RegionNameTM,
 			RegionNameEN: b.RegionNameEN,
 			RegionNameRU: b.RegionNameRU,
+			Names:        helpers.LocalizedValues(b.Names, b.NameTM, b.NameEN, b.NameRU),
+			Latitude:     b.Latitude,
+			Longitude:    b.Longitude,
 		})
 	}
 
@@ -162,6 +190,61 @@ func (s *RegionsService) GetAllCities(ctx context.Context, limit, page int64, se
 	return result, nil
 }
 
+// GetRegionTree returns every region with its cities nested, named in lang and
+// sorted by that name. lang defaults to the first configured locale.
+func (s *RegionsService) GetRegionTree(ctx context.Context, lang string) (dtos.RegionTree, error) {
+	if lang == "" {
+		lang = helpers.SupportedLocales()[0]
+	}
+	if err := helpers.ValidateLocales(map[string]string{lang: ""}); err != nil {
+		return dtos.RegionTree{}, err
+	}
+
+	regions, err := s.repo.GetRegionTree(ctx)
+	if err != nil {
+		s.logger.Errorf("get region tree err: %v", err)
+		return dtos.RegionTree{}, err
+	}
+
+	collator := helpers.Collator(lang)
+	tree := dtos.RegionTree{
+		Lang:    lang,
+		Regions: make([]dtos.RegionTreeNode, 0, len(regions)),
+	}
+	for _, region := range regions {
+		node := dtos.RegionTreeNode{
+			ID:     region.ID,
+			Name:   helpers.LocalizedName(helpers.LocalizedValues(region.Names, region.NameTM, region.NameEN, region.NameRU), lang),
+			Cities: make([]dtos.RegionTreeCity, 0, len(region.Cities)),
+		}
+		for _, city := range region.Cities {
+			cityNode := dtos.RegionTreeCity{
+				ID:        city.ID,
+				Name:      helpers.LocalizedName(helpers.LocalizedValues(city.Names, city.NameTM, city.NameEN, city.NameRU), lang),
+				Districts: make([]dtos.RegionTreeDistrict, 0, len(city.Districts)),
+			}
+			for _, district := range city.Districts {
+				cityNode.Districts = append(cityNode.Districts, dtos.RegionTreeDistrict{
+					ID:   district.ID,
+					Name: helpers.LocalizedName(helpers.LocalizedValues(district.Names, district.NameTM, district.NameEN, district.NameRU), lang),
+				})
+			}
+			sort.SliceStable(cityNode.Districts, func(i, j int) bool {
+				return collator.CompareString(cityNode.Districts[i].Name, cityNode.Districts[j].Name) < 0
+			})
+			node.Cities = append(node.Cities, cityNode)
+		}
+		sort.SliceStable(node.Cities, func(i, j int) bool {
+			return collator.CompareString(node.Cities[i].Name, node.Cities[j].Name) < 0
+		})
+		tree.Regions = append(tree.Regions, node)
+	}
+	sort.SliceStable(tree.Regions, func(i, j int) bool {
+		return collator.CompareString(tree.Regions[i].Name, tree.Regions[j].Name) < 0
+	})
+	return tree, nil
+}
+
 func (s *RegionsService) UpdateCity(ctx context.Context, city dtos.UpdateCityReq) (int64, error) {
 	validate := helpers.GetValidator()
 	if err := validate.Struct(city); err != nil {
@@ -169,12 +252,21 @@ func (s *RegionsService) UpdateCity(ctx context.Context, city dtos.UpdateCityReq
 		return 0, err
 	}
 
+	names := helpers.LocalizedValues(city.Names, city.NameTM, city.NameEN, city.NameRu)
+	if err := helpers.ValidateLocales(names); err != nil {
+		s.logger.Errorf("validate locales err: %v", err)
+		return 0, err
+	}
+
 	newCity := models.City{
-		ID:       city.ID,
-		NameTM:   city.NameTM,
-		NameEN:   city.NameEN,
-		NameRU:   city.NameRu,
-		RegionID: city.RegionID,
+		ID:        city.ID,
+		NameTM:    names["tm"],
+		NameEN:    names["en"],
+		NameRU:    names["ru"],
+		Names:     names,
+		RegionID:  city.RegionID,
+		Latitude:  city.Latitude,
+		Longitude: city.Longitude,
 	}
 
 	cityID, err := s.repo.UpdateCity(ctx, newCity)
@@ -185,15 +277,122 @@ func (s *RegionsService) UpdateCity(ctx context.Context, city dtos.UpdateCityReq
 	return cityID, nil
 }
 
-func (s *RegionsService) DeleteCity(ctx context.Context, id int64) error {
+func (s *RegionsService) DeleteCity(ctx context.Context, id, reassignTo int64) error {
 	deleteID := models.ID{
 		ID: id,
 	}
 
-	err := s.repo.DeleteCity(ctx, deleteID)
+	err := s.repo.DeleteCity(ctx, deleteID, reassignTo)
 	if err != nil {
 		s.logger.Errorf("delete city err: %v", err)
 		return err
 	}
 	return nil
 }
+
+// Districts
+func (s *RegionsService) CreateDistrict(ctx context.Context, district dtos.CreateDistrictReq) (int64, error) {
+	validate := helpers.GetValidator()
+	if err := validate.Struct(district); err != nil {
+		s.logger.Errorf("validate err: %v", err)
+		return 0, err
+	}
+
+	names := helpers.LocalizedValues(district.Names, district.NameTM, district.NameEN, district.NameRu)
+	if err := helpers.ValidateLocales(names); err != nil {
+		s.logger.Errorf("validate locales err: %v", err)
+		return 0, err
+	}
+
+	newDistrict := models.District{
+		NameTM: names["tm"],
+		NameEN: names["en"],
+		NameRU: names["ru"],
+		Names:  names,
+		CityID: district.CityID,
+	}
+
+	districtID, err := s.repo.CreateDistrict(ctx, newDistrict)
+	if err != nil {
+		s.logger.Errorf("create district err: %v", err)
+		return districtID, err
+	}
+	return districtID, nil
+}
+
+func (s *RegionsService) GetAllDistricts(ctx context.Context, limit, page int64, cityID int64, search string) (dtos.DistrictResult, error) {
+	offset := (page - 1) * limit
+	if page <= 0 {
+		page = 1
+		offset = 0
+	}
+
+	districts, count, err := s.repo.GetAllDistricts(ctx, limit, offset, cityID, search)
+	if err != nil {
+		s.logger.Errorf("get all districts err: %v", err)
+		return dtos.DistrictResult{}, err
+	}
+	var dtoDistricts []dtos.District
+	for _, d := range districts {
+		dtoDistricts = append(dtoDistricts, dtos.District{
+			ID:         d.ID,
+			NameTM:     d.NameTM,
+			NameEN:     d.NameEN,
+			NameRu:     d.NameRU,
+			CityID:     d.CityID,
+			CityNameTM: d.CityNameTM,
+			CityNameEN: d.CityNameEN,
+			CityNameRU: d.CityNameRU,
+			Names:      helpers.LocalizedValues(d.Names, d.NameTM, d.NameEN, d.NameRU),
+		})
+	}
+
+	result := dtos.DistrictResult{
+		Districts: dtoDistricts,
+		Count:     count,
+	}
+	return result, nil
+}
+
+func (s *RegionsService) UpdateDistrict(ctx context.Context, district dtos.UpdateDistrictReq) (int64, error) {
+	validate := helpers.GetValidator()
+	if err := validate.Struct(district); err != nil {
+		s.logger.Errorf("validate err: %v", err)
+		return 0, err
+	}
+
+	names := helpers.LocalizedValues(district.Names, district.NameTM, district.NameEN, district.NameRu)
+	if err := helpers.ValidateLocales(names); err != nil {
+		s.logger.Errorf("validate locales err: %v", err)
+		return 0, err
+	}
+
+	newDistrict := models.District{
+		ID:     district.ID,
+		NameTM: names["tm"],
+		NameEN: names["en"],
+		NameRU: names["ru"],
+		Names:  names,
+		CityID: district.CityID,
+	}
+
+	districtID, err := s.repo.UpdateDistrict(ctx, newDistrict)
+	if err != nil {
+		s.logger.Errorf("update district err: %v", err)
+		return districtID, err
+	}
+	return districtID, nil
+}
+
+func (s *RegionsService) DeleteDistrict(ctx context.Context, id, reassignTo int64) error {
+	deleteID := models.ID{
+		ID: id,
+	}
+
+	err := s.repo.DeleteDistrict(ctx, deleteID, reassignTo)
+	if err != nil {
+		s.logger.Errorf("delete district err: %v", err)
+		return err
+	}
+	return nil
+}
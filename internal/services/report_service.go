@@ -0,0 +1,112 @@
+package services
+
+import (
+	"autotm-admin/internal/dtos"
+	"autotm-admin/internal/helpers"
+	"autotm-admin/internal/models"
+	"autotm-admin/internal/repository/storage"
+	"context"
+	"fmt"
+	slog "github.com/salamsites/package-log"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// localizedEntities is the order in which the translation report lists entities.
+var localizedEntities = []string{"categories", "body_types", "regions", "cities", "districts"}
+
+type ReportService struct {
+	logger *slog.Logger
+	repo   storage.ReportRepository
+}
+
+func NewReportService(logger *slog.Logger, repo storage.ReportRepository) *ReportService {
+	return &ReportService{
+		logger: logger,
+		repo:   repo,
+	}
+}
+
+func (s *ReportService) GetTranslationReport(ctx context.Context, entity string) (dtos.TranslationReport, error) {
+	entities := localizedEntities
+	if entity != "" {
+		if !slices.Contains(localizedEntities, entity) {
+			return dtos.TranslationReport{}, fmt.Errorf("%w: %q is not a localized entity", helpers.ErrUnknownEntity, entity)
+		}
+		entities = []string{entity}
+	}
+
+	var report dtos.TranslationReport
+	for _, e := range entities {
+		names, err := s.repo.GetLocalizedNames(ctx, e)
+		if err != nil {
+			s.logger.Errorf("get localized names err: %v", err)
+			return dtos.TranslationReport{}, err
+		}
+		report.Entities = append(report.Entities, checkTranslations(e, names))
+	}
+	return report, nil
+}
+
+func checkTranslations(entity string, names []models.LocalizedName) dtos.EntityTranslationReport {
+	result := dtos.EntityTranslationReport{
+		Entity:         entity,
+		Total:          len(names),
+		Missing:        dtos.TranslationIssue{IDs: []int64{}},
+		Identical:      dtos.TranslationIssue{IDs: []int64{}},
+		ScriptMismatch: dtos.TranslationIssue{IDs: []int64{}},
+	}
+
+	for _, n := range names {
+		tm, en, ru := strings.TrimSpace(n.NameTM), strings.TrimSpace(n.NameEN), strings.TrimSpace(n.NameRU)
+		complete := true
+
+		if tm == "" || en == "" || ru == "" {
+			result.Missing.IDs = append(result.Missing.IDs, n.ID)
+			complete = false
+		}
+
+		if sameText(tm, en) || sameText(tm, ru) || sameText(en, ru) {
+			result.Identical.IDs = append(result.Identical.IDs, n.ID)
+			complete = false
+		}
+
+		// Turkmen and English names are written in Latin script, Russian in Cyrillic.
+		if hasCyrillic(tm) || hasCyrillic(en) || (hasLetters(ru) && !hasCyrillic(ru)) {
+			result.ScriptMismatch.IDs = append(result.ScriptMismatch.IDs, n.ID)
+			complete = false
+		}
+
+		if complete {
+			result.Complete++
+		}
+	}
+
+	result.Missing.Count = len(result.Missing.IDs)
+	result.Identical.Count = len(result.Identical.IDs)
+	result.ScriptMismatch.Count = len(result.ScriptMismatch.IDs)
+	return result
+}
+
+func sameText(a, b string) bool {
+	return a != "" && strings.EqualFold(a, b)
+}
+
+func hasCyrillic(s string) bool {
+	for _, r := range s {
+		if unicode.Is(unicode.Cyrillic, r) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasLetters(s string) bool {
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			return true
+		}
+	}
+	return false
+}
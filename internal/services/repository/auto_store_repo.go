@@ -8,7 +8,17 @@ import (
 type AutoStoreService interface {
 	CreateAutoStore(ctx context.Context, autoStore dtos.CreateAutoStoreReq) (int64, error)
 	GetUsersFromUserService(ctx context.Context, limit, page int64, search string) (dtos.GetUserResult, error)
-	GetAutoStores(ctx context.Context, limit, page int64, search string) (dtos.AutoStoresResult, error)
+	GetAutoStores(ctx context.Context, filter dtos.AutoStoresFilter) (dtos.AutoStoresResult, error)
+	GetNearestAutoStores(ctx context.Context, req dtos.NearestAutoStoresReq) (dtos.AutoStoresResult, error)
 	UpdateAutoStore(ctx context.Context, autoStore dtos.UpdateAutoStoreReq) (dtos.ID, error)
 	DeleteAutoStore(ctx context.Context, id int64) error
+	SetAutoStoreSchedule(ctx context.Context, schedule dtos.AutoStoreSchedule) error
+	GetAutoStoreSchedule(ctx context.Context, autoStoreID int64) (dtos.AutoStoreSchedule, error)
+	ApproveAutoStore(ctx context.Context, req dtos.ReviewAutoStoreReq) error
+	RejectAutoStore(ctx context.Context, req dtos.ReviewAutoStoreReq) error
+	SuspendAutoStore(ctx context.Context, req dtos.ReviewAutoStoreReq) error
+	GetAutoStoreStatusHistory(ctx context.Context, autoStoreID int64) ([]dtos.AutoStoreStatusChange, error)
+	GetAutoStoreBySlug(ctx context.Context, slug string) (dtos.AutoStoreBySlugResult, error)
+	SetAutoStoreBrands(ctx context.Context, req dtos.SetAutoStoreBrandsReq) error
+	GetAutoStoreBrands(ctx context.Context, autoStoreID int64) ([]dtos.AutoStoreBrand, error)
 }
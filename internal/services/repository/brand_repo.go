@@ -23,4 +23,15 @@ type BrandService interface {
 	GetModels(ctx context.Context, limit, page int64, category, search string) (dtos.ModelResult, error)
 	UpdateModel(ctx context.Context, model dtos.UpdateModelReq) (dtos.ID, error)
 	DeleteModel(ctx context.Context, id int64) error
+
+	// WMI Code
+	CreateWMICode(ctx context.Context, code dtos.CreateWMICodeReq) (dtos.ID, error)
+	GetWMICodes(ctx context.Context, limit, page int64, brandID int64, search string) (dtos.WMICodeResult, error)
+	UpdateWMICode(ctx context.Context, code dtos.UpdateWMICodeReq) (dtos.ID, error)
+	DeleteWMICode(ctx context.Context, id int64) error
+	DecodeVIN(ctx context.Context, vin string) (dtos.VINDecodeResult, error)
+
+	// Merge
+	MergeBrands(ctx context.Context, req dtos.MergeReq) (dtos.MergeResult, error)
+	MergeModels(ctx context.Context, req dtos.MergeReq) (dtos.MergeResult, error)
 }
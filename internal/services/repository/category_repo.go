@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"autotm-admin/internal/dtos"
+	"context"
+)
+
+type CategoryService interface {
+	CreateCategory(ctx context.Context, category dtos.CreateCategoryReq) (dtos.ID, error)
+	GetCategories(ctx context.Context, limit, page int64, search string, activeOnly bool) (dtos.CategoryResult, error)
+	UpdateCategory(ctx context.Context, category dtos.UpdateCategoryReq) (dtos.ID, error)
+	DeleteCategory(ctx context.Context, id int64) error
+}
@@ -10,11 +10,18 @@ type RegionsService interface {
 	CreateRegion(ctx context.Context, region dtos.CreateRegionReq) (int64, error)
 	GetAllRegions(ctx context.Context, limit, page int64, search string) (dtos.RegionResult, error)
 	UpdateRegion(ctx context.Context, region dtos.UpdateRegionReq) (int64, error)
-	DeleteRegion(ctx context.Context, id int64) error
+	DeleteRegion(ctx context.Context, id, reassignTo int64) error
 
 	// Cities
 	CreateCity(ctx context.Context, city dtos.CreateCityReq) (int64, error)
-	GetAllCities(ctx context.Context, limit, page int64, search string) (dtos.CityResult, error)
+	GetAllCities(ctx context.Context, limit, page int64, regionID int64, search string) (dtos.CityResult, error)
+	GetRegionTree(ctx context.Context, lang string) (dtos.RegionTree, error)
 	UpdateCity(ctx context.Context, region dtos.UpdateCityReq) (int64, error)
-	DeleteCity(ctx context.Context, id int64) error
+	DeleteCity(ctx context.Context, id, reassignTo int64) error
+
+	// Districts
+	CreateDistrict(ctx context.Context, district dtos.CreateDistrictReq) (int64, error)
+	GetAllDistricts(ctx context.Context, limit, page int64, cityID int64, search string) (dtos.DistrictResult, error)
+	UpdateDistrict(ctx context.Context, district dtos.UpdateDistrictReq) (int64, error)
+	DeleteDistrict(ctx context.Context, id, reassignTo int64) error
 }
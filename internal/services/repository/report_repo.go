@@ -0,0 +1,10 @@
+package repository
+
+import (
+	"autotm-admin/internal/dtos"
+	"context"
+)
+
+type ReportService interface {
+	GetTranslationReport(ctx context.Context, entity string) (dtos.TranslationReport, error)
+}
@@ -10,4 +10,18 @@ type SlidersService interface {
 	GetAllSliders(ctx context.Context, limit, page int64, platform string) (dtos.SliderResult, error)
 	UpdateSlider(ctx context.Context, role dtos.UpdateSliderReq) (int64, error)
 	DeleteSlider(ctx context.Context, id int64) error
+	GetActiveSliders(ctx context.Context, platform, clientID string) (dtos.SliderResult, error)
+	ReorderSliders(ctx context.Context, req dtos.ReorderSlidersReq) error
+	TrackSliderImpressions(ctx context.Context, req dtos.TrackSliderImpressionsReq) (int64, error)
+	TrackSliderClick(ctx context.Context, req dtos.TrackSliderClickReq) error
+	GetSliderStats(ctx context.Context, req dtos.SliderStatsReq) (dtos.SliderStatsResult, error)
+	CreatePlatform(ctx context.Context, platform dtos.CreatePlatformReq) (int64, error)
+	GetPlatforms(ctx context.Context) ([]dtos.Platform, error)
+	UpdatePlatform(ctx context.Context, platform dtos.UpdatePlatformReq) (int64, error)
+	DeletePlatform(ctx context.Context, id int64) error
+	CreateSliderVariant(ctx context.Context, variant dtos.CreateSliderVariantReq) (int64, error)
+	GetSliderVariants(ctx context.Context, sliderID int64) ([]dtos.SliderVariant, error)
+	UpdateSliderVariant(ctx context.Context, variant dtos.UpdateSliderVariantReq) (int64, error)
+	DeleteSliderVariant(ctx context.Context, id int64) error
+	PromoteSliderVariant(ctx context.Context, id int64) error
 }
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"autotm-admin/internal/dtos"
+	"context"
+)
+
+type SubscriptionService interface {
+	CreateSubscriptionPlan(ctx context.Context, plan dtos.CreateSubscriptionPlanReq) (dtos.ID, error)
+	GetSubscriptionPlans(ctx context.Context, activeOnly bool) ([]dtos.SubscriptionPlan, error)
+	UpdateSubscriptionPlan(ctx context.Context, plan dtos.UpdateSubscriptionPlanReq) (dtos.ID, error)
+	DeleteSubscriptionPlan(ctx context.Context, id int64) error
+	AssignSubscription(ctx context.Context, req dtos.AssignSubscriptionReq) (dtos.ID, error)
+	ExtendSubscription(ctx context.Context, req dtos.ExtendSubscriptionReq) error
+	CancelSubscription(ctx context.Context, id int64) error
+	GetStoreSubscriptions(ctx context.Context, autoStoreID int64) ([]dtos.StoreSubscription, error)
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"autotm-admin/internal/dtos"
+	"autotm-admin/internal/helpers"
+	"autotm-admin/internal/models"
+	"autotm-admin/internal/repository/storage"
+	"context"
+	"encoding/json"
+	slog "github.com/salamsites/package-log"
+	"strings"
+)
+
+type SeedService struct {
+	logger *slog.Logger
+	repo   storage.SeedRepository
+}
+
+func NewSeedService(logger *slog.Logger, repo storage.SeedRepository) *SeedService {
+	return &SeedService{
+		logger: logger,
+		repo:   repo,
+	}
+}
+
+// SeedCatalog decodes a JSON catalog dataset and loads it into the database.
+func (s *SeedService) SeedCatalog(ctx context.Context, data []byte) (dtos.SeedResult, error) {
+	var catalog dtos.SeedCatalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		s.logger.Errorf("decode seed catalog err: %v", err)
+		return dtos.SeedResult{}, err
+	}
+
+	validate := helpers.GetValidator()
+	if err := validate.Struct(catalog); err != nil {
+		s.logger.Errorf("validate err: %v", err)
+		return dtos.SeedResult{}, err
+	}
+
+	var seed models.SeedCatalog
+	for _, c := range catalog.Categories {
+		category := models.SeedCategory{
+			Category: models.Category{
+				Slug:     strings.ToLower(strings.TrimSpace(c.Slug)),
+				NameTM:   c.NameTM,
+				NameEN:   c.NameEN,
+				NameRU:   c.NameRU,
+				Names:    helpers.LocalizedValues(nil, c.NameTM, c.NameEN, c.NameRU),
+				IsActive: true,
+			},
+		}
+		for _, b := range c.Brands {
+			category.Brands = append(category.Brands, models.SeedBrand{
+				Name:   strings.TrimSpace(b.Name),
+				Models: b.Models,
+			})
+		}
+		seed.Categories = append(seed.Categories, category)
+	}
+
+	for _, bt := range catalog.BodyTypes {
+		seed.BodyTypes = append(seed.BodyTypes, models.BodyType{
+			NameTM:   bt.NameTM,
+			NameEN:   bt.NameEN,
+			NameRU:   bt.NameRU,
+			Names:    helpers.LocalizedValues(nil, bt.NameTM, bt.NameEN, bt.NameRU),
+			Category: strings.ToLower(strings.TrimSpace(bt.Category)),
+		})
+	}
+
+	for _, r := range catalog.Regions {
+		region := models.SeedRegion{
+			Region: models.Region{
+				NameTM: r.NameTM,
+				NameEN: r.NameEN,
+				NameRU: r.NameRU,
+				Names:  helpers.LocalizedValues(nil, r.NameTM, r.NameEN, r.NameRU),
+			},
+		}
+		for _, c := range r.Cities {
+			region.Cities = append(region.Cities, models.City{
+				NameTM: c.NameTM,
+				NameEN: c.NameEN,
+				NameRU: c.NameRU,
+				Names:  helpers.LocalizedValues(nil, c.NameTM, c.NameEN, c.NameRU),
+			})
+		}
+		seed.Regions = append(seed.Regions, region)
+	}
+
+	created, err := s.repo.SeedCatalog(ctx, seed)
+	if err != nil {
+		s.logger.Errorf("seed catalog err: %v", err)
+		return dtos.SeedResult{}, err
+	}
+
+	return dtos.SeedResult{Created: created}, nil
+}
@@ -6,9 +6,22 @@ import (
 	"autotm-admin/internal/models"
 	"autotm-admin/internal/repository/storage"
 	"context"
+	"fmt"
 	slog "github.com/salamsites/package-log"
+	"math"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 )
 
+// maxSliderStatsDays bounds the date range of a slider stats request.
+const maxSliderStatsDays = 366
+
+// screenPattern matches in-app screen routes such as "search" or "catalog/trucks".
+var screenPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*(/[a-z0-9_-]+)*$`)
+
 type SlidersService struct {
 	logger *slog.Logger
 	repo   storage.SlidersRepository
@@ -22,17 +35,61 @@ func NewSlidersService(logger *slog.Logger, repo storage.SlidersRepository) *Sli
 }
 
 func (s *SlidersService) CreateSlider(ctx context.Context, slider dtos.CreateSliderReq) (int64, error) {
+	slider.Platform = normalizePlatform(slider.Platform)
+	imagePaths := helpers.LocalizedValues(slider.ImagePaths, slider.ImagePathTM, slider.ImagePathEN, slider.ImagePathRU)
+	slider.ImagePathTM, slider.ImagePathEN, slider.ImagePathRU = imagePaths["tm"], imagePaths["en"], imagePaths["ru"]
+
 	validate := helpers.GetValidator()
 	if err := validate.Struct(slider); err != nil {
 		s.logger.Errorf("validate err: %v", err)
 		return 0, err
 	}
+	if err := helpers.ValidateLocales(imagePaths); err != nil {
+		s.logger.Errorf("validate locales err: %v", err)
+		return 0, err
+	}
+	if err := validateSliderSchedule(slider.StartsAt, slider.EndsAt); err != nil {
+		s.logger.Errorf("validate schedule err: %v", err)
+		return 0, err
+	}
+	target, err := sliderTarget(slider.TargetType, slider.TargetID, slider.TargetURL, slider.TargetURLs, slider.TargetScreen)
+	if err != nil {
+		s.logger.Errorf("validate target err: %v", err)
+		return 0, err
+	}
+	if err = s.checkSliderImages(ctx, slider.Platform, imagePaths); err != nil {
+		s.logger.Errorf("check slider images err: %v", err)
+		return 0, err
+	}
+
+	isActive := true
+	if slider.IsActive != nil {
+		isActive = *slider.IsActive
+	}
+
+	var position int64
+	if slider.Position != nil {
+		position = *slider.Position
+	} else {
+		next, err := s.repo.GetNextSliderPosition(ctx, slider.Platform)
+		if err != nil {
+			s.logger.Errorf("get next slider position err: %v", err)
+			return 0, err
+		}
+		position = next
+	}
 
 	newSlider := models.Slider{
 		ImagePathTM: slider.ImagePathTM,
 		ImagePathEN: slider.ImagePathEN,
 		ImagePathRU: slider.ImagePathRU,
+		ImagePaths:  imagePaths,
 		Platform:    slider.Platform,
+		StartsAt:    slider.StartsAt,
+		EndsAt:      slider.EndsAt,
+		IsActive:    isActive,
+		Position:    position,
+		Target:      target,
 	}
 
 	brandID, err := s.repo.CreateSlider(ctx, newSlider)
@@ -50,35 +107,93 @@ func (s *SlidersService) GetAllSliders(ctx context.Context, limit, page int64, p
 		offset = 0
 	}
 
-	sliders, count, err := s.repo.GetAllSliders(ctx, limit, offset, platform)
+	sliders, count, err := s.repo.GetAllSliders(ctx, limit, offset, normalizePlatform(platform))
 	if err != nil {
 		s.logger.Errorf("get sliders err: %v", err)
 		return dtos.SliderResult{}, err
 	}
-	var dtoSliders []dtos.Slider
-	for _, b := range sliders {
-		dtoSliders = append(dtoSliders, dtos.Slider{
-			ID:          b.ID,
-			ImagePathTM: b.ImagePathTM,
-			ImagePathEN: b.ImagePathEN,
-			ImagePathRU: b.ImagePathRU,
-			Platform:    b.Platform,
-		})
+
+	result := dtos.SliderResult{
+		Sliders: toSliderDTOs(sliders),
+		Count:   count,
+	}
+	return result, nil
+}
+
+// GetActiveSliders returns the sliders currently shown on a platform. For sliders
+// with variants the creative is picked by clientID, so a client keeps seeing the
+// same variant.
+func (s *SlidersService) GetActiveSliders(ctx context.Context, platform, clientID string) (dtos.SliderResult, error) {
+	sliders, err := s.repo.GetActiveSliders(ctx, normalizePlatform(platform))
+	if err != nil {
+		s.logger.Errorf("get active sliders err: %v", err)
+		return dtos.SliderResult{}, err
+	}
+
+	sliderIDs := make([]int64, 0, len(sliders))
+	for _, slider := range sliders {
+		sliderIDs = append(sliderIDs, slider.ID)
+	}
+	variants, err := s.repo.GetSliderVariants(ctx, sliderIDs)
+	if err != nil {
+		s.logger.Errorf("get slider variants err: %v", err)
+		return dtos.SliderResult{}, err
+	}
+	bySlider := make(map[int64][]models.SliderVariant)
+	for _, variant := range variants {
+		bySlider[variant.SliderID] = append(bySlider[variant.SliderID], variant)
+	}
+
+	dtoSliders := toSliderDTOs(sliders)
+	for i := range dtoSliders {
+		variant, ok := pickSliderVariant(clientID, dtoSliders[i].ID, bySlider[dtoSliders[i].ID])
+		if !ok {
+			continue
+		}
+		dtoSliders[i].VariantID = &variant.ID
+		dtoSliders[i].ImagePathTM = variant.ImagePathTM
+		dtoSliders[i].ImagePathEN = variant.ImagePathEN
+		dtoSliders[i].ImagePathRU = variant.ImagePathRU
+		dtoSliders[i].ImagePaths = helpers.LocalizedValues(variant.ImagePaths, variant.ImagePathTM, variant.ImagePathEN, variant.ImagePathRU)
 	}
 
 	result := dtos.SliderResult{
 		Sliders: dtoSliders,
-		Count:   count,
+		Count:   int64(len(sliders)),
 	}
 	return result, nil
 }
 
+func (s *SlidersService) ReorderSliders(ctx context.Context, req dtos.ReorderSlidersReq) error {
+	req.Platform = normalizePlatform(req.Platform)
+
+	validate := helpers.GetValidator()
+	if err := validate.Struct(req); err != nil {
+		s.logger.Errorf("validate err: %v", err)
+		return err
+	}
+
+	if err := s.repo.ReorderSliders(ctx, req.Platform, req.IDs); err != nil {
+		s.logger.Errorf("reorder sliders err: %v", err)
+		return err
+	}
+	return nil
+}
+
 func (s *SlidersService) UpdateSlider(ctx context.Context, slider dtos.UpdateSliderReq) (int64, error) {
+	slider.Platform = normalizePlatform(slider.Platform)
+	imagePaths := helpers.LocalizedValues(slider.ImagePaths, slider.ImagePathTM, slider.ImagePathEN, slider.ImagePathRU)
+	slider.ImagePathTM, slider.ImagePathEN, slider.ImagePathRU = imagePaths["tm"], imagePaths["en"], imagePaths["ru"]
+
 	validate := helpers.GetValidator()
 	if err := validate.Struct(slider); err != nil {
 		s.logger.Errorf("validate err: %v", err)
 		return 0, err
 	}
+	if err := helpers.ValidateLocales(imagePaths); err != nil {
+		s.logger.Errorf("validate locales err: %v", err)
+		return 0, err
+	}
 
 	oldSlider, err := s.repo.GetSliderByID(ctx, slider.ID)
 	if err != nil {
@@ -86,30 +201,52 @@ func (s *SlidersService) UpdateSlider(ctx context.Context, slider dtos.UpdateSli
 		return 0, err
 	}
 
-	if oldSlider.ImagePathTM != slider.ImagePathTM && slider.ImagePathTM != "" {
-		if err = helpers.DeleteImage(oldSlider.ImagePathTM); err != nil {
-			s.logger.Errorf("delete old image path tm err: %v", err)
-		}
+	startsAt, endsAt := oldSlider.StartsAt, oldSlider.EndsAt
+	if slider.ClearSchedule {
+		startsAt, endsAt = nil, nil
 	}
-
-	if oldSlider.ImagePathEN != slider.ImagePathEN && slider.ImagePathEN != "" {
-		if err = helpers.DeleteImage(oldSlider.ImagePathEN); err != nil {
-			s.logger.Errorf("delete old image path en err: %v", err)
-		}
+	if slider.StartsAt != nil {
+		startsAt = slider.StartsAt
 	}
-
-	if oldSlider.ImagePathRU != slider.ImagePathRU && slider.ImagePathRU != "" {
-		if err = helpers.DeleteImage(oldSlider.ImagePathRU); err != nil {
-			s.logger.Errorf("delete old image path ru err: %v", err)
+	if slider.EndsAt != nil {
+		endsAt = slider.EndsAt
+	}
+	if err = validateSliderSchedule(startsAt, endsAt); err != nil {
+		s.logger.Errorf("validate schedule err: %v", err)
+		return 0, err
+	}
+	// Without a target type the slider keeps its stored target; "none" removes it.
+	target := oldSlider.Target
+	if slider.TargetType != "" {
+		target, err = sliderTarget(slider.TargetType, slider.TargetID, slider.TargetURL, slider.TargetURLs, slider.TargetScreen)
+		if err != nil {
+			s.logger.Errorf("validate target err: %v", err)
+			return 0, err
 		}
 	}
+	if err = s.checkSliderImages(ctx, slider.Platform, imagePaths); err != nil {
+		s.logger.Errorf("check slider images err: %v", err)
+		return 0, err
+	}
 
 	newSlider := models.Slider{
 		ID:          slider.ID,
 		ImagePathTM: slider.ImagePathTM,
 		ImagePathEN: slider.ImagePathEN,
 		ImagePathRU: slider.ImagePathRU,
+		ImagePaths:  imagePaths,
 		Platform:    slider.Platform,
+		StartsAt:    startsAt,
+		EndsAt:      endsAt,
+		IsActive:    oldSlider.IsActive,
+		Position:    oldSlider.Position,
+		Target:      target,
+	}
+	if slider.IsActive != nil {
+		newSlider.IsActive = *slider.IsActive
+	}
+	if slider.Position != nil {
+		newSlider.Position = *slider.Position
 	}
 
 	sliderID, err := s.repo.UpdateSlider(ctx, newSlider)
@@ -117,6 +254,18 @@ func (s *SlidersService) UpdateSlider(ctx context.Context, slider dtos.UpdateSli
 		s.logger.Errorf("update slider err: %v", err)
 		return sliderID, err
 	}
+
+	// Locales missing from the request keep their image; only replaced and
+	// removed images are deleted, once the slider no longer points at them.
+	oldImagePaths := helpers.LocalizedValues(oldSlider.ImagePaths, oldSlider.ImagePathTM, oldSlider.ImagePathEN, oldSlider.ImagePathRU)
+	for locale, oldPath := range oldImagePaths {
+		newPath, ok := imagePaths[locale]
+		if oldPath != "" && ok && oldPath != newPath {
+			if err = helpers.DeleteImage(oldPath); err != nil {
+				s.logger.Errorf("delete old image path %s err: %v", locale, err)
+			}
+		}
+	}
 	return sliderID, nil
 }
 
@@ -127,22 +276,22 @@ func (s *SlidersService) DeleteSlider(ctx context.Context, id int64) error {
 		return err
 	}
 
-	if oldSlider.ImagePathTM != "" {
-		if err = helpers.DeleteImage(oldSlider.ImagePathTM); err != nil {
-			s.logger.Errorf("delete old image path tm err: %v", err)
+	oldImagePaths := helpers.LocalizedValues(oldSlider.ImagePaths, oldSlider.ImagePathTM, oldSlider.ImagePathEN, oldSlider.ImagePathRU)
+	for locale, oldPath := range oldImagePaths {
+		if oldPath != "" {
+			if err = helpers.DeleteImage(oldPath); err != nil {
+				s.logger.Errorf("delete old image path %s err: %v", locale, err)
+			}
 		}
 	}
 
-	if oldSlider.ImagePathEN != "" {
-		if err = helpers.DeleteImage(oldSlider.ImagePathEN); err != nil {
-			s.logger.Errorf("delete old image path en err: %v", err)
-		}
+	variants, err := s.repo.GetSliderVariants(ctx, []int64{id})
+	if err != nil {
+		s.logger.Errorf("get slider variants err: %v", err)
+		return err
 	}
-
-	if oldSlider.ImagePathRU != "" {
-		if err = helpers.DeleteImage(oldSlider.ImagePathRU); err != nil {
-			s.logger.Errorf("delete old image path ru err: %v", err)
-		}
+	for _, variant := range variants {
+		s.deleteVariantImages(variant, nil)
 	}
 
 	deleteID := models.ID{
@@ -156,3 +305,259 @@ func (s *SlidersService) DeleteSlider(ctx context.Context, id int64) error {
 	}
 	return nil
 }
+
+func (s *SlidersService) TrackSliderImpressions(ctx context.Context, req dtos.TrackSliderImpressionsReq) (int64, error) {
+	validate := helpers.GetValidator()
+	if err := validate.Struct(req); err != nil {
+		s.logger.Errorf("validate err: %v", err)
+		return 0, err
+	}
+
+	if len(req.VariantIDs) > len(req.SliderIDs) {
+		return 0, fmt.Errorf("%w: variant_ids has more entries than slider_ids", helpers.ErrInvalidReference)
+	}
+	variantIDs := make([]int64, len(req.SliderIDs))
+	copy(variantIDs, req.VariantIDs)
+
+	recorded, err := s.repo.RecordSliderImpressions(ctx, req.SliderIDs, variantIDs, normalizePlatform(req.Platform))
+	if err != nil {
+		s.logger.Errorf("record slider impressions err: %v", err)
+		return 0, err
+	}
+	return recorded, nil
+}
+
+func (s *SlidersService) TrackSliderClick(ctx context.Context, req dtos.TrackSliderClickReq) error {
+	validate := helpers.GetValidator()
+	if err := validate.Struct(req); err != nil {
+		s.logger.Errorf("validate err: %v", err)
+		return err
+	}
+
+	if err := s.repo.RecordSliderClick(ctx, req.SliderID, req.VariantID, normalizePlatform(req.Platform)); err != nil {
+		s.logger.Errorf("record slider click err: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (s *SlidersService) GetSliderStats(ctx context.Context, req dtos.SliderStatsReq) (dtos.SliderStatsResult, error) {
+	if req.To.Before(req.From) {
+		return dtos.SliderStatsResult{}, fmt.Errorf("%w: from must not be after to", helpers.ErrInvalidDateRange)
+	}
+	days := int(req.To.Sub(req.From).Hours()/24) + 1
+	if days > maxSliderStatsDays {
+		return dtos.SliderStatsResult{}, fmt.Errorf("%w: range must not exceed %d days", helpers.ErrInvalidDateRange, maxSliderStatsDays)
+	}
+
+	req.Platform = normalizePlatform(req.Platform)
+	stats, err := s.repo.GetSliderStats(ctx, req.From, req.To, req.Platform, req.SliderID)
+	if err != nil {
+		s.logger.Errorf("get slider stats err: %v", err)
+		return dtos.SliderStatsResult{}, err
+	}
+
+	result := dtos.SliderStatsResult{
+		From:     req.From.Format(time.DateOnly),
+		To:       req.To.Format(time.DateOnly),
+		Platform: req.Platform,
+		Sliders:  []dtos.SliderStats{},
+	}
+
+	// stats are ordered by slider, variant and day; days sum the variants of a
+	// slider and days without events are filled with zeros
+	bySlider := make(map[int64]map[string]models.SliderStat)
+	variants := make(map[int64][]dtos.SliderVariantStats)
+	var sliderIDs []int64
+	for _, stat := range stats {
+		if _, ok := bySlider[stat.SliderID]; !ok {
+			bySlider[stat.SliderID] = make(map[string]models.SliderStat)
+			sliderIDs = append(sliderIDs, stat.SliderID)
+		}
+		day := stat.Day.Format(time.DateOnly)
+		dayStat := bySlider[stat.SliderID][day]
+		dayStat.Impressions += stat.Impressions
+		dayStat.Clicks += stat.Clicks
+		bySlider[stat.SliderID][day] = dayStat
+
+		sliderVariants := variants[stat.SliderID]
+		if n := len(sliderVariants); n == 0 || sliderVariants[n-1].VariantID != stat.VariantID {
+			sliderVariants = append(sliderVariants, dtos.SliderVariantStats{VariantID: stat.VariantID, Name: stat.VariantName})
+		}
+		sliderVariants[len(sliderVariants)-1].Impressions += stat.Impressions
+		sliderVariants[len(sliderVariants)-1].Clicks += stat.Clicks
+		variants[stat.SliderID] = sliderVariants
+	}
+
+	for _, sliderID := range sliderIDs {
+		sliderStats := dtos.SliderStats{SliderID: sliderID, Variants: variants[sliderID]}
+		for i := 0; i < days; i++ {
+			day := req.From.AddDate(0, 0, i).Format(time.DateOnly)
+			stat := bySlider[sliderID][day]
+			sliderStats.Days = append(sliderStats.Days, dtos.SliderDayStats{
+				Day:         day,
+				Impressions: stat.Impressions,
+				Clicks:      stat.Clicks,
+				CTR:         clickThroughRate(stat.Clicks, stat.Impressions),
+			})
+			sliderStats.Impressions += stat.Impressions
+			sliderStats.Clicks += stat.Clicks
+		}
+		sliderStats.CTR = clickThroughRate(sliderStats.Clicks, sliderStats.Impressions)
+		for i := range sliderStats.Variants {
+			sliderStats.Variants[i].CTR = clickThroughRate(sliderStats.Variants[i].Clicks, sliderStats.Variants[i].Impressions)
+		}
+
+		result.Impressions += sliderStats.Impressions
+		result.Clicks += sliderStats.Clicks
+		result.Sliders = append(result.Sliders, sliderStats)
+	}
+	result.CTR = clickThroughRate(result.Clicks, result.Impressions)
+	return result, nil
+}
+
+// clickThroughRate returns clicks per impression rounded to four decimals.
+func clickThroughRate(clicks, impressions int64) float64 {
+	if impressions == 0 {
+		return 0
+	}
+	return math.Round(float64(clicks)/float64(impressions)*10000) / 10000
+}
+
+// checkSliderImages makes sure the platform exists and is active and that every
+// image meets the platform's resolution and aspect ratio requirements.
+func (s *SlidersService) checkSliderImages(ctx context.Context, code string, imagePaths map[string]string) error {
+	platform, err := s.repo.GetPlatformByCode(ctx, code)
+	if err != nil {
+		return err
+	}
+	if !platform.IsActive {
+		return fmt.Errorf("%w: platform %q is inactive", helpers.ErrInvalidReference, code)
+	}
+
+	locales := make([]string, 0, len(imagePaths))
+	for locale := range imagePaths {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	for _, locale := range locales {
+		// an empty path removes the locale's image
+		if imagePaths[locale] == "" {
+			continue
+		}
+		width, height, err := helpers.ImageSize(imagePaths[locale])
+		if err != nil {
+			return fmt.Errorf("image %s: %w", locale, err)
+		}
+		err = helpers.CheckImageSize(width, height, int(platform.MinWidth), int(platform.MinHeight),
+			int(platform.AspectWidth), int(platform.AspectHeight))
+		if err != nil {
+			return fmt.Errorf("image %s: %w", locale, err)
+		}
+	}
+	return nil
+}
+
+func normalizePlatform(platform string) string {
+	return strings.ToLower(strings.TrimSpace(platform))
+}
+
+func validateSliderSchedule(startsAt, endsAt *time.Time) error {
+	if startsAt != nil && endsAt != nil && !endsAt.After(*startsAt) {
+		return fmt.Errorf("%w: ends_at must be after starts_at", helpers.ErrInvalidSchedule)
+	}
+	return nil
+}
+
+func toSliderDTOs(sliders []models.Slider) []dtos.Slider {
+	var dtoSliders []dtos.Slider
+	for _, b := range sliders {
+		dtoSliders = append(dtoSliders, dtos.Slider{
+			ID:          b.ID,
+			ImagePathTM: b.ImagePathTM,
+			ImagePathEN: b.ImagePathEN,
+			ImagePathRU: b.ImagePathRU,
+			ImagePaths:  helpers.LocalizedValues(b.ImagePaths, b.ImagePathTM, b.ImagePathEN, b.ImagePathRU),
+			Platform:    b.Platform,
+			StartsAt:    b.StartsAt,
+			EndsAt:      b.EndsAt,
+			IsActive:    b.IsActive,
+			Position:    b.Position,
+			Target:      toSliderTargetDTO(b.Target),
+		})
+	}
+	return dtoSliders
+}
+
+// sliderTarget validates the click target fields of a slider request and keeps
+// only the fields that belong to the target type.
+func sliderTarget(targetType string, targetID *int64, targetURL string, targetURLs map[string]string, screen string) (models.SliderTarget, error) {
+	target := models.SliderTarget{Type: targetType}
+	if target.Type == "" {
+		target.Type = models.SliderTargetNone
+	}
+
+	switch target.Type {
+	case models.SliderTargetNone:
+	case models.SliderTargetURL:
+		if err := helpers.ValidateLocales(targetURLs); err != nil {
+			return target, err
+		}
+		if targetURL == "" && len(targetURLs) == 0 {
+			return target, fmt.Errorf("%w: target_url is required for url targets", helpers.ErrInvalidTarget)
+		}
+		target.URL = targetURL
+		target.URLs = make(map[string]string)
+		for locale, link := range targetURLs {
+			if link != "" {
+				target.URLs[locale] = link
+			}
+		}
+		links := []string{target.URL}
+		for _, link := range target.URLs {
+			links = append(links, link)
+		}
+		for _, link := range links {
+			if link == "" {
+				continue
+			}
+			if u, err := url.ParseRequestURI(link); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return target, fmt.Errorf("%w: %q is not an http(s) url", helpers.ErrInvalidTarget, link)
+			}
+		}
+	case models.SliderTargetBrand, models.SliderTargetModel, models.SliderTargetAutoStore:
+		if targetID == nil {
+			return target, fmt.Errorf("%w: target_id is required for %s targets", helpers.ErrInvalidTarget, target.Type)
+		}
+		target.ID = targetID
+	case models.SliderTargetScreen:
+		if !screenPattern.MatchString(screen) {
+			return target, fmt.Errorf("%w: %q is not a valid screen", helpers.ErrInvalidTarget, screen)
+		}
+		target.Screen = screen
+	default:
+		return target, fmt.Errorf("%w: unknown target type %q", helpers.ErrInvalidTarget, target.Type)
+	}
+	return target, nil
+}
+
+func toSliderTargetDTO(target models.SliderTarget) dtos.SliderTarget {
+	result := dtos.SliderTarget{
+		Type:   target.Type,
+		ID:     target.ID,
+		Name:   target.Name,
+		URL:    target.URL,
+		Screen: target.Screen,
+	}
+	if target.Type == models.SliderTargetURL {
+		result.URLs = make(map[string]string)
+		for _, locale := range helpers.SupportedLocales() {
+			result.URLs[locale] = target.URL
+			if link := target.URLs[locale]; link != "" {
+				result.URLs[locale] = link
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"autotm-admin/internal/dtos"
+	"autotm-admin/internal/helpers"
+	"autotm-admin/internal/models"
+	"context"
+	"hash/fnv"
+	"strconv"
+)
+
+func (s *SlidersService) CreateSliderVariant(ctx context.Context, variant dtos.CreateSliderVariantReq) (int64, error) {
+	imagePaths := helpers.LocalizedValues(variant.ImagePaths, variant.ImagePathTM, variant.ImagePathEN, variant.ImagePathRU)
+	variant.ImagePathTM, variant.ImagePathEN, variant.ImagePathRU = imagePaths["tm"], imagePaths["en"], imagePaths["ru"]
+
+	validate := helpers.GetValidator()
+	if err := validate.Struct(variant); err != nil {
+		s.logger.Errorf("validate err: %v", err)
+		return 0, err
+	}
+	if err := helpers.ValidateLocales(imagePaths); err != nil {
+		s.logger.Errorf("validate locales err: %v", err)
+		return 0, err
+	}
+
+	slider, err := s.repo.GetSliderByID(ctx, variant.SliderID)
+	if err != nil {
+		s.logger.Errorf("get slider err: %v", err)
+		return 0, err
+	}
+	if err = s.checkSliderImages(ctx, slider.Platform, imagePaths); err != nil {
+		s.logger.Errorf("check slider variant images err: %v", err)
+		return 0, err
+	}
+
+	weight := int64(1)
+	if variant.Weight != nil {
+		weight = *variant.Weight
+	}
+
+	id, err := s.repo.CreateSliderVariant(ctx, models.SliderVariant{
+		SliderID:    variant.SliderID,
+		Name:        variant.Name,
+		Weight:      weight,
+		ImagePathTM: variant.ImagePathTM,
+		ImagePathEN: variant.ImagePathEN,
+		ImagePathRU: variant.ImagePathRU,
+		ImagePaths:  imagePaths,
+	})
+	if err != nil {
+		s.logger.Errorf("create slider variant err: %v", err)
+		return 0, err
+	}
+	return id, nil
+}
+
+func (s *SlidersService) GetSliderVariants(ctx context.Context, sliderID int64) ([]dtos.SliderVariant, error) {
+	variants, err := s.repo.GetSliderVariants(ctx, []int64{sliderID})
+	if err != nil {
+		s.logger.Errorf("get slider variants err: %v", err)
+		return nil, err
+	}
+
+	result := make([]dtos.SliderVariant, 0, len(variants))
+	for _, v := range variants {
+		result = append(result, dtos.SliderVariant{
+			ID:          v.ID,
+			SliderID:    v.SliderID,
+			Name:        v.Name,
+			Weight:      v.Weight,
+			ImagePathTM: v.ImagePathTM,
+			ImagePathEN: v.ImagePathEN,
+			ImagePathRU: v.ImagePathRU,
+			ImagePaths:  helpers.LocalizedValues(v.ImagePaths, v.ImagePathTM, v.ImagePathEN, v.ImagePathRU),
+		})
+	}
+	return result, nil
+}
+
+func (s *SlidersService) UpdateSliderVariant(ctx context.Context, variant dtos.UpdateSliderVariantReq) (int64, error) {
+	imagePaths := helpers.LocalizedValues(variant.ImagePaths, variant.ImagePathTM, variant.ImagePathEN, variant.ImagePathRU)
+	variant.ImagePathTM, variant.ImagePathEN, variant.ImagePathRU = imagePaths["tm"], imagePaths["en"], imagePaths["ru"]
+
+	validate := helpers.GetValidator()
+	if err := validate.Struct(variant); err != nil {
+		s.logger.Errorf("validate err: %v", err)
+		return 0, err
+	}
+	if err := helpers.ValidateLocales(imagePaths); err != nil {
+		s.logger.Errorf("validate locales err: %v", err)
+		return 0, err
+	}
+
+	oldVariant, err := s.repo.GetSliderVariantByID(ctx, variant.ID)
+	if err != nil {
+		s.logger.Errorf("get old slider variant err: %v", err)
+		return 0, err
+	}
+	slider, err := s.repo.GetSliderByID(ctx, oldVariant.SliderID)
+	if err != nil {
+		s.logger.Errorf("get slider err: %v", err)
+		return 0, err
+	}
+	if err = s.checkSliderImages(ctx, slider.Platform, imagePaths); err != nil {
+		s.logger.Errorf("check slider variant images err: %v", err)
+		return 0, err
+	}
+
+	newVariant := models.SliderVariant{
+		ID:          variant.ID,
+		Name:        variant.Name,
+		Weight:      oldVariant.Weight,
+		ImagePathTM: variant.ImagePathTM,
+		ImagePathEN: variant.ImagePathEN,
+		ImagePathRU: variant.ImagePathRU,
+		ImagePaths:  imagePaths,
+	}
+	if variant.Weight != nil {
+		newVariant.Weight = *variant.Weight
+	}
+
+	id, err := s.repo.UpdateSliderVariant(ctx, newVariant)
+	if err != nil {
+		s.logger.Errorf("update slider variant err: %v", err)
+		return 0, err
+	}
+
+	oldImagePaths := helpers.LocalizedValues(oldVariant.ImagePaths, oldVariant.ImagePathTM, oldVariant.ImagePathEN, oldVariant.ImagePathRU)
+	s.deleteVariantImages(oldVariant, helpers.MergeLocalizedValues(oldImagePaths, imagePaths))
+	return id, nil
+}
+
+func (s *SlidersService) DeleteSliderVariant(ctx context.Context, id int64) error {
+	variant, err := s.repo.GetSliderVariantByID(ctx, id)
+	if err != nil {
+		s.logger.Errorf("get slider variant err: %v", err)
+		return err
+	}
+
+	if err = s.repo.DeleteSliderVariant(ctx, models.ID{ID: id}); err != nil {
+		s.logger.Errorf("delete slider variant err: %v", err)
+		return err
+	}
+
+	s.deleteVariantImages(variant, nil)
+	return nil
+}
+
+// PromoteSliderVariant makes a variant the slider's own creative and ends the
+// experiment by removing all variants. Images no longer used are deleted.
+func (s *SlidersService) PromoteSliderVariant(ctx context.Context, id int64) error {
+	promoted, err := s.repo.GetSliderVariantByID(ctx, id)
+	if err != nil {
+		s.logger.Errorf("get slider variant err: %v", err)
+		return err
+	}
+	slider, err := s.repo.GetSliderByID(ctx, promoted.SliderID)
+	if err != nil {
+		s.logger.Errorf("get slider err: %v", err)
+		return err
+	}
+	variants, err := s.repo.GetSliderVariants(ctx, []int64{slider.ID})
+	if err != nil {
+		s.logger.Errorf("get slider variants err: %v", err)
+		return err
+	}
+
+	if err = s.repo.PromoteSliderVariant(ctx, id); err != nil {
+		s.logger.Errorf("promote slider variant err: %v", err)
+		return err
+	}
+
+	keep := helpers.LocalizedValues(promoted.ImagePaths, promoted.ImagePathTM, promoted.ImagePathEN, promoted.ImagePathRU)
+	s.deleteVariantImages(models.SliderVariant{
+		ImagePathTM: slider.ImagePathTM,
+		ImagePathEN: slider.ImagePathEN,
+		ImagePathRU: slider.ImagePathRU,
+		ImagePaths:  slider.ImagePaths,
+	}, keep)
+	for _, variant := range variants {
+		s.deleteVariantImages(variant, keep)
+	}
+	return nil
+}
+
+// deleteVariantImages removes the image files of a variant that are not among keep.
+func (s *SlidersService) deleteVariantImages(variant models.SliderVariant, keep map[string]string) {
+	kept := make(map[string]bool, len(keep))
+	for _, path := range keep {
+		kept[path] = true
+	}
+
+	imagePaths := helpers.LocalizedValues(variant.ImagePaths, variant.ImagePathTM, variant.ImagePathEN, variant.ImagePathRU)
+	for locale, path := range imagePaths {
+		if path == "" || kept[path] {
+			continue
+		}
+		if err := helpers.DeleteImage(path); err != nil {
+			s.logger.Errorf("delete variant image path %s err: %v", locale, err)
+		}
+	}
+}
+
+// pickSliderVariant chooses a variant for a client by hashing the client and
+// slider IDs into the variants' cumulative weights. It reports false when the
+// slider has no variant with a positive weight.
+func pickSliderVariant(clientID string, sliderID int64, variants []models.SliderVariant) (models.SliderVariant, bool) {
+	var total uint64
+	for _, variant := range variants {
+		if variant.Weight > 0 {
+			total += uint64(variant.Weight)
+		}
+	}
+	if total == 0 {
+		return models.SliderVariant{}, false
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(clientID + ":" + strconv.FormatInt(sliderID, 10)))
+	point := h.Sum64() % total
+
+	for _, variant := range variants {
+		if variant.Weight <= 0 {
+			continue
+		}
+		if point < uint64(variant.Weight) {
+			return variant, true
+		}
+		point -= uint64(variant.Weight)
+	}
+	return models.SliderVariant{}, false
+}
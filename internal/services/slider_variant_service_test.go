@@ -0,0 +1,78 @@
+package services
+
+import (
+	"autotm-admin/internal/models"
+	"strconv"
+	"testing"
+)
+
+func TestPickSliderVariant(t *testing.T) {
+	tests := []struct {
+		name     string
+		variants []models.SliderVariant
+		wantOK   bool
+	}{
+		{name: "no variants", variants: nil, wantOK: false},
+		{name: "zero weights", variants: []models.SliderVariant{{ID: 1, Weight: 0}, {ID: 2, Weight: 0}}, wantOK: false},
+		{name: "negative weight", variants: []models.SliderVariant{{ID: 1, Weight: -5}}, wantOK: false},
+		{name: "single variant", variants: []models.SliderVariant{{ID: 1, Weight: 10}}, wantOK: true},
+		{name: "zero weight is never served", variants: []models.SliderVariant{{ID: 1, Weight: 0}, {ID: 2, Weight: 3}}, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				got, ok := pickSliderVariant("client-"+strconv.Itoa(i), 7, tt.variants)
+				if ok != tt.wantOK {
+					t.Fatalf("pickSliderVariant ok = %v, want %v", ok, tt.wantOK)
+				}
+				if ok && got.Weight <= 0 {
+					t.Fatalf("pickSliderVariant served variant %d with weight %d", got.ID, got.Weight)
+				}
+			}
+		})
+	}
+}
+
+func TestPickSliderVariantIsDeterministic(t *testing.T) {
+	variants := []models.SliderVariant{{ID: 1, Weight: 1}, {ID: 2, Weight: 1}, {ID: 3, Weight: 1}}
+
+	for i := 0; i < 50; i++ {
+		clientID := "client-" + strconv.Itoa(i)
+		first, _ := pickSliderVariant(clientID, 42, variants)
+		for j := 0; j < 5; j++ {
+			got, _ := pickSliderVariant(clientID, 42, variants)
+			if got.ID != first.ID {
+				t.Fatalf("client %s got variant %d, then %d", clientID, first.ID, got.ID)
+			}
+		}
+	}
+}
+
+func TestPickSliderVariantDistribution(t *testing.T) {
+	variants := []models.SliderVariant{{ID: 1, Weight: 1}, {ID: 2, Weight: 3}, {ID: 3, Weight: 0}, {ID: 4, Weight: 6}}
+	const clients = 20000
+
+	counts := make(map[int64]int)
+	for i := 0; i < clients; i++ {
+		got, ok := pickSliderVariant("client-"+strconv.Itoa(i), 9, variants)
+		if !ok {
+			t.Fatalf("pickSliderVariant returned no variant")
+		}
+		counts[got.ID]++
+	}
+
+	if counts[3] != 0 {
+		t.Errorf("variant with zero weight served %d times", counts[3])
+	}
+	for _, variant := range variants {
+		if variant.Weight == 0 {
+			continue
+		}
+		want := float64(clients) * float64(variant.Weight) / 10
+		got := float64(counts[variant.ID])
+		if got < want*0.9 || got > want*1.1 {
+			t.Errorf("variant %d served %v times, want about %v", variant.ID, got, want)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"autotm-admin/internal/dtos"
+	"autotm-admin/internal/helpers"
+	"autotm-admin/internal/models"
+	"autotm-admin/internal/repository/storage"
+	"context"
+	slog "github.com/salamsites/package-log"
+	"strings"
+	"time"
+)
+
+// expiryJobInterval is how often RunExpiryJob re-checks the stores.
+const expiryJobInterval = 24 * time.Hour
+
+type SubscriptionService struct {
+	logger *slog.Logger
+	repo   storage.SubscriptionRepository
+}
+
+func NewSubscriptionService(logger *slog.Logger, repo storage.SubscriptionRepository) *SubscriptionService {
+	return &SubscriptionService{
+		logger: logger,
+		repo:   repo,
+	}
+}
+
+func (s *SubscriptionService) CreateSubscriptionPlan(ctx context.Context, plan dtos.CreateSubscriptionPlanReq) (dtos.ID, error) {
+	var id dtos.ID
+	plan.Name = strings.TrimSpace(plan.Name)
+	plan.Currency = strings.ToUpper(strings.TrimSpace(plan.Currency))
+
+	validate := helpers.GetValidator()
+	if err := validate.Struct(plan); err != nil {
+		s.logger.Errorf("validate err: %v", err)
+		return id, err
+	}
+
+	isActive := true
+	if plan.IsActive != nil {
+		isActive = *plan.IsActive
+	}
+
+	planID, err := s.repo.CreateSubscriptionPlan(ctx, models.SubscriptionPlan{
+		Name:             plan.Name,
+		Price:            plan.Price,
+		Currency:         planCurrency(plan.Currency),
+		DurationDays:     plan.DurationDays,
+		MaxGalleryImages: plan.MaxGalleryImages,
+		IsActive:         isActive,
+	})
+	if err != nil {
+		s.logger.Errorf("create subscription plan err: %v", err)
+		return id, err
+	}
+
+	id.ID = planID
+	return id, nil
+}
+
+func (s *SubscriptionService) GetSubscriptionPlans(ctx context.Context, activeOnly bool) ([]dtos.SubscriptionPlan, error) {
+	plans, err := s.repo.GetSubscriptionPlans(ctx, activeOnly)
+	if err != nil {
+		s.logger.Errorf("get subscription plans err: %v", err)
+		return nil, err
+	}
+
+	result := make([]dtos.SubscriptionPlan, 0, len(plans))
+	for _, p := range plans {
+		result = append(result, dtos.SubscriptionPlan{
+			ID:               p.ID,
+			Name:             p.Name,
+			Price:            p.Price,
+			Currency:         p.Currency,
+			DurationDays:     p.DurationDays,
+			MaxGalleryImages: p.MaxGalleryImages,
+			IsActive:         p.IsActive,
+		})
+	}
+	return result, nil
+}
+
+// UpdateSubscriptionPlan changes a plan. Running subscriptions keep their
+// dates; a new image limit applies to them at once.
+func (s *SubscriptionService) UpdateSubscriptionPlan(ctx context.Context, plan dtos.UpdateSubscriptionPlanReq) (dtos.ID, error) {
+	var id dtos.ID
+	plan.Name = strings.TrimSpace(plan.Name)
+	plan.Currency = strings.ToUpper(strings.TrimSpace(plan.Currency))
+
+	validate := helpers.GetValidator()
+	if err := validate.Struct(plan); err != nil {
+		s.logger.Errorf("validate err: %v", err)
+		return id, err
+	}
+
+	planID, err := s.repo.UpdateSubscriptionPlan(ctx, models.SubscriptionPlan{
+		ID:               plan.ID,
+		Name:             plan.Name,
+		Price:            plan.Price,
+		Currency:         planCurrency(plan.Currency),
+		DurationDays:     plan.DurationDays,
+		MaxGalleryImages: plan.MaxGalleryImages,
+		IsActive:         *plan.IsActive,
+	})
+	if err != nil {
+		s.logger.Errorf("update subscription plan err: %v", err)
+		return id, err
+	}
+
+	id.ID = planID
+	return id, nil
+}
+
+func (s *SubscriptionService) DeleteSubscriptionPlan(ctx context.Context, id int64) error {
+	if err := s.repo.DeleteSubscriptionPlan(ctx, models.ID{ID: id}); err != nil {
+		s.logger.Errorf("delete subscription plan err: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (s *SubscriptionService) AssignSubscription(ctx context.Context, req dtos.AssignSubscriptionReq) (dtos.ID, error) {
+	var id dtos.ID
+
+	validate := helpers.GetValidator()
+	if err := validate.Struct(req); err != nil {
+		s.logger.Errorf("validate err: %v", err)
+		return id, err
+	}
+
+	subscriptionID, err := s.repo.AssignSubscription(ctx, req.AutoStoreID, req.PlanID, req.StartsAt)
+	if err != nil {
+		s.logger.Errorf("assign subscription err: %v", err)
+		return id, err
+	}
+
+	id.ID = subscriptionID
+	return id, nil
+}
+
+func (s *SubscriptionService) ExtendSubscription(ctx context.Context, req dtos.ExtendSubscriptionReq) error {
+	validate := helpers.GetValidator()
+	if err := validate.Struct(req); err != nil {
+		s.logger.Errorf("validate err: %v", err)
+		return err
+	}
+
+	if err := s.repo.ExtendSubscription(ctx, req.SubscriptionID, req.Days); err != nil {
+		s.logger.Errorf("extend subscription err: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (s *SubscriptionService) CancelSubscription(ctx context.Context, id int64) error {
+	if err := s.repo.CancelSubscription(ctx, id); err != nil {
+		s.logger.Errorf("cancel subscription err: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (s *SubscriptionService) GetStoreSubscriptions(ctx context.Context, autoStoreID int64) ([]dtos.StoreSubscription, error) {
+	subscriptions, err := s.repo.GetStoreSubscriptions(ctx, autoStoreID)
+	if err != nil {
+		s.logger.Errorf("get store subscriptions err: %v", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	result := make([]dtos.StoreSubscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		result = append(result, dtos.StoreSubscription{
+			ID:          subscription.ID,
+			AutoStoreID: subscription.AutoStoreID,
+			PlanID:      subscription.PlanID,
+			PlanName:    subscription.PlanName,
+			StartsAt:    subscription.StartsAt,
+			EndsAt:      subscription.EndsAt,
+			Status:      subscriptionStatus(subscription, now),
+			CancelledAt: subscription.CancelledAt,
+			CreatedAt:   subscription.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// RunExpiryJob marks stores whose subscriptions have lapsed as expired, once
+// at start and then daily, until ctx is done. Assigning, extending and
+// cancelling a subscription update the store at once; the job catches the
+// subscriptions that simply run out. The store queries work out expiry from
+// the subscriptions themselves, so the stored flag may lag by up to a day
+// without a lapsed store being listed.
+func (s *SubscriptionService) RunExpiryJob(ctx context.Context) {
+	ticker := time.NewTicker(expiryJobInterval)
+	defer ticker.Stop()
+
+	for {
+		changed, err := s.repo.RefreshExpiredAutoStores(ctx)
+		if err != nil {
+			s.logger.Errorf("refresh expired auto stores err: %v", err)
+		} else if changed > 0 {
+			s.logger.Infof("subscription expiry changed %d auto stores", changed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// subscriptionStatus reports a stored subscription status as seen at now.
+func subscriptionStatus(subscription models.StoreSubscription, now time.Time) string {
+	if subscription.Status != models.SubscriptionStatusActive {
+		return subscription.Status
+	}
+	if now.Before(subscription.StartsAt) {
+		return models.SubscriptionStatusScheduled
+	}
+	if !now.Before(subscription.EndsAt) {
+		return models.SubscriptionStatusExpired
+	}
+	return models.SubscriptionStatusActive
+}
+
+// planCurrency defaults an empty currency to Turkmen manat.
+func planCurrency(currency string) string {
+	if currency == "" {
+		return "TMT"
+	}
+	return currency
+}
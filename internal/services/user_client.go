@@ -0,0 +1,110 @@
+package services
+
+import (
+	"autotm-admin/internal/dtos"
+	"sync"
+	"time"
+)
+
+// circuitBreaker stops calls to a failing service. It opens after threshold
+// failed calls in a row and lets calls through again once cooldown has passed.
+// The next failure reopens it at once; a success closes it.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+// allow reports whether a call may be made now.
+func (b *circuitBreaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.threshold <= 0 || !now.Before(b.openUntil)
+}
+
+func (b *circuitBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.openUntil = time.Time{}
+}
+
+func (b *circuitBreaker) failure(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.threshold > 0 && b.failures >= b.threshold {
+		b.openUntil = now.Add(b.cooldown)
+	}
+}
+
+type cachedUser struct {
+	user    dtos.GetUsers
+	expires time.Time
+}
+
+// userCache keeps users returned by the user service for a short time.
+type userCache struct {
+	mu    sync.Mutex
+	ttl   time.Duration
+	users map[int64]cachedUser
+}
+
+func newUserCache(ttl time.Duration) *userCache {
+	return &userCache{
+		ttl:   ttl,
+		users: make(map[int64]cachedUser),
+	}
+}
+
+// get returns the cached users among ids and the ids that still have to be fetched.
+func (c *userCache) get(ids []int64, now time.Time) ([]dtos.GetUsers, []int64) {
+	if c.ttl <= 0 {
+		return nil, ids
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var (
+		users   []dtos.GetUsers
+		missing []int64
+	)
+	for _, id := range ids {
+		cached, ok := c.users[id]
+		if !ok || now.After(cached.expires) {
+			delete(c.users, id)
+			missing = append(missing, id)
+			continue
+		}
+		users = append(users, cached.user)
+	}
+	return users, missing
+}
+
+func (c *userCache) put(users []dtos.GetUsers, now time.Time) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for id, cached := range c.users {
+		if now.After(cached.expires) {
+			delete(c.users, id)
+		}
+	}
+	for _, user := range users {
+		c.users[user.Id] = cachedUser{user: user, expires: now.Add(c.ttl)}
+	}
+}
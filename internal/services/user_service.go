@@ -3,6 +3,7 @@ package services
 import (
 	"autotm-admin/internal/configs"
 	"autotm-admin/internal/dtos"
+	"autotm-admin/internal/helpers"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -14,15 +15,31 @@ import (
 	"time"
 )
 
+// UserService calls the user service over one shared HTTP client. Failed calls
+// are retried, repeated failures open a circuit breaker, and users looked up by
+// ID are cached briefly. Calls that cannot reach the service return an error
+// wrapping helpers.ErrUserServiceUnavailable.
 type UserService struct {
-	logger *slog.Logger
-	cfg    *configs.Config
+	logger  *slog.Logger
+	cfg     *configs.Config
+	client  *http.Client
+	breaker *circuitBreaker
+	cache   *userCache
 }
 
 func NewUserService(cfg *configs.Config, logger *slog.Logger) *UserService {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = 20
+
 	return &UserService{
 		logger: logger,
 		cfg:    cfg,
+		client: &http.Client{
+			Timeout:   cfg.UserClient.Timeout,
+			Transport: transport,
+		},
+		breaker: newCircuitBreaker(cfg.UserClient.BreakerThreshold, cfg.UserClient.BreakerCooldown),
+		cache:   newUserCache(cfg.UserClient.CacheTTL),
 	}
 }
 
@@ -31,34 +48,12 @@ func (s *UserService) GetUsers(ctx context.Context, limit, page int64, search st
 
 	urlUser := fmt.Sprintf("%s/users/get-users?limit=%d&page=%d&search=%s", s.cfg.UserServiceURL, limit, page, searchParam)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlUser, nil)
-	if err != nil {
-		s.logger.Errorf("failed to build request: %v", err)
-		return nil, 0, err
-	}
-
-	client := &http.Client{
-		Timeout: 30 * time.Second,
-	}
-
-	resp, err := client.Do(req)
+	bodyBytes, err := s.do(ctx, func() (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodGet, urlUser, nil)
+	})
 	if err != nil {
-		s.logger.Errorf("http request failed: %v", err)
 		return nil, 0, err
 	}
-	defer resp.Body.Close()
-
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		s.logger.Errorf("failed to read response body: %v", err)
-		return nil, 0, err
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		errMsg := fmt.Sprintf("unexpected status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
-		s.logger.Error(errMsg)
-		return nil, 0, fmt.Errorf(errMsg)
-	}
 
 	var responseWrapper struct {
 		Data dtos.GetUserResult `json:"Data"`
@@ -74,44 +69,108 @@ func (s *UserService) GetUsers(ctx context.Context, limit, page int64, search st
 	return users, count, nil
 }
 
+// GetUserByIds returns the users with the given IDs. Cached users are not
+// requested again; unknown IDs are left out of the result.
 func (s *UserService) GetUserByIds(ctx context.Context, ids dtos.GetUserByIDsReq) ([]dtos.GetUsers, error) {
+	users, missing := s.cache.get(ids.Ids, time.Now())
+	if len(missing) == 0 {
+		return users, nil
+	}
+
 	urlUser := fmt.Sprintf("%s/users/get-by-ids", s.cfg.UserServiceURL)
 
-	body, err := json.Marshal(ids)
+	body, err := json.Marshal(dtos.GetUserByIDsReq{Ids: missing})
 	if err != nil {
 		s.logger.Errorf("failed to build request: %s", err)
 		return nil, err
 	}
-	req, err := http.NewRequestWithContext(ctx, "POST", urlUser, bytes.NewBuffer(body))
-	if err != nil {
-		s.logger.Errorf("failed to create request: %s", err)
-		return nil, err
-	}
-	req.Header.Set("Content-Type", "application/json")
-	client := http.Client{
-		Timeout: 30 * time.Second,
-	}
-	resp, err := client.Do(req)
+
+	bodyBytes, err := s.do(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, urlUser, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
 	if err != nil {
-		s.logger.Errorf("http request failed: %s", err)
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		s.logger.Errorf("unexpected status: %d", resp.StatusCode)
-		return nil, fmt.Errorf("users not found")
-	}
 
 	var result struct {
 		Data []dtos.GetUsers `json:"Data"`
 	}
 
-	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err = json.Unmarshal(bodyBytes, &result); err != nil {
 		s.logger.Errorf("failed to decode response: %s", err)
 		return nil, err
 	}
 
-	users := result.Data
+	s.cache.put(result.Data, time.Now())
+	users = append(users, result.Data...)
 	return users, nil
 }
+
+// do sends the request built by newRequest and returns the response body of a
+// 200 response. Transport errors and 5xx responses are retried with exponential
+// backoff and count as one breaker failure once the retries are used up; other
+// statuses are returned at once.
+func (s *UserService) do(ctx context.Context, newRequest func() (*http.Request, error)) ([]byte, error) {
+	if !s.breaker.allow(time.Now()) {
+		return nil, fmt.Errorf("%w: circuit breaker is open", helpers.ErrUserServiceUnavailable)
+	}
+
+	backoff := s.cfg.UserClient.RetryBackoff
+	var lastErr error
+	for attempt := 0; attempt <= s.cfg.UserClient.Retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		req, err := newRequest()
+		if err != nil {
+			s.logger.Errorf("failed to build request: %v", err)
+			return nil, err
+		}
+
+		resp, err := s.client.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			s.logger.Errorf("http request failed (attempt %d): %v", attempt+1, err)
+			lastErr = err
+			continue
+		}
+
+		bodyBytes, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			s.logger.Errorf("failed to read response body (attempt %d): %v", attempt+1, err)
+			lastErr = err
+			continue
+		}
+
+		if resp.StatusCode >= http.StatusInternalServerError {
+			lastErr = fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
+			s.logger.Errorf("user service error (attempt %d): %v", attempt+1, lastErr)
+			continue
+		}
+
+		s.breaker.success()
+		if resp.StatusCode != http.StatusOK {
+			err = fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
+			s.logger.Error(err.Error())
+			return nil, err
+		}
+		return bodyBytes, nil
+	}
+
+	s.breaker.failure(time.Now())
+	return nil, fmt.Errorf("%w: %v", helpers.ErrUserServiceUnavailable, lastErr)
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"autotm-admin/internal/configs"
+	"autotm-admin/internal/dtos"
+	"autotm-admin/internal/helpers"
+	"autotm-admin/internal/userstub"
+	"context"
+	"encoding/json"
+	"errors"
+	slog "github.com/salamsites/package-log"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// The payloads below are the user service responses UserService is written
+// against. A change here is a change of the contract with that service.
+const (
+	getUsersPayload = `{
+		"Status": true,
+		"Message": "List of users",
+		"Data": {
+			"users": [
+				{"id": 1, "full_name": "Aman Berdiýew", "email": "aman@example.com", "phone_number": "+99365000001", "avatar": null},
+				{"id": 2, "full_name": null, "email": null, "phone_number": null, "avatar": "avatars/2.png"}
+			],
+			"count": 12
+		}
+	}`
+	getByIDsPayload = `{
+		"Status": true,
+		"Message": "Users",
+		"Data": [
+			{"id": 3, "full_name": "Merdan Annaýew", "email": "merdan@example.com", "phone_number": "+99361000003", "avatar": null}
+		]
+	}`
+)
+
+func newTestUserService(t *testing.T, url string) *UserService {
+	t.Helper()
+	cfg := &configs.Config{
+		UserServiceURL: url,
+		UserClient: configs.UserClient{
+			Timeout:          time.Second,
+			Retries:          1,
+			RetryBackoff:     time.Millisecond,
+			BreakerThreshold: 2,
+			BreakerCooldown:  time.Minute,
+			CacheTTL:         time.Minute,
+		},
+	}
+	return NewUserService(cfg, slog.GetLogger(t.TempDir(), "test.log"))
+}
+
+func TestUserServiceGetUsersContract(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/users/get-users" {
+			t.Errorf("request = %s %s, want GET /users/get-users", r.Method, r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("limit") != "10" || query.Get("page") != "20" || query.Get("search") != "aman berdi" {
+			t.Errorf("query = %s, want limit=10 page=20 search=aman berdi", r.URL.RawQuery)
+		}
+		io.WriteString(w, getUsersPayload)
+	}))
+	defer srv.Close()
+
+	users, count, err := newTestUserService(t, srv.URL).GetUsers(context.Background(), 10, 20, "aman berdi")
+	if err != nil {
+		t.Fatalf("GetUsers: %v", err)
+	}
+	if count != 12 {
+		t.Errorf("count = %d, want 12", count)
+	}
+	if len(users) != 2 {
+		t.Fatalf("got %d users, want 2", len(users))
+	}
+	if users[0].Id != 1 || users[0].FullName == nil || *users[0].FullName != "Aman Berdiýew" {
+		t.Errorf("users[0] = %+v, want Aman Berdiýew with ID 1", users[0])
+	}
+	if users[1].FullName != nil || users[1].Avatar == nil || *users[1].Avatar != "avatars/2.png" {
+		t.Errorf("users[1] = %+v, want no name and avatar avatars/2.png", users[1])
+	}
+}
+
+func TestUserServiceGetUserByIdsContract(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/users/get-by-ids" {
+			t.Errorf("request = %s %s, want POST /users/get-by-ids", r.Method, r.URL.Path)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", ct)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"ids":[3]}` {
+			t.Errorf("body = %s, want {\"ids\":[3]}", body)
+		}
+		io.WriteString(w, getByIDsPayload)
+	}))
+	defer srv.Close()
+
+	users, err := newTestUserService(t, srv.URL).GetUserByIds(context.Background(), dtos.GetUserByIDsReq{Ids: []int64{3}})
+	if err != nil {
+		t.Fatalf("GetUserByIds: %v", err)
+	}
+	if len(users) != 1 || users[0].Id != 3 || users[0].FullName == nil || *users[0].FullName != "Merdan Annaýew" {
+		t.Errorf("users = %+v, want Merdan Annaýew with ID 3", users)
+	}
+}
+
+func TestUserServiceUnavailable(t *testing.T) {
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	s := newTestUserService(t, srv.URL)
+	req := dtos.GetUserByIDsReq{Ids: []int64{1}}
+	for i := 0; i < 3; i++ {
+		_, err := s.GetUserByIds(context.Background(), req)
+		if !errors.Is(err, helpers.ErrUserServiceUnavailable) {
+			t.Fatalf("call %d: err = %v, want ErrUserServiceUnavailable", i+1, err)
+		}
+	}
+	// two calls with one retry each open the breaker; the third is not sent
+	if n := calls.Load(); n != 4 {
+		t.Errorf("server got %d requests, want 4", n)
+	}
+}
+
+// TestUserStubContract checks that the stub answers in the shape pinned above,
+// so the client behaves the same against the stub and the real service.
+func TestUserStubContract(t *testing.T) {
+	srv := httptest.NewServer(userstub.Handler(slog.GetLogger(t.TempDir(), "test.log")))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/users/get-users?limit=2&page=1")
+	if err != nil {
+		t.Fatalf("get-users: %v", err)
+	}
+	var list struct {
+		Data map[string]json.RawMessage `json:"Data"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&list)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatalf("decode get-users: %v", err)
+	}
+	if _, ok := list.Data["users"]; !ok {
+		t.Error(`get-users Data has no "users"`)
+	}
+	if _, ok := list.Data["count"]; !ok {
+		t.Error(`get-users Data has no "count"`)
+	}
+
+	s := newTestUserService(t, srv.URL)
+	users, count, err := s.GetUsers(context.Background(), 2, 1, "")
+	if err != nil {
+		t.Fatalf("GetUsers: %v", err)
+	}
+	if count != int64(len(userstub.Users)) {
+		t.Errorf("count = %d, want %d", count, len(userstub.Users))
+	}
+	if len(users) != 2 || users[0].Id != userstub.Users[1].Id {
+		t.Errorf("users = %+v, want 2 users from ID %d", users, userstub.Users[1].Id)
+	}
+
+	byIDs, err := s.GetUserByIds(context.Background(), dtos.GetUserByIDsReq{Ids: []int64{2, 999}})
+	if err != nil {
+		t.Fatalf("GetUserByIds: %v", err)
+	}
+	if len(byIDs) != 1 || byIDs[0].Id != 2 {
+		t.Errorf("GetUserByIds = %+v, want only user 2", byIDs)
+	}
+}
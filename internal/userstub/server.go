@@ -0,0 +1,125 @@
+// Package userstub is an in-process stand-in for the user service, serving
+// fixture users for local development and tests.
+package userstub
+
+import (
+	"autotm-admin/internal/dtos"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	shttp "github.com/salamsites/package-http"
+	slog "github.com/salamsites/package-log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Handler serves the user service endpoints the admin uses:
+//
+//	GET  /users/get-users?limit=&page=&search=  Data: {"users": [...], "count": n}
+//	POST /users/get-by-ids {"ids": [...]}        Data: [...]
+//
+// Every response is an shttp.Result, so the payload sits under "Data".
+// page is read as the number of users to skip, which is what services.UserService sends.
+func Handler(logger *slog.Logger) http.Handler {
+	r := chi.NewRouter()
+	middleware := shttp.NewMiddleware(logger, "", nil)
+	r.Method("GET", "/users/get-users", middleware.Base(getUsers))
+	r.Method("POST", "/users/get-by-ids", middleware.Base(getByIDs))
+	return r
+}
+
+func getUsers(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+
+	limit, err := strconv.ParseInt(r.URL.Query().Get("limit"), 10, 64)
+	if err != nil || limit <= 0 {
+		limit = 10
+	}
+	offset, err := strconv.ParseInt(r.URL.Query().Get("page"), 10, 64)
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	search := strings.ToLower(r.URL.Query().Get("search"))
+
+	var matched []dtos.GetUsers
+	for _, user := range Users {
+		if search == "" || strings.Contains(strings.ToLower(*user.FullName), search) ||
+			strings.Contains(strings.ToLower(*user.Email), search) || strings.Contains(*user.PhoneNumber, search) {
+			matched = append(matched, user)
+		}
+	}
+
+	page := []dtos.GetUsers{}
+	if offset < int64(len(matched)) {
+		page = matched[offset:min(offset+limit, int64(len(matched)))]
+	}
+
+	result.Status = true
+	result.Message = "List of users"
+	result.Data = dtos.GetUserResult{
+		Users: page,
+		Count: int64(len(matched)),
+	}
+	return shttp.Success.SetData(result)
+}
+
+func getByIDs(w http.ResponseWriter, r *http.Request) shttp.Response {
+	var result shttp.Result
+
+	var req dtos.GetUserByIDsReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		result.Message = err.Error()
+		return shttp.BadRequest.SetData(result)
+	}
+
+	wanted := make(map[int64]struct{}, len(req.Ids))
+	for _, id := range req.Ids {
+		wanted[id] = struct{}{}
+	}
+	users := []dtos.GetUsers{}
+	for _, user := range Users {
+		if _, ok := wanted[user.Id]; ok {
+			users = append(users, user)
+		}
+	}
+
+	result.Status = true
+	result.Message = "Users"
+	result.Data = users
+	return shttp.Success.SetData(result)
+}
+
+// Server is a running stub listening on a local port.
+type Server struct {
+	URL string
+	srv *http.Server
+}
+
+// Start serves Handler on a free port of 127.0.0.1 until Close is called.
+func Start(logger *slog.Logger) (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		URL: "http://" + listener.Addr().String(),
+		srv: &http.Server{
+			Handler:           Handler(logger),
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+	}
+	go func() {
+		if err := s.srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Errorf("user service stub: %v", err)
+		}
+	}()
+	return s, nil
+}
+
+func (s *Server) Close() error {
+	return s.srv.Close()
+}
@@ -0,0 +1,23 @@
+package userstub
+
+import "autotm-admin/internal/dtos"
+
+// Users are the fixture users served by the stub, in ID order.
+var Users = []dtos.GetUsers{
+	newUser(1, "Aman Berdiýew", "aman@example.com", "+99365000001"),
+	newUser(2, "Maýsa Orazowa", "maysa@example.com", "+99365000002"),
+	newUser(3, "Merdan Annaýew", "merdan@example.com", "+99361000003"),
+	newUser(4, "Jeren Gurbanowa", "jeren@example.com", "+99362000004"),
+	newUser(5, "Serdar Hojaýew", "serdar@example.com", "+99363000005"),
+	newUser(6, "Ogulnabat Rejepowa", "ogulnabat@example.com", "+99364000006"),
+	newUser(7, "Dovlet Nurmuhammedow", "dovlet@example.com", "+99365000007"),
+}
+
+func newUser(id int64, fullName, email, phoneNumber string) dtos.GetUsers {
+	return dtos.GetUsers{
+		Id:          id,
+		FullName:    &fullName,
+		Email:       &email,
+		PhoneNumber: &phoneNumber,
+	}
+}